@@ -19,8 +19,11 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	nethttp "net/http"
+	"os"
+	"strconv"
 
 	_ "github.com/PraveenGongada/shortly/api"
 	logfield "github.com/PraveenGongada/shortly/internal/domain/shared/logger"
@@ -60,15 +63,37 @@ func main() {
 	// Initialize domain logger adapter
 	domainLogger := zerolog.NewWithLogger(logger)
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(domainLogger, os.Args[2:]))
+	}
+
 	// Initialize the complete application using Wire
-	app, err := wireProviders.InitializeApplication(domainLogger)
+	databaseConfig := config.NewDatabaseConfigAdapter(cfg, config.GetGlobalSecrets())
+	var app *wireProviders.Application
+	var err error
+	if databaseConfig.Storage() == config.StorageMemory {
+		domainLogger.Warn(context.Background(), "Using in-memory storage; data is lost on shutdown")
+		app, err = wireProviders.InitializeMemoryApplication(domainLogger)
+	} else {
+		app, err = wireProviders.InitializeApplication(domainLogger)
+	}
 	if err != nil {
 		log.Fatalf("Failed to initialize application: %v", err)
 	}
 
+	if app.Migrator != nil && databaseConfig.MigrateOnStart() {
+		if _, err := app.Migrator.Up(context.Background(), 0); err != nil {
+			log.Fatalf("Failed to apply migrations: %v", err)
+		}
+	}
+
 	readiness := health.New()
-	readiness.Register("postgres", app.PostgresClient.HealthCheck)
-	readiness.Register("redis", app.RedisClient.HealthCheck)
+	if app.PostgresClient != nil {
+		readiness.Register("postgres", app.PostgresClient.HealthCheck)
+	}
+	if app.RedisClient != nil {
+		readiness.Register("redis", app.RedisClient.HealthCheck)
+	}
 
 	// Initialize HTTP layer (these remain manual as they're infrastructure wiring)
 	routerInstance := router.New(app.Handler, securityConfig, domainLogger)
@@ -81,27 +106,119 @@ func main() {
 		}
 	}()
 
-	// Set up graceful shutdown with proper context handling
-	graceful.GracefulShutdown(
+	// Set up graceful shutdown with proper context handling. Servers stop first so no new
+	// work is accepted, then background workers flush what they buffered, then the
+	// connections they write to are closed.
+	graceful.GracefulShutdownInStages(
 		func() error {
 			return server.Listen()
 		},
 		serverConfig.GracefulShutdownTimeout(),
-		map[string]graceful.Operation{
-			"http": func(ctx context.Context) error {
-				return server.Shutdown(ctx)
-			},
-			"admin": func(ctx context.Context) error {
-				return adminServer.Shutdown(ctx)
+		[]map[string]graceful.Operation{
+			{
+				"http": func(ctx context.Context) error {
+					return server.Shutdown(ctx)
+				},
+				"admin": func(ctx context.Context) error {
+					return adminServer.Shutdown(ctx)
+				},
 			},
-			"postgres": func(ctx context.Context) error {
-				app.PostgresClient.Close()
-				return nil
+			{
+				"clicks": func(ctx context.Context) error {
+					return app.ClickRecorder.Shutdown(ctx)
+				},
+				"redirects": func(ctx context.Context) error {
+					return app.RedirectFlusher.Shutdown(ctx)
+				},
+				"trash": func(ctx context.Context) error {
+					return app.TrashPurger.Shutdown(ctx)
+				},
+				"rescan": func(ctx context.Context) error {
+					return app.URLRescanner.Shutdown(ctx)
+				},
+				"blocklists": func(ctx context.Context) error {
+					return app.SafetyChecker.Shutdown(ctx)
+				},
 			},
-			"redis": func(ctx context.Context) error {
-				return app.RedisClient.Close()
+			{
+				"postgres": func(ctx context.Context) error {
+					if app.PostgresClient != nil {
+						app.PostgresClient.Close()
+					}
+					return nil
+				},
+				"redis": func(ctx context.Context) error {
+					if app.RedisClient == nil {
+						return nil
+					}
+					return app.RedisClient.Close()
+				},
 			},
 		},
 		domainLogger,
 	)
 }
+
+const migrateUsage = "usage: shortly migrate up [N] | down [N] | status"
+
+// runMigrate runs the migrate subcommand and returns the process exit code
+func runMigrate(domainLogger logfield.Logger, args []string) int {
+	if len(args) == 0 || len(args) > 2 || (args[0] == "status" && len(args) > 1) {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	steps := 0
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			fmt.Fprintln(os.Stderr, migrateUsage)
+			return 2
+		}
+		steps = n
+	}
+
+	migration, err := wireProviders.InitializeMigration(domainLogger)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize migrations: %v\n", err)
+		return 1
+	}
+	defer migration.PostgresClient.Close()
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		applied, err := migration.Migrator.Up(ctx, steps)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Migration failed after %d applied: %v\n", applied, err)
+			return 1
+		}
+		fmt.Printf("Applied %d migration(s)\n", applied)
+	case "down":
+		rolledBack, err := migration.Migrator.Down(ctx, steps)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Rollback failed after %d rolled back: %v\n", rolledBack, err)
+			return 1
+		}
+		fmt.Printf("Rolled back %d migration(s)\n", rolledBack)
+	case "status":
+		version, statuses, err := migration.Migrator.Status(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read migration status: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Current version: %d\n", version)
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied"
+			}
+			fmt.Printf("%06d  %-8s %s\n", status.Version, state, status.Name)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	return 0
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/admin/valueobject"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	urlRepository "github.com/PraveenGongada/shortly/internal/domain/url/repository"
+	"github.com/PraveenGongada/shortly/internal/domain/user/cache"
+	"github.com/PraveenGongada/shortly/internal/domain/user/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/user/repository"
+)
+
+// AdminService defines the interface for the use cases of system administrators. Links
+// are administered through URLService.
+type AdminService interface {
+	ListUsers(ctx context.Context, req *valueobject.UserListRequest) (*valueobject.UserListResponse, error)
+	SuspendUser(ctx context.Context, adminID, userID string) (*valueobject.AdminUserResponse, error)
+	UnsuspendUser(ctx context.Context, userID string) (*valueobject.AdminUserResponse, error)
+	GetStats(ctx context.Context) (*valueobject.StatsResponse, error)
+}
+
+type adminService struct {
+	users       repository.UserRepository
+	urls        urlRepository.URLRepository
+	sessions    sessionRevoker
+	logger      logger.Logger
+	maxPageSize int
+}
+
+func NewAdminService(
+	users repository.UserRepository,
+	urls urlRepository.URLRepository,
+	refreshTokens repository.RefreshTokenRepository,
+	revocations cache.TokenRevocationCache,
+	logger logger.Logger,
+	maxPageSize int,
+) AdminService {
+	if maxPageSize <= 0 {
+		maxPageSize = 1
+	}
+	return &adminService{
+		users:       users,
+		urls:        urls,
+		sessions:    sessionRevoker{refreshTokens: refreshTokens, revocations: revocations, logger: logger},
+		logger:      logger,
+		maxPageSize: maxPageSize,
+	}
+}
+
+func (s *adminService) ListUsers(
+	ctx context.Context,
+	req *valueobject.UserListRequest,
+) (*valueobject.UserListResponse, error) {
+	filter := repository.UserFilter{
+		Search:    strings.TrimSpace(req.Search),
+		Role:      req.Role,
+		Suspended: req.Suspended,
+	}
+	if len(filter.Search) > maxSearchLength {
+		return nil, errors.ValidationError(fmt.Sprintf("search cannot exceed %d characters", maxSearchLength))
+	}
+	if filter.Role != "" && !slices.Contains(entity.UserRoles, filter.Role) {
+		return nil, errors.ValidationError(fmt.Sprintf("role must be one of %v", entity.UserRoles))
+	}
+
+	limit, err := pageLimit(req.Limit, s.maxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	var after *repository.UserCursor
+	if req.Cursor != "" {
+		cursor, ok := decodeUserCursor(req.Cursor)
+		if !ok {
+			return nil, errors.ValidationError("invalid cursor")
+		}
+		after = cursor
+	}
+
+	// One user more than the page holds tells whether another page follows
+	users, err := s.users.FindAll(ctx, filter, after, limit+1)
+	if err != nil {
+		return nil, errors.InternalError("query failed")
+	}
+	total, err := s.users.CountAll(ctx, filter)
+	if err != nil {
+		return nil, errors.InternalError("query failed")
+	}
+
+	var nextCursor string
+	if len(users) > limit {
+		users = users[:limit]
+		last := users[limit-1]
+		nextCursor = encodeUserCursor(repository.UserCursor{CreatedAt: last.CreatedAt(), ID: last.ID()})
+	}
+
+	items := make([]valueobject.AdminUserResponse, len(users))
+	for i, user := range users {
+		items[i] = valueobject.CreateAdminUserResponse(user)
+	}
+
+	return &valueobject.UserListResponse{
+		Items:      items,
+		NextCursor: nextCursor,
+		Total:      total,
+	}, nil
+}
+
+// SuspendUser keeps the user from signing in or using their API keys, and ends every
+// session they have
+func (s *adminService) SuspendUser(
+	ctx context.Context,
+	adminID, userID string,
+) (*valueobject.AdminUserResponse, error) {
+	// An administrator suspending themselves could leave nobody to lift it
+	if adminID == userID {
+		return nil, errors.ValidationError("administrators cannot suspend themselves")
+	}
+
+	tokenVersion, err := s.users.Suspend(ctx, userID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sessions.revokeAll(ctx, userID, tokenVersion); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "User suspended",
+		logger.String("adminID", adminID),
+		logger.String("userID", userID),
+		logger.String("operation", "SuspendUser"))
+	return s.findUser(ctx, userID)
+}
+
+func (s *adminService) UnsuspendUser(ctx context.Context, userID string) (*valueobject.AdminUserResponse, error) {
+	if err := s.users.Unsuspend(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "User unsuspended",
+		logger.String("userID", userID),
+		logger.String("operation", "UnsuspendUser"))
+	return s.findUser(ctx, userID)
+}
+
+func (s *adminService) findUser(ctx context.Context, userID string) (*valueobject.AdminUserResponse, error) {
+	user, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	response := valueobject.CreateAdminUserResponse(user)
+	return &response, nil
+}
+
+func (s *adminService) GetStats(ctx context.Context) (*valueobject.StatsResponse, error) {
+	var stats valueobject.StatsResponse
+	var err error
+
+	if stats.Users.Total, err = s.users.CountAll(ctx, repository.UserFilter{}); err != nil {
+		return nil, errors.InternalError("query failed")
+	}
+	if stats.Users.Admins, err = s.users.CountAll(ctx, repository.UserFilter{Role: entity.RoleAdmin}); err != nil {
+		return nil, errors.InternalError("query failed")
+	}
+	if stats.Users.Suspended, err = s.users.CountAll(ctx, repository.UserFilter{Suspended: true}); err != nil {
+		return nil, errors.InternalError("query failed")
+	}
+
+	totals, err := s.urls.Totals(ctx)
+	if err != nil {
+		return nil, errors.InternalError("query failed")
+	}
+	stats.URLs = valueobject.URLStats{
+		Active:    totals.Active,
+		Disabled:  totals.Disabled,
+		Trashed:   totals.Deleted,
+		Redirects: totals.Redirects,
+	}
+
+	return &stats, nil
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	zl "github.com/rs/zerolog"
+
+	adminValueobject "github.com/PraveenGongada/shortly/internal/domain/admin/valueobject"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	urlEntity "github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	urlRepository "github.com/PraveenGongada/shortly/internal/domain/url/repository"
+	urlValueobject "github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+	"github.com/PraveenGongada/shortly/internal/domain/user/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/user/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/auth"
+	memoryCache "github.com/PraveenGongada/shortly/internal/infrastructure/cache/memory"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/logging/zerolog"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/persistence/memory"
+)
+
+func TestSuspendUser(t *testing.T) {
+	ctx := context.Background()
+	log := zerolog.NewWithLogger(zl.Nop())
+	store := memory.NewStore()
+	users := memory.NewUserRepository(store)
+	refreshTokens := memory.NewRefreshTokenRepository(store)
+	revocations := memoryCache.NewTokenRevocationCache()
+	admin := NewAdminService(users, memory.NewURLRepository(store), refreshTokens, revocations, log, 10)
+	sessions := NewUserService(nil, nil, users, refreshTokens, revocations, nil, nil, log)
+	apiKeys := NewAPIKeyService(auth.NewAPIKeyGenerator(), memory.NewAPIKeyRepository(store), users, log)
+
+	for id, role := range map[string]string{"root": entity.RoleAdmin, "member": entity.RoleUser} {
+		user := entity.NewUserFromRepository(id, id+"@example.com", "hash", id, role, 0, time.Now(), nil, nil)
+		if _, err := users.Save(ctx, user); err != nil {
+			t.Fatalf("Save(%s) error = %v", id, err)
+		}
+	}
+	key, err := apiKeys.CreateAPIKey(ctx, "member", &valueobject.CreateAPIKeyRequest{Name: "CI"})
+	if err != nil {
+		t.Fatalf("CreateAPIKey() error = %v", err)
+	}
+	refreshToken := entity.NewRefreshToken("refresh", "member", "family", "hash", time.Now().Add(time.Hour))
+	if err := refreshTokens.Save(ctx, refreshToken); err != nil {
+		t.Fatalf("Save(refresh token) error = %v", err)
+	}
+
+	assertErrorType := func(err error, want errors.ErrorType) {
+		t.Helper()
+		if err == nil || errors.GetErrorType(err) != want {
+			t.Fatalf("error = %v, want %s", err, want)
+		}
+	}
+
+	_, err = admin.SuspendUser(ctx, "root", "root")
+	assertErrorType(err, errors.ErrorTypeValidation)
+	_, err = admin.SuspendUser(ctx, "root", "nobody")
+	assertErrorType(err, errors.ErrorTypeNotFound)
+
+	suspended, err := admin.SuspendUser(ctx, "root", "member")
+	if err != nil {
+		t.Fatalf("SuspendUser() error = %v", err)
+	}
+	if suspended.SuspendedAt == nil {
+		t.Fatalf("SuspendUser() = %+v, want a suspension time", suspended)
+	}
+
+	// Every credential of the user stops working at once
+	err = sessions.ValidateSession(ctx, &valueobject.AccessTokenClaims{UserID: "member", TokenVersion: 0})
+	assertErrorType(err, errors.ErrorTypeUnauthorized)
+	token, err := refreshTokens.FindByHash(ctx, "hash")
+	if err != nil || !token.IsRevoked() {
+		t.Fatalf("refresh token = %v, %v, want it revoked", token, err)
+	}
+	_, err = apiKeys.AuthenticateAPIKey(ctx, key.Key)
+	assertErrorType(err, errors.ErrorTypeForbidden)
+
+	list, err := admin.ListUsers(ctx, &adminValueobject.UserListRequest{Suspended: true})
+	if err != nil {
+		t.Fatalf("ListUsers() error = %v", err)
+	}
+	if list.Total != 1 || len(list.Items) != 1 || list.Items[0].ID != "member" {
+		t.Fatalf("ListUsers(suspended) = %+v, want only member", list)
+	}
+	stats, err := admin.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats() error = %v", err)
+	}
+	if stats.Users.Total != 2 || stats.Users.Admins != 1 || stats.Users.Suspended != 1 {
+		t.Fatalf("GetStats().Users = %+v, want 2 users, 1 admin and 1 suspended", stats.Users)
+	}
+
+	if _, err := admin.UnsuspendUser(ctx, "member"); err != nil {
+		t.Fatalf("UnsuspendUser() error = %v", err)
+	}
+	if _, err := apiKeys.AuthenticateAPIKey(ctx, key.Key); err != nil {
+		t.Fatalf("AuthenticateAPIKey(unsuspended) error = %v", err)
+	}
+}
+
+func TestValidateSessionRole(t *testing.T) {
+	ctx := context.Background()
+	log := zerolog.NewWithLogger(zl.Nop())
+	store := memory.NewStore()
+	users := memory.NewUserRepository(store)
+	sessions := NewUserService(nil, nil, users, memory.NewRefreshTokenRepository(store),
+		memoryCache.NewTokenRevocationCache(), nil, nil, log)
+
+	for id, role := range map[string]string{"root": entity.RoleAdmin, "demoted": entity.RoleUser} {
+		user := entity.NewUserFromRepository(id, id+"@example.com", "hash", id, role, 0, time.Now(), nil, nil)
+		if _, err := users.Save(ctx, user); err != nil {
+			t.Fatalf("Save(%s) error = %v", id, err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		claims valueobject.AccessTokenClaims
+		valid  bool
+	}{
+		{"Admin", valueobject.AccessTokenClaims{UserID: "root", Role: entity.RoleAdmin}, true},
+		{"User", valueobject.AccessTokenClaims{UserID: "demoted", Role: entity.RoleUser}, true},
+		{"WithoutRole", valueobject.AccessTokenClaims{UserID: "demoted"}, true},
+		{"Demoted", valueobject.AccessTokenClaims{UserID: "demoted", Role: entity.RoleAdmin}, false},
+		{"Missing", valueobject.AccessTokenClaims{UserID: "nobody", Role: entity.RoleAdmin}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sessions.ValidateSession(ctx, &tt.claims)
+			if tt.valid && err != nil {
+				t.Fatalf("ValidateSession() error = %v, want nil", err)
+			}
+			if !tt.valid && errors.GetErrorType(err) != errors.ErrorTypeUnauthorized {
+				t.Fatalf("ValidateSession() error = %v, want unauthorized", err)
+			}
+		})
+	}
+}
+
+// blockingURLRepository holds the first lookup by short code after it has loaded the
+// URL until it is released, like a redirect that is slow to finish
+type blockingURLRepository struct {
+	urlRepository.URLRepository
+	loaded  chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (r *blockingURLRepository) FindByShortCode(
+	ctx context.Context,
+	domain, shortCode string,
+) (*urlEntity.URL, error) {
+	url, err := r.URLRepository.FindByShortCode(ctx, domain, shortCode)
+	r.once.Do(func() {
+		close(r.loaded)
+		<-r.release
+	})
+	return url, err
+}
+
+func TestDisableURLDuringRedirect(t *testing.T) {
+	ctx := context.Background()
+	log := zerolog.NewWithLogger(zl.Nop())
+	urls := &blockingURLRepository{
+		URLRepository: memory.NewURLRepository(memory.NewStore()),
+		loaded:        make(chan struct{}),
+		release:       make(chan struct{}),
+	}
+	urlCache := memoryCache.NewURLCache()
+	service := NewURLService(nil, nil, nil, nil, urls, nil, nil, nil, nil, urlCache,
+		memoryCache.NewRedirectCounter(), nil, nil, log, nil, 1, 1, 10)
+
+	url := urlEntity.NewURLFromRepository(urlEntity.URLSnapshot{
+		ID:           "link",
+		UserID:       "member",
+		WorkspaceID:  "member",
+		ShortCode:    "takedwn",
+		LongURL:      "https://example.com/phish",
+		RedirectType: urlEntity.DefaultRedirectType,
+		CreatedAt:    time.Now(),
+	})
+	if _, err := urls.Save(ctx, url); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// The redirect loads the link before it is disabled and caches it afterwards
+	inFlight := make(chan error, 1)
+	go func() {
+		_, err := service.GetOriginalURL(ctx, "", "takedwn", "")
+		inFlight <- err
+	}()
+	<-urls.loaded
+	if err := service.DisableURL(ctx, "link", &urlValueobject.DisableURLRequest{Reason: "phishing"}); err != nil {
+		t.Fatalf("DisableURL() error = %v", err)
+	}
+	close(urls.release)
+	if err := <-inFlight; err != nil {
+		t.Fatalf("GetOriginalURL(in flight) error = %v", err)
+	}
+
+	if cached, err := urlCache.GetRedirect(ctx, "", "takedwn"); err != nil || cached != nil {
+		t.Fatalf("GetRedirect() = %+v, %v, want a miss", cached, err)
+	}
+	_, err := service.GetOriginalURL(ctx, "", "takedwn", "")
+	if errors.GetErrorType(err) != errors.ErrorTypeGone {
+		t.Fatalf("GetOriginalURL(disabled) error = %v, want gone", err)
+	}
+}
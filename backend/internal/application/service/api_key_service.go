@@ -0,0 +1,205 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/user/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/user/repository"
+	"github.com/PraveenGongada/shortly/internal/domain/user/valueobject"
+	"github.com/PraveenGongada/shortly/internal/shared/utils"
+)
+
+const (
+	// maxAPIKeyGenerationAttempts bounds retries when a generated prefix is already taken
+	maxAPIKeyGenerationAttempts = 3
+
+	// lastUsedUpdateInterval throttles writes of API key last used timestamps
+	lastUsedUpdateInterval = time.Minute
+)
+
+// APIKeyGenerator defines interface for API key generation
+type APIKeyGenerator interface {
+	GenerateAPIKey() (string, string, error) // returns key, prefix, error
+	APIKeyPrefix(key string) (string, bool)
+}
+
+// APIKeyService defines the interface for API key use cases
+type APIKeyService interface {
+	CreateAPIKey(
+		ctx context.Context,
+		userID string,
+		req *valueobject.CreateAPIKeyRequest,
+	) (*valueobject.CreateAPIKeyResponse, error)
+	ListAPIKeys(ctx context.Context, userID string) ([]valueobject.APIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, userID, keyID string) error
+	AuthenticateAPIKey(ctx context.Context, key string) (*valueobject.APIKeyPrincipal, error)
+}
+
+type apiKeyService struct {
+	generator  APIKeyGenerator
+	repository repository.APIKeyRepository
+	users      repository.UserRepository
+	logger     logger.Logger
+}
+
+func NewAPIKeyService(
+	generator APIKeyGenerator,
+	repository repository.APIKeyRepository,
+	users repository.UserRepository,
+	logger logger.Logger,
+) APIKeyService {
+	return &apiKeyService{
+		generator:  generator,
+		repository: repository,
+		users:      users,
+		logger:     logger,
+	}
+}
+
+func (s *apiKeyService) CreateAPIKey(
+	ctx context.Context,
+	userID string,
+	req *valueobject.CreateAPIKeyRequest,
+) (*valueobject.CreateAPIKeyResponse, error) {
+	for attempt := 0; attempt < maxAPIKeyGenerationAttempts; attempt++ {
+		key, prefix, err := s.generator.GenerateAPIKey()
+		if err != nil {
+			s.logger.Error(ctx, "API key generation failed",
+				logger.String("userID", userID),
+				logger.Error(err))
+			return nil, errors.InternalError("API key generation failed")
+		}
+
+		apiKey, err := entity.NewAPIKey(
+			utils.GenerateRandomUUID(),
+			userID,
+			req.Name,
+			prefix,
+			hashToken(key),
+			req.Scopes,
+			req.ExpiresAt,
+		)
+		if err != nil {
+			return nil, errors.ValidationError(err.Error())
+		}
+
+		if err := s.repository.Save(ctx, apiKey); err != nil {
+			if errors.GetErrorType(err) == errors.ErrorTypeConflict {
+				continue
+			}
+			return nil, err
+		}
+
+		s.logger.Info(ctx, "API key created",
+			logger.String("userID", userID),
+			logger.String("keyID", apiKey.ID()),
+			logger.String("operation", "CreateAPIKey"))
+
+		return &valueobject.CreateAPIKeyResponse{
+			APIKeyResponse: valueobject.CreateAPIKeyResponseFromEntity(apiKey),
+			Key:            key,
+		}, nil
+	}
+
+	s.logger.Error(ctx, "Failed to generate unique API key prefix",
+		logger.String("userID", userID),
+		logger.Int("attempts", maxAPIKeyGenerationAttempts))
+	return nil, errors.InternalError("API key generation failed")
+}
+
+func (s *apiKeyService) ListAPIKeys(ctx context.Context, userID string) ([]valueobject.APIKeyResponse, error) {
+	keys, err := s.repository.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]valueobject.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		responses[i] = valueobject.CreateAPIKeyResponseFromEntity(key)
+	}
+
+	return responses, nil
+}
+
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, userID, keyID string) error {
+	if err := s.repository.Delete(ctx, keyID, userID); err != nil {
+		return err
+	}
+
+	s.logger.Info(ctx, "API key revoked",
+		logger.String("userID", userID),
+		logger.String("keyID", keyID),
+		logger.String("operation", "RevokeAPIKey"))
+	return nil
+}
+
+// AuthenticateAPIKey resolves a presented key to the user and scopes it acts with
+func (s *apiKeyService) AuthenticateAPIKey(ctx context.Context, key string) (*valueobject.APIKeyPrincipal, error) {
+	prefix, ok := s.generator.APIKeyPrefix(key)
+	if !ok {
+		return nil, errors.UnauthorizedError("invalid API key")
+	}
+
+	apiKey, err := s.repository.FindByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.GetErrorType(err) == errors.ErrorTypeNotFound {
+			return nil, errors.UnauthorizedError("invalid API key")
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashToken(key)), []byte(apiKey.KeyHash())) != 1 {
+		return nil, errors.UnauthorizedError("invalid API key")
+	}
+
+	now := time.Now().UTC()
+	if apiKey.IsExpired(now) {
+		return nil, errors.UnauthorizedError("API key has expired")
+	}
+
+	// Keys outlive the sessions revoked on suspension, so their owner is checked each time
+	owner, err := s.users.FindByID(ctx, apiKey.UserID())
+	if err != nil {
+		if errors.GetErrorType(err) == errors.ErrorTypeNotFound {
+			return nil, errors.UnauthorizedError("invalid API key")
+		}
+		return nil, err
+	}
+	if owner.IsSuspended() {
+		return nil, errors.ForbiddenError("account is suspended")
+	}
+
+	// Recording every request would turn each API call into a write
+	if lastUsedAt := apiKey.LastUsedAt(); lastUsedAt == nil || now.Sub(*lastUsedAt) >= lastUsedUpdateInterval {
+		if err := s.repository.UpdateLastUsed(ctx, apiKey.ID(), now); err != nil {
+			s.logger.Warn(ctx, "Failed to record API key usage",
+				logger.String("keyID", apiKey.ID()),
+				logger.Error(err))
+		}
+	}
+
+	return &valueobject.APIKeyPrincipal{
+		UserID: apiKey.UserID(),
+		Scopes: apiKey.Scopes(),
+	}, nil
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/interfaces"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+	"github.com/PraveenGongada/shortly/internal/shared/utils"
+)
+
+// verificationTokenBytes is the length of the random part of domain verification tokens
+const verificationTokenBytes = 16
+
+// DomainVerifier defines interface for checking the DNS records proving control over a domain
+type DomainVerifier interface {
+	HasTXTRecord(ctx context.Context, name, value string) (bool, error)
+}
+
+// DomainService defines the interface for custom domain use cases
+type DomainService interface {
+	AddDomain(ctx context.Context, userID string, req *valueobject.AddDomainRequest) (*valueobject.DomainResponse, error)
+	ListDomains(ctx context.Context, userID string) ([]valueobject.DomainResponse, error)
+	VerifyDomain(ctx context.Context, userID, domainID string) (*valueobject.DomainResponse, error)
+	DeleteDomain(ctx context.Context, userID, domainID string) error
+}
+
+type domainService struct {
+	repository    repository.DomainRepository
+	urlRepository repository.URLRepository
+	verifier      DomainVerifier
+	validator     interfaces.URLValidator
+	logger        logger.Logger
+}
+
+func NewDomainService(
+	repository repository.DomainRepository,
+	urlRepository repository.URLRepository,
+	verifier DomainVerifier,
+	validator interfaces.URLValidator,
+	logger logger.Logger,
+) DomainService {
+	return &domainService{
+		repository:    repository,
+		urlRepository: urlRepository,
+		verifier:      verifier,
+		validator:     validator,
+		logger:        logger,
+	}
+}
+
+func (s *domainService) AddDomain(
+	ctx context.Context,
+	userID string,
+	req *valueobject.AddDomainRequest,
+) (*valueobject.DomainResponse, error) {
+	token, err := generateVerificationToken()
+	if err != nil {
+		return nil, errors.InternalError("verification token generation failed")
+	}
+
+	domain, err := entity.NewDomain(utils.GenerateRandomUUID(), userID, req.Hostname, token, s.validator)
+	if err != nil {
+		return nil, errors.ValidationError(err.Error())
+	}
+
+	if err := s.repository.Save(ctx, domain); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Domain added",
+		logger.String("userID", userID),
+		logger.String("domainID", domain.ID()),
+		logger.String("hostname", domain.Hostname()),
+		logger.String("operation", "AddDomain"))
+
+	response := valueobject.CreateDomainResponse(domain)
+	return &response, nil
+}
+
+func (s *domainService) ListDomains(ctx context.Context, userID string) ([]valueobject.DomainResponse, error) {
+	domains, err := s.repository.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]valueobject.DomainResponse, len(domains))
+	for i, domain := range domains {
+		responses[i] = valueobject.CreateDomainResponse(domain)
+	}
+
+	return responses, nil
+}
+
+// VerifyDomain checks the verification record of a domain, after which short URLs can
+// be created on it. Domains that are already verified are returned as they are.
+func (s *domainService) VerifyDomain(
+	ctx context.Context,
+	userID, domainID string,
+) (*valueobject.DomainResponse, error) {
+	domain, err := s.findOwnedDomain(ctx, userID, domainID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !domain.IsVerified() {
+		found, err := s.verifier.HasTXTRecord(ctx, domain.VerificationRecordName(), domain.VerificationRecordValue())
+		if err != nil {
+			s.logger.Warn(ctx, "Domain verification lookup failed",
+				logger.String("domainID", domain.ID()),
+				logger.String("hostname", domain.Hostname()),
+				logger.Error(err))
+			return nil, errors.InternalError("DNS lookup failed")
+		}
+		if !found {
+			return nil, errors.ValidationError("verification record not found")
+		}
+
+		verifiedAt := time.Now().UTC()
+		if err := s.repository.MarkVerified(ctx, domain.ID(), verifiedAt); err != nil {
+			return nil, err
+		}
+		domain.Verify(verifiedAt)
+
+		s.logger.Info(ctx, "Domain verified",
+			logger.String("userID", userID),
+			logger.String("domainID", domain.ID()),
+			logger.String("hostname", domain.Hostname()),
+			logger.String("operation", "VerifyDomain"))
+	}
+
+	response := valueobject.CreateDomainResponse(domain)
+	return &response, nil
+}
+
+// DeleteDomain removes a domain of the user. Verified domains still serving short URLs,
+// including those in the trash, cannot be removed.
+func (s *domainService) DeleteDomain(ctx context.Context, userID, domainID string) error {
+	domain, err := s.findOwnedDomain(ctx, userID, domainID)
+	if err != nil {
+		return err
+	}
+
+	if domain.IsVerified() {
+		inUse, err := s.urlRepository.ExistsByDomain(ctx, domain.Hostname())
+		if err != nil {
+			return errors.InternalError("database query failed")
+		}
+		if inUse {
+			return errors.ConflictError("domain still has short URLs")
+		}
+	}
+
+	if err := s.repository.Delete(ctx, domain.ID(), userID); err != nil {
+		return err
+	}
+
+	s.logger.Info(ctx, "Domain deleted",
+		logger.String("userID", userID),
+		logger.String("domainID", domain.ID()),
+		logger.String("operation", "DeleteDomain"))
+	return nil
+}
+
+// findOwnedDomain loads a domain of the user; domains of other users are not found
+func (s *domainService) findOwnedDomain(ctx context.Context, userID, domainID string) (*entity.Domain, error) {
+	domain, err := s.repository.FindByID(ctx, domainID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsOwnedBy(userID) {
+		return nil, errors.NotFoundError("domain not found")
+	}
+	return domain, nil
+}
+
+// generateVerificationToken returns a random token for a domain verification record
+func generateVerificationToken() (string, error) {
+	token := make([]byte, verificationTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
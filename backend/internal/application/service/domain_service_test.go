@@ -0,0 +1,155 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	zl "github.com/rs/zerolog"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	urlDomainService "github.com/PraveenGongada/shortly/internal/domain/url/service"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/logging/zerolog"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/persistence/memory"
+)
+
+// stubVerifier finds the TXT records it holds, by name
+type stubVerifier map[string]string
+
+func (v stubVerifier) HasTXTRecord(ctx context.Context, name, value string) (bool, error) {
+	return v[name] == value, nil
+}
+
+func TestDomainVerification(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	urls := memory.NewURLRepository(store)
+	records := stubVerifier{}
+	validator := urlDomainService.NewValidator([]string{"sho.rt"}, nil)
+	s := NewDomainService(memory.NewDomainRepository(store), urls, records, validator,
+		zerolog.NewWithLogger(zl.Nop()))
+
+	assertErrorType := func(err error, want errors.ErrorType) {
+		t.Helper()
+		if err == nil || errors.GetErrorType(err) != want {
+			t.Fatalf("error = %v, want %s", err, want)
+		}
+	}
+
+	_, err := s.AddDomain(ctx, "user-1", &valueobject.AddDomainRequest{Hostname: "sho.rt"})
+	assertErrorType(err, errors.ErrorTypeValidation)
+
+	domain, err := s.AddDomain(ctx, "user-1", &valueobject.AddDomainRequest{Hostname: "Go.Example.com."})
+	if err != nil {
+		t.Fatalf("AddDomain() error = %v", err)
+	}
+	if domain.Hostname != "go.example.com" || domain.Verified {
+		t.Fatalf("AddDomain() = %+v, want unverified go.example.com", domain)
+	}
+	if domain.VerificationRecord.Name != "_shortly-verification.go.example.com" {
+		t.Fatalf("VerificationRecord.Name = %q", domain.VerificationRecord.Name)
+	}
+	rival, err := s.AddDomain(ctx, "user-2", &valueobject.AddDomainRequest{Hostname: "go.example.com"})
+	if err != nil {
+		t.Fatalf("AddDomain(rival) error = %v", err)
+	}
+
+	_, err = s.VerifyDomain(ctx, "user-1", domain.ID)
+	assertErrorType(err, errors.ErrorTypeValidation)
+	_, err = s.VerifyDomain(ctx, "user-2", domain.ID)
+	assertErrorType(err, errors.ErrorTypeNotFound)
+
+	records[domain.VerificationRecord.Name] = domain.VerificationRecord.Value
+	verified, err := s.VerifyDomain(ctx, "user-1", domain.ID)
+	if err != nil || !verified.Verified || verified.VerifiedAt == nil {
+		t.Fatalf("VerifyDomain() = %+v, %v, want verified", verified, err)
+	}
+
+	// Only one user can verify a hostname, even with the right record
+	records[rival.VerificationRecord.Name] = rival.VerificationRecord.Value
+	_, err = s.VerifyDomain(ctx, "user-2", rival.ID)
+	assertErrorType(err, errors.ErrorTypeConflict)
+
+	url := entity.NewURLFromRepository(entity.URLSnapshot{
+		ID:           "url-1",
+		UserID:       "user-1",
+		WorkspaceID:  "workspace-1",
+		Domain:       "go.example.com",
+		ShortCode:    "abc1234",
+		LongURL:      "https://example.com",
+		RedirectType: entity.DefaultRedirectType,
+		CreatedAt:    verified.CreatedAt,
+	})
+	if _, err := urls.Save(ctx, url); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	assertErrorType(s.DeleteDomain(ctx, "user-1", domain.ID), errors.ErrorTypeConflict)
+
+	if err := s.DeleteDomain(ctx, "user-2", rival.ID); err != nil {
+		t.Fatalf("DeleteDomain(unverified) error = %v", err)
+	}
+}
+
+func TestCustomDomainDestination(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	domains := memory.NewDomainRepository(store)
+	records := stubVerifier{}
+	validator := urlDomainService.NewValidator([]string{"sho.rt"}, nil)
+	log := zerolog.NewWithLogger(zl.Nop())
+	domainService := NewDomainService(domains, memory.NewURLRepository(store), records, validator, log)
+	service := NewURLService(nil, validator, nil, nil, nil, nil, domains, nil, nil, nil,
+		nil, nil, nil, log, []string{"sho.rt"}, 1, 1, 10).(*urlService)
+
+	verified, err := domainService.AddDomain(ctx, "user-1", &valueobject.AddDomainRequest{Hostname: "go.example.com"})
+	if err != nil {
+		t.Fatalf("AddDomain() error = %v", err)
+	}
+	records[verified.VerificationRecord.Name] = verified.VerificationRecord.Value
+	if _, err := domainService.VerifyDomain(ctx, "user-1", verified.ID); err != nil {
+		t.Fatalf("VerifyDomain() error = %v", err)
+	}
+	if _, err := domainService.AddDomain(ctx, "user-1", &valueobject.AddDomainRequest{Hostname: "go.pending.com"}); err != nil {
+		t.Fatalf("AddDomain() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		longURL string
+		allowed bool
+	}{
+		{"ShortLink", "https://go.example.com/abc1234", false},
+		{"ShortLinkUppercaseHost", "https://GO.EXAMPLE.COM/abc1234/", false},
+		{"OtherPath", "https://go.example.com/docs/abc1234", true},
+		{"UnverifiedDomain", "https://go.pending.com/abc1234", true},
+		{"OtherHost", "https://example.com/abc1234", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.checkDomainDestination(ctx, tt.longURL)
+			if tt.allowed && err != nil {
+				t.Fatalf("checkDomainDestination() error = %v, want nil", err)
+			}
+			if !tt.allowed && errors.GetErrorType(err) != errors.ErrorTypeValidation {
+				t.Fatalf("checkDomainDestination() error = %v, want validation error", err)
+			}
+		})
+	}
+}
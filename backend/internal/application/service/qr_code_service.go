@@ -0,0 +1,251 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/url/cache"
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+	workspaceEntity "github.com/PraveenGongada/shortly/internal/domain/workspace/entity"
+	workspaceRepository "github.com/PraveenGongada/shortly/internal/domain/workspace/repository"
+)
+
+const (
+	defaultQRCodeSize   = 256
+	minQRCodeSize       = 64
+	maxQRCodeSize       = 2048
+	defaultQRCodeLevel  = "M"
+	defaultQRCodeMargin = 4
+	maxQRCodeMargin     = 16
+
+	// qrCodeCacheTTL bounds how long a rendered image is kept; the image only depends on
+	// the short URL and rendering options, so it never goes stale
+	qrCodeCacheTTL = 24 * time.Hour
+)
+
+var (
+	defaultQRCodeForeground = color.NRGBA{A: 0xff}
+	defaultQRCodeBackground = color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+)
+
+// QRCodeRenderer defines interface for rendering content as a QR code image
+type QRCodeRenderer interface {
+	Render(content string, options valueobject.QRCodeOptions) ([]byte, error)
+}
+
+// QRCodeService defines the interface for QR code use cases
+type QRCodeService interface {
+	GetQRCode(
+		ctx context.Context,
+		shortCode string,
+		userID string,
+		req *valueobject.QRCodeRequest,
+	) (*valueobject.QRCodeResponse, error)
+}
+
+type qrCodeService struct {
+	repository repository.URLRepository
+	workspaces workspaceAccess
+	renderer   QRCodeRenderer
+	cache      cache.QRCodeCache
+	logger     logger.Logger
+	publicURL  string
+}
+
+func NewQRCodeService(
+	repository repository.URLRepository,
+	workspaces workspaceRepository.WorkspaceRepository,
+	renderer QRCodeRenderer,
+	cache cache.QRCodeCache,
+	logger logger.Logger,
+	publicURL string,
+) QRCodeService {
+	return &qrCodeService{
+		repository: repository,
+		workspaces: workspaceAccess{repository: workspaces},
+		renderer:   renderer,
+		cache:      cache,
+		logger:     logger,
+		publicURL:  publicURL,
+	}
+}
+
+func (s *qrCodeService) GetQRCode(
+	ctx context.Context,
+	shortCode string,
+	userID string,
+	req *valueobject.QRCodeRequest,
+) (*valueobject.QRCodeResponse, error) {
+	options, err := qrCodeOptions(req)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := s.repository.FindByShortCode(ctx, entity.NormalizeHostname(req.Domain), shortCode)
+	if err != nil {
+		return nil, errors.NotFoundError("URL not found")
+	}
+
+	// Same workspace rule as analytics
+	if _, err := s.workspaces.authorize(ctx, userID, url.WorkspaceID(), workspaceEntity.RoleViewer,
+		"view QR code"); err != nil {
+		return nil, err
+	}
+
+	baseURL := s.publicURL
+	if baseURL == "" {
+		baseURL = strings.TrimRight(req.BaseURL, "/")
+	}
+	if baseURL == "" {
+		return nil, errors.InternalError("public URL is not configured")
+	}
+	// Custom domains are served with the scheme of the default one
+	if url.Domain() != "" {
+		scheme, _, _ := strings.Cut(baseURL, "://")
+		baseURL = scheme + "://" + url.Domain()
+	}
+	content := baseURL + "/" + url.ShortCode()
+
+	response := &valueobject.QRCodeResponse{ContentType: qrCodeContentType(options.Format)}
+
+	key := cache.ShortURLKey(url.Domain(), url.ShortCode()) + ":" + qrCodeCacheKey(content, options)
+	if image, err := s.cache.GetQRCode(ctx, key); err == nil && len(image) > 0 {
+		response.Image = image
+		return response, nil
+	}
+
+	image, err := s.renderer.Render(content, options)
+	if err != nil {
+		if errors.GetErrorType(err) == errors.ErrorTypeValidation {
+			return nil, err
+		}
+		s.logger.Error(ctx, "QR code rendering failed",
+			logger.String("shortCode", shortCode),
+			logger.String("operation", "GetQRCode"),
+			logger.Error(err))
+		return nil, errors.InternalError("QR code rendering failed")
+	}
+
+	s.cache.SetQRCode(ctx, key, image, qrCodeCacheTTL)
+
+	response.Image = image
+	return response, nil
+}
+
+// qrCodeOptions validates a QR code request and fills in defaults
+func qrCodeOptions(req *valueobject.QRCodeRequest) (valueobject.QRCodeOptions, error) {
+	options := valueobject.QRCodeOptions{
+		Format:     strings.ToLower(req.Format),
+		Size:       req.Size,
+		Level:      strings.ToUpper(req.Level),
+		Margin:     defaultQRCodeMargin,
+		Foreground: defaultQRCodeForeground,
+		Background: defaultQRCodeBackground,
+	}
+
+	switch options.Format {
+	case "":
+		options.Format = valueobject.QRCodeFormatPNG
+	case valueobject.QRCodeFormatPNG, valueobject.QRCodeFormatSVG:
+	default:
+		return options, errors.ValidationError("format must be one of png or svg")
+	}
+
+	if options.Size == 0 {
+		options.Size = defaultQRCodeSize
+	}
+	if options.Size < minQRCodeSize || options.Size > maxQRCodeSize {
+		return options, errors.ValidationError(
+			fmt.Sprintf("size must be between %d and %d", minQRCodeSize, maxQRCodeSize))
+	}
+
+	switch options.Level {
+	case "":
+		options.Level = defaultQRCodeLevel
+	case "L", "M", "Q", "H":
+	default:
+		return options, errors.ValidationError("level must be one of L, M, Q or H")
+	}
+
+	if req.Margin != nil {
+		if *req.Margin < 0 || *req.Margin > maxQRCodeMargin {
+			return options, errors.ValidationError(
+				fmt.Sprintf("margin must be between 0 and %d", maxQRCodeMargin))
+		}
+		options.Margin = *req.Margin
+	}
+
+	var err error
+	if req.Foreground != "" {
+		if options.Foreground, err = parseHexColor(req.Foreground); err != nil {
+			return options, errors.ValidationError("fg " + err.Error())
+		}
+	}
+	if req.Background != "" {
+		if options.Background, err = parseHexColor(req.Background); err != nil {
+			return options, errors.ValidationError("bg " + err.Error())
+		}
+	}
+
+	return options, nil
+}
+
+// parseHexColor parses RRGGBB or RRGGBBAA, with an optional leading '#'
+func parseHexColor(value string) (color.NRGBA, error) {
+	value = strings.TrimPrefix(value, "#")
+	if len(value) != 6 && len(value) != 8 {
+		return color.NRGBA{}, fmt.Errorf("must be a hex colour in RRGGBB or RRGGBBAA form")
+	}
+
+	raw, err := hex.DecodeString(value)
+	if err != nil {
+		return color.NRGBA{}, fmt.Errorf("must be a hex colour in RRGGBB or RRGGBBAA form")
+	}
+
+	c := color.NRGBA{R: raw[0], G: raw[1], B: raw[2], A: 0xff}
+	if len(raw) == 4 {
+		c.A = raw[3]
+	}
+	return c, nil
+}
+
+func qrCodeContentType(format string) string {
+	if format == valueobject.QRCodeFormatSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// qrCodeCacheKey identifies a rendered image by everything that affects its bytes
+func qrCodeCacheKey(content string, options valueobject.QRCodeOptions) string {
+	fg, bg := options.Foreground, options.Background
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%d|%s|%d|%02x%02x%02x%02x|%02x%02x%02x%02x",
+		content, options.Format, options.Size, options.Level, options.Margin,
+		fg.R, fg.G, fg.B, fg.A, bg.R, bg.G, bg.B, bg.A)))
+	return hex.EncodeToString(sum[:])
+}
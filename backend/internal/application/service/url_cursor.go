@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+)
+
+// urlCursor is the encoded form of a position in a URL listing. It records the order
+// the position was taken in, since it only makes sense within that order.
+type urlCursor struct {
+	Sort      repository.URLSort `json:"s"`
+	CreatedAt time.Time          `json:"c"`
+	UpdatedAt time.Time          `json:"u"`
+	Redirects int                `json:"r"`
+	Name      string             `json:"n"`
+	ID        string             `json:"i"`
+}
+
+// encodeURLCursor encodes a position in a URL listing as an opaque string
+func encodeURLCursor(sort repository.URLSort, cursor repository.URLCursor) string {
+	// Marshalling cannot fail for these field types
+	data, _ := json.Marshal(urlCursor{
+		Sort:      sort,
+		CreatedAt: cursor.CreatedAt,
+		UpdatedAt: cursor.UpdatedAt,
+		Redirects: cursor.Redirects,
+		Name:      cursor.Name,
+		ID:        cursor.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeURLCursor decodes a position encoded by encodeURLCursor, reporting false for
+// values that are malformed or were taken in another order than sort
+func decodeURLCursor(sort repository.URLSort, value string) (*repository.URLCursor, bool) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, false
+	}
+	var cursor urlCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != sort || cursor.ID == "" {
+		return nil, false
+	}
+	return &repository.URLCursor{
+		CreatedAt: cursor.CreatedAt,
+		UpdatedAt: cursor.UpdatedAt,
+		Redirects: cursor.Redirects,
+		Name:      cursor.Name,
+		ID:        cursor.ID,
+	}, true
+}
@@ -18,6 +18,12 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"net"
+	neturl "net/url"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/PraveenGongada/shortly/internal/domain/interfaces"
@@ -27,6 +33,8 @@ import (
 	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
 	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
 	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+	workspaceEntity "github.com/PraveenGongada/shortly/internal/domain/workspace/entity"
+	workspaceRepository "github.com/PraveenGongada/shortly/internal/domain/workspace/repository"
 	"github.com/PraveenGongada/shortly/internal/shared/utils"
 )
 
@@ -37,45 +45,173 @@ type URLService interface {
 		userID string,
 		req *valueobject.CreateURLRequest,
 	) (*valueobject.CreateURLResponse, error)
-	GetOriginalURL(ctx context.Context, shortCode string) (string, error)
-	GetAnalytics(ctx context.Context, shortCode string, userID string) (int, error)
+	CreateShortURLs(
+		ctx context.Context,
+		userID string,
+		reqs []valueobject.CreateURLRequest,
+	) (*valueobject.BulkCreateURLResponse, error)
+	ExportURLs(
+		ctx context.Context,
+		userID, workspaceID string,
+		write func(record valueobject.URLRecord) error,
+	) error
+	ImportURLs(
+		ctx context.Context,
+		userID, workspaceID string,
+		records []valueobject.URLRecord,
+	) (*valueobject.BulkCreateURLResponse, error)
+	GetOriginalURL(ctx context.Context, host, shortCode, unlockToken string) (*valueobject.Redirect, error)
+	UnlockURL(ctx context.Context, host, shortCode, password string) (*valueobject.UnlockURLResponse, error)
+	RecordClick(ctx context.Context, urlID string, req *valueobject.ClickRequest)
+	GetAnalytics(
+		ctx context.Context,
+		shortCode string,
+		userID string,
+		req *valueobject.AnalyticsRequest,
+	) (*valueobject.AnalyticsResponse, error)
 	GetPaginatedURLs(
 		ctx context.Context,
 		userID string,
-		limit int,
-		offset int,
-	) ([]valueobject.URLResponse, error)
-	UpdateURL(ctx context.Context, urlID string, userID string, newURL string) error
+		req *valueobject.URLListRequest,
+	) (*valueobject.URLListResponse, error)
+	UpdateURL(ctx context.Context, userID string, req *valueobject.URLUpdateRequest) error
+	GetURLHistory(
+		ctx context.Context,
+		userID, urlID string,
+		req *valueobject.URLHistoryRequest,
+	) (*valueobject.URLHistoryResponse, error)
+	AddURLTags(ctx context.Context, userID, urlID string, tags []string) (*valueobject.URLTagsResponse, error)
+	RemoveURLTag(ctx context.Context, userID, urlID, tag string) (*valueobject.URLTagsResponse, error)
+	ListTags(ctx context.Context, userID, workspaceID string) ([]valueobject.TagResponse, error)
 	DeleteURL(ctx context.Context, urlID string, userID string) error
+	RestoreURL(ctx context.Context, urlID string, userID string) error
+	// ListAllURLs lists the URLs of every workspace, or of the requested one, to
+	// administrators, who need no membership
+	ListAllURLs(ctx context.Context, req *valueobject.URLListRequest) (*valueobject.URLListResponse, error)
+	// DisableURL takes down a URL on behalf of an administrator
+	DisableURL(ctx context.Context, urlID string, req *valueobject.DisableURLRequest) error
 }
 
+// ClickRecorder defines interface for recording click events without blocking the caller
+type ClickRecorder interface {
+	Record(ctx context.Context, click *entity.Click)
+}
+
+// DestinationResolver defines interface for following links on other URL shorteners to
+// their final destination
+type DestinationResolver interface {
+	// Resolve returns the URL to store in place of longURL, which is longURL itself unless
+	// it is a shortened link being resolved
+	Resolve(ctx context.Context, longURL string) (string, error)
+}
+
+// LinkUnlockTokenGenerator defines interface for tokens granting access to password protected URLs
+type LinkUnlockTokenGenerator interface {
+	GenerateUnlockToken(shortCode, passwordHash string) (string, time.Time, error) // returns token, expiry, error
+	VerifyUnlockToken(token, shortCode, passwordHash string) bool
+}
+
+const (
+	// defaultCacheTTL bounds how long a resolved URL stays cached; links that expire sooner are cached for less
+	defaultCacheTTL = 5 * time.Minute
+
+	defaultAnalyticsRange = 30 * 24 * time.Hour
+	defaultAnalyticsTop   = 10
+	maxAnalyticsTop       = 100
+	maxAnalyticsBuckets   = 1000
+
+	// exportBatchSize is how many exported URLs share one lookup of pending redirect counts
+	exportBatchSize = 500
+
+	// createURLsAction and viewURLsAction describe requests to a workspace in errors
+	createURLsAction = "create URLs in this workspace"
+	viewURLsAction   = "view URLs of this workspace"
+
+	maxSearchLength = 200
+	// maxDisableReasonLength bounds the reason an administrator gives for disabling a URL
+	maxDisableReasonLength = 500
+	// defaultPageSize is the number of URLs, revisions or users listed when no limit is requested
+	defaultPageSize = 20
+
+	// bulkResolveConcurrency bounds how many destinations of a batch are resolved at once,
+	// and bulkResolveTimeout how long resolving all of them may take
+	bulkResolveConcurrency = 8
+	bulkResolveTimeout     = 30 * time.Second
+)
+
 type urlService struct {
-	generator  interfaces.ShortCodeGenerator
-	validator  interfaces.URLValidator
-	repository repository.URLRepository
-	cache      cache.URLCache
-	logger     logger.Logger
-	maxRetries int
+	generator       interfaces.ShortCodeGenerator
+	validator       interfaces.URLValidator
+	safetyChecker   interfaces.URLSafetyChecker
+	resolver        DestinationResolver
+	repository      repository.URLRepository
+	clickRepository repository.ClickRepository
+	domains         repository.DomainRepository
+	workspaces      workspaceAccess
+	clickRecorder   ClickRecorder
+	cache           cache.URLCache
+	counter         cache.RedirectCounter
+	hasher          interfaces.PasswordHasher
+	unlockTokens    LinkUnlockTokenGenerator
+	logger          logger.Logger
+	ownHosts        map[string]struct{} // hostnames serving the default domain
+	maxRetries      int
+	maxBulkURLs     int
+	maxPageSize     int
 }
 
 func NewURLService(
 	generator interfaces.ShortCodeGenerator,
 	validator interfaces.URLValidator,
+	safetyChecker interfaces.URLSafetyChecker,
+	resolver DestinationResolver,
 	repository repository.URLRepository,
+	clickRepository repository.ClickRepository,
+	domains repository.DomainRepository,
+	workspaces workspaceRepository.WorkspaceRepository,
+	clickRecorder ClickRecorder,
 	cache cache.URLCache,
+	counter cache.RedirectCounter,
+	hasher interfaces.PasswordHasher,
+	unlockTokens LinkUnlockTokenGenerator,
 	logger logger.Logger,
+	ownHosts []string,
 	maxRetries int,
+	maxBulkURLs int,
+	maxPageSize int,
 ) URLService {
 	if maxRetries <= 0 {
 		maxRetries = 1
 	}
+	if maxBulkURLs <= 0 {
+		maxBulkURLs = 1
+	}
+	if maxPageSize <= 0 {
+		maxPageSize = 1
+	}
+	ownHostSet := make(map[string]struct{}, len(ownHosts))
+	for _, host := range ownHosts {
+		ownHostSet[requestHostname(host)] = struct{}{}
+	}
 	return &urlService{
-		generator:  generator,
-		validator:  validator,
-		repository: repository,
-		cache:      cache,
-		logger:     logger,
-		maxRetries: maxRetries,
+		generator:       generator,
+		validator:       validator,
+		safetyChecker:   safetyChecker,
+		resolver:        resolver,
+		repository:      repository,
+		clickRepository: clickRepository,
+		domains:         domains,
+		workspaces:      workspaceAccess{repository: workspaces},
+		clickRecorder:   clickRecorder,
+		cache:           cache,
+		counter:         counter,
+		hasher:          hasher,
+		unlockTokens:    unlockTokens,
+		logger:          logger,
+		ownHosts:        ownHostSet,
+		maxRetries:      maxRetries,
+		maxBulkURLs:     maxBulkURLs,
+		maxPageSize:     maxPageSize,
 	}
 }
 
@@ -88,11 +224,29 @@ func (s *urlService) CreateShortURL(
 		logger.String("service", "URLService"),
 		logger.String("operation", "CreateShortURL"),
 		logger.String("userID", userID),
-		logger.String("longURL", req.LongURL))
+		logger.String("longURL", req.LongURL),
+		logger.String("alias", req.Alias),
+		logger.String("domain", req.Domain),
+		logger.String("workspaceID", req.WorkspaceID))
 
-	url, err := s.createShortURLWithRetries(ctx, userID, req, s.maxRetries)
+	var url *entity.URL
+	workspaceID, err := s.workspaces.resolve(ctx, userID, req.WorkspaceID, workspaceEntity.RoleEditor, createURLsAction)
+	var domain string
+	if err == nil {
+		domain, err = s.requestDomain(ctx, userID, req.Domain)
+	}
+	if err == nil {
+		req, err = s.resolveDestination(ctx, req)
+	}
+	switch {
+	case err != nil:
+	case strings.TrimSpace(req.Alias) != "":
+		url, err = s.createAliasedURL(ctx, userID, workspaceID, domain, req)
+	default:
+		url, err = s.createShortURLWithRetries(ctx, userID, workspaceID, domain, req, s.maxRetries)
+	}
 	if err != nil {
-		s.logger.Error(ctx, "Failed to create short URL after retries", logger.Error(err))
+		s.logger.Error(ctx, "Failed to create short URL", logger.Error(err))
 		return nil, err
 	}
 
@@ -108,6 +262,8 @@ func (s *urlService) CreateShortURL(
 func (s *urlService) createShortURLWithRetries(
 	ctx context.Context,
 	userID string,
+	workspaceID string,
+	domain string,
 	req *valueobject.CreateURLRequest,
 	retriesLeft int,
 ) (*entity.URL, error) {
@@ -120,126 +276,1377 @@ func (s *urlService) createShortURLWithRetries(
 		return nil, errors.InternalError("short code generation failed")
 	}
 
-	exists, err := s.repository.ExistsByShortCode(ctx, shortCode)
+	exists, err := s.repository.ExistsByShortCode(ctx, domain, shortCode)
 	if err != nil {
 		return nil, errors.InternalError("database query failed")
 	}
 	if exists {
 		// Retry with a new short code
-		return s.createShortURLWithRetries(ctx, userID, req, retriesLeft-1)
+		return s.createShortURLWithRetries(ctx, userID, workspaceID, domain, req, retriesLeft-1)
 	}
 
-	// Generate UUID for new URL
-	urlID := utils.GenerateRandomUUID()
+	url, err := s.newURL(ctx, userID, workspaceID, domain, shortCode, req)
+	if err != nil {
+		return nil, err
+	}
 
-	// Create new URL entity (validation happens in domain)
-	url, err := entity.NewURL(urlID, userID, shortCode, req.LongURL, s.validator)
+	savedURL, err := s.repository.Save(ctx, url)
 	if err != nil {
+		// The short code may have been taken between the existence check and the insert
+		if errors.GetErrorType(err) == errors.ErrorTypeConflict {
+			return s.createShortURLWithRetries(ctx, userID, workspaceID, domain, req, retriesLeft-1)
+		}
+		return nil, errors.InternalError("save operation failed")
+	}
+
+	return savedURL, nil
+}
+
+func (s *urlService) createAliasedURL(
+	ctx context.Context,
+	userID string,
+	workspaceID string,
+	domain string,
+	req *valueobject.CreateURLRequest,
+) (*entity.URL, error) {
+	alias := strings.TrimSpace(req.Alias)
+	if err := s.validator.ValidateShortCode(alias); err != nil {
 		return nil, errors.ValidationError(err.Error())
 	}
 
+	exists, err := s.repository.ExistsByShortCode(ctx, domain, alias)
+	if err != nil {
+		return nil, errors.InternalError("database query failed")
+	}
+	if exists {
+		return nil, errors.ConflictError("alias already in use")
+	}
+
+	url, err := s.newURL(ctx, userID, workspaceID, domain, alias, req)
+	if err != nil {
+		return nil, err
+	}
+
 	savedURL, err := s.repository.Save(ctx, url)
 	if err != nil {
+		// The alias may have been taken between the existence check and the insert
+		if errors.GetErrorType(err) == errors.ErrorTypeConflict {
+			return nil, errors.ConflictError("alias already in use")
+		}
 		return nil, errors.InternalError("save operation failed")
 	}
 
 	return savedURL, nil
 }
 
+// requestDomain validates the custom domain a URL is requested on, which has to be
+// verified by the user. It returns the hostname of the domain, empty for the default one.
+func (s *urlService) requestDomain(ctx context.Context, userID, hostname string) (string, error) {
+	hostname = entity.NormalizeHostname(hostname)
+	if hostname == "" {
+		return "", nil
+	}
+
+	domain, err := s.domains.FindVerified(ctx, hostname)
+	if err != nil {
+		if errors.GetErrorType(err) == errors.ErrorTypeNotFound {
+			return "", errors.ValidationError("domain is not verified")
+		}
+		return "", errors.InternalError("database query failed")
+	}
+	if !domain.IsOwnedBy(userID) {
+		return "", errors.ValidationError("domain is not verified")
+	}
+
+	return domain.Hostname(), nil
+}
+
+// bulkURL tracks one request of a bulk creation until it is saved or fails
+type bulkURL struct {
+	index int
+	req   *valueobject.CreateURLRequest
+	// record is the imported URL the request was made from, if any
+	record      *valueobject.URLRecord
+	workspaceID string
+	domain      string
+	alias       string
+	url         *entity.URL
+}
+
+// conflict is the error reported when the short code chosen for the request is taken
+func (b *bulkURL) conflict() error {
+	if b.record != nil {
+		return errors.ConflictError("short code already in use")
+	}
+	return errors.ConflictError("alias already in use")
+}
+
+func (s *urlService) CreateShortURLs(
+	ctx context.Context,
+	userID string,
+	reqs []valueobject.CreateURLRequest,
+) (*valueobject.BulkCreateURLResponse, error) {
+	s.logger.Info(ctx, "Processing bulk create short URL request",
+		logger.String("service", "URLService"),
+		logger.String("operation", "CreateShortURLs"),
+		logger.String("userID", userID),
+		logger.Int("count", len(reqs)))
+
+	if err := s.validateBulkSize(len(reqs)); err != nil {
+		return nil, err
+	}
+
+	items := make([]*bulkURL, len(reqs))
+	for i := range reqs {
+		items[i] = &bulkURL{index: i, req: &reqs[i]}
+	}
+
+	response := s.createBulkURLs(ctx, userID, items)
+
+	s.logger.Info(ctx, "Bulk short URL creation completed",
+		logger.String("userID", userID),
+		logger.Int("created", response.Created),
+		logger.Int("failed", response.Failed))
+
+	return response, nil
+}
+
+func (s *urlService) ExportURLs(
+	ctx context.Context,
+	userID, workspaceID string,
+	write func(record valueobject.URLRecord) error,
+) error {
+	s.logger.Info(ctx, "Processing export URLs request",
+		logger.String("service", "URLService"),
+		logger.String("operation", "ExportURLs"),
+		logger.String("userID", userID),
+		logger.String("workspaceID", workspaceID))
+
+	workspaceID, err := s.workspaces.resolve(ctx, userID, workspaceID, workspaceEntity.RoleViewer, viewURLsAction)
+	if err != nil {
+		return err
+	}
+
+	batch := make([]*entity.URL, 0, exportBatchSize)
+	flush := func() error {
+		s.includePendingRedirects(ctx, batch...)
+		for _, url := range batch {
+			if err := write(valueobject.CreateURLRecord(url)); err != nil {
+				return err
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	err = s.repository.ForEachByWorkspaceID(ctx, workspaceID, func(url *entity.URL) error {
+		if batch = append(batch, url); len(batch) == exportBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
+
+func (s *urlService) ImportURLs(
+	ctx context.Context,
+	userID, workspaceID string,
+	records []valueobject.URLRecord,
+) (*valueobject.BulkCreateURLResponse, error) {
+	s.logger.Info(ctx, "Processing import URLs request",
+		logger.String("service", "URLService"),
+		logger.String("operation", "ImportURLs"),
+		logger.String("userID", userID),
+		logger.String("workspaceID", workspaceID),
+		logger.Int("count", len(records)))
+
+	if err := s.validateBulkSize(len(records)); err != nil {
+		return nil, err
+	}
+
+	// Imported short codes are kept like aliases, so taken ones are reported as conflicts
+	items := make([]*bulkURL, len(records))
+	for i := range records {
+		record := &records[i]
+		items[i] = &bulkURL{
+			index: i,
+			req: &valueobject.CreateURLRequest{
+				LongURL:     record.LongURL,
+				Alias:       record.ShortCode,
+				Domain:      record.Domain,
+				WorkspaceID: workspaceID,
+			},
+			record: record,
+		}
+	}
+
+	response := s.createBulkURLs(ctx, userID, items)
+
+	s.logger.Info(ctx, "URL import completed",
+		logger.String("userID", userID),
+		logger.Int("created", response.Created),
+		logger.Int("failed", response.Failed))
+
+	return response, nil
+}
+
+// validateBulkSize checks the number of URLs created by a single bulk request
+func (s *urlService) validateBulkSize(count int) error {
+	if count == 0 {
+		return errors.ValidationError("at least one URL is required")
+	}
+	if count > s.maxBulkURLs {
+		return errors.ValidationError(fmt.Sprintf("at most %d URLs can be created at once", s.maxBulkURLs))
+	}
+	return nil
+}
+
+// createBulkURLs saves the URLs of a bulk creation, reporting the outcome for each of them
+func (s *urlService) createBulkURLs(
+	ctx context.Context,
+	userID string,
+	items []*bulkURL,
+) *valueobject.BulkCreateURLResponse {
+	results := make([]valueobject.BulkCreateURLResult, len(items))
+	// Short codes claimed by requests of this batch, so that none is used twice
+	claimed := make(map[repository.ShortLink]struct{}, len(items))
+	// Workspaces and domains already checked for this batch, by requested ID and hostname
+	workspaces := make(map[string]string)
+	domains := make(map[string]string)
+	pending := make([]*bulkURL, 0, len(items))
+	for _, item := range items {
+		results[item.index].Index = item.index
+
+		// Hashing is deliberately slow, which would make large batches time out
+		if item.req.Password != "" {
+			failBulkURL(results, item.index, errors.ValidationError("password protected URLs cannot be created in bulk"))
+			continue
+		}
+		if item.record != nil {
+			if err := validateURLRecord(item.record); err != nil {
+				failBulkURL(results, item.index, err)
+				continue
+			}
+		}
+
+		workspaceID, checked := workspaces[item.req.WorkspaceID]
+		if !checked {
+			var err error
+			workspaceID, err = s.workspaces.resolve(ctx, userID, item.req.WorkspaceID,
+				workspaceEntity.RoleEditor, createURLsAction)
+			if err != nil {
+				failBulkURL(results, item.index, err)
+				continue
+			}
+			workspaces[item.req.WorkspaceID] = workspaceID
+		}
+
+		domain, checked := domains[item.req.Domain]
+		if !checked {
+			var err error
+			if domain, err = s.requestDomain(ctx, userID, item.req.Domain); err != nil {
+				failBulkURL(results, item.index, err)
+				continue
+			}
+			domains[item.req.Domain] = domain
+		}
+
+		alias := strings.TrimSpace(item.req.Alias)
+		if alias != "" {
+			if err := s.validator.ValidateShortCode(alias); err != nil {
+				failBulkURL(results, item.index, errors.ValidationError(err.Error()))
+				continue
+			}
+			link := repository.ShortLink{Domain: domain, ShortCode: alias}
+			if _, taken := claimed[link]; taken {
+				failBulkURL(results, item.index, item.conflict())
+				continue
+			}
+			claimed[link] = struct{}{}
+		}
+
+		item.workspaceID = workspaceID
+		item.domain = domain
+		item.alias = alias
+		pending = append(pending, item)
+	}
+
+	pending = s.resolveBulkDestinations(ctx, pending, results)
+
+	for attempt := 0; len(pending) > 0; attempt++ {
+		if attempt >= s.maxRetries {
+			for _, item := range pending {
+				failBulkURL(results, item.index, errors.InternalError("max retries exceeded"))
+			}
+			break
+		}
+		pending = s.saveBulkURLs(ctx, userID, pending, claimed, results)
+	}
+
+	response := &valueobject.BulkCreateURLResponse{Results: results}
+	for _, result := range results {
+		if result.Error == "" {
+			response.Created++
+		} else {
+			response.Failed++
+		}
+	}
+	return response
+}
+
+// resolveBulkDestinations resolves the destinations of the items, a few at a time, and
+// returns the items whose destination was resolved. Items still unresolved when the
+// batch runs out of time fail.
+func (s *urlService) resolveBulkDestinations(
+	ctx context.Context,
+	items []*bulkURL,
+	results []valueobject.BulkCreateURLResult,
+) []*bulkURL {
+	ctx, cancel := context.WithTimeout(ctx, bulkResolveTimeout)
+	defer cancel()
+
+	errs := make([]error, len(items))
+	slots := make(chan struct{}, bulkResolveConcurrency)
+	var wg sync.WaitGroup
+	for i, item := range items {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			req, err := s.resolveDestination(ctx, item.req)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			item.req = req
+		}()
+	}
+	wg.Wait()
+
+	resolved := items[:0]
+	for i, item := range items {
+		if errs[i] != nil {
+			failBulkURL(results, item.index, errs[i])
+			continue
+		}
+		resolved = append(resolved, item)
+	}
+	return resolved
+}
+
+// validateURLRecord checks the fields an imported URL carries over from its origin
+func validateURLRecord(record *valueobject.URLRecord) error {
+	if record.Redirects < 0 {
+		return errors.ValidationError("redirects cannot be negative")
+	}
+	if record.CreatedAt.After(time.Now()) {
+		return errors.ValidationError("created_at cannot be in the future")
+	}
+	return nil
+}
+
+// importedURL carries the redirect count and creation time of an imported record over to a new URL
+func importedURL(url *entity.URL, record *valueobject.URLRecord) *entity.URL {
+	snapshot := url.Snapshot()
+	snapshot.Redirects = record.Redirects
+	if !record.CreatedAt.IsZero() {
+		snapshot.CreatedAt = record.CreatedAt.UTC()
+	}
+	return entity.NewURLFromRepository(snapshot)
+}
+
+// saveBulkURLs makes one attempt at saving the pending requests of a bulk creation, with
+// a single existence check and a single batch insert. Requests that fail are recorded in
+// the results; those whose generated short code turned out to be taken are returned to
+// be tried again with a new code.
+func (s *urlService) saveBulkURLs(
+	ctx context.Context,
+	userID string,
+	pending []*bulkURL,
+	claimed map[repository.ShortLink]struct{},
+	results []valueobject.BulkCreateURLResult,
+) []*bulkURL {
+	batch := make([]*bulkURL, 0, len(pending))
+	for _, item := range pending {
+		shortCode := item.alias
+		if shortCode == "" {
+			generated, err := s.generateUnclaimedShortCode(claimed, item.domain)
+			if err != nil {
+				failBulkURL(results, item.index, err)
+				continue
+			}
+			shortCode = generated
+		}
+
+		url, err := s.newURL(ctx, userID, item.workspaceID, item.domain, shortCode, item.req)
+		if err != nil {
+			failBulkURL(results, item.index, err)
+			continue
+		}
+		if item.record != nil {
+			url = importedURL(url, item.record)
+		}
+		item.url = url
+		batch = append(batch, item)
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+
+	links := make([]repository.ShortLink, len(batch))
+	for i, item := range batch {
+		links[i] = shortLink(item.url)
+	}
+	existing, err := s.repository.ExistsByShortCodes(ctx, links)
+	if err != nil {
+		for _, item := range batch {
+			failBulkURL(results, item.index, errors.InternalError("database query failed"))
+		}
+		return nil
+	}
+
+	var retry []*bulkURL
+	toSave := make([]*bulkURL, 0, len(batch))
+	for _, item := range batch {
+		if existing[shortLink(item.url)] {
+			retry = retryOrConflict(results, item, retry)
+			continue
+		}
+		toSave = append(toSave, item)
+	}
+	if len(toSave) == 0 {
+		return retry
+	}
+
+	urls := make([]*entity.URL, len(toSave))
+	for i, item := range toSave {
+		urls[i] = item.url
+	}
+	savedURLs, err := s.repository.SaveBatch(ctx, urls)
+	if err != nil {
+		for _, item := range toSave {
+			failBulkURL(results, item.index, errors.InternalError("save operation failed"))
+		}
+		return retry
+	}
+
+	for i, item := range toSave {
+		// The short code may have been taken between the existence check and the insert
+		if savedURLs[i] == nil {
+			retry = retryOrConflict(results, item, retry)
+			continue
+		}
+		results[item.index].ID = savedURLs[i].ID()
+		results[item.index].Domain = savedURLs[i].Domain()
+		results[item.index].ShortCode = savedURLs[i].ShortCode()
+	}
+
+	return retry
+}
+
+// retryOrConflict handles a bulk request whose short code is taken: aliases fail with a
+// conflict, generated codes are queued for another attempt
+func retryOrConflict(
+	results []valueobject.BulkCreateURLResult,
+	item *bulkURL,
+	retry []*bulkURL,
+) []*bulkURL {
+	if item.alias != "" {
+		failBulkURL(results, item.index, item.conflict())
+		return retry
+	}
+	return append(retry, item)
+}
+
+// shortLink returns the domain and short code a URL is served under
+func shortLink(url *entity.URL) repository.ShortLink {
+	return repository.ShortLink{Domain: url.Domain(), ShortCode: url.ShortCode()}
+}
+
+// generateUnclaimedShortCode generates a short code not yet used on the domain within
+// the current batch
+func (s *urlService) generateUnclaimedShortCode(
+	claimed map[repository.ShortLink]struct{},
+	domain string,
+) (string, error) {
+	for attempt := 0; attempt < s.maxRetries; attempt++ {
+		shortCode, err := s.generator.GenerateShortCode()
+		if err != nil {
+			return "", errors.InternalError("short code generation failed")
+		}
+		link := repository.ShortLink{Domain: domain, ShortCode: shortCode}
+		if _, taken := claimed[link]; !taken {
+			claimed[link] = struct{}{}
+			return shortCode, nil
+		}
+	}
+	return "", errors.InternalError("max retries exceeded")
+}
+
+// failBulkURL records the error for the request at index
+func failBulkURL(results []valueobject.BulkCreateURLResult, index int, err error) {
+	results[index].Error = err.Error()
+	results[index].ErrorType = string(errors.GetErrorType(err))
+}
+
+// resolveDestination returns the request with the destination it gets resolved to.
+// Destinations are resolved once per request, not on every attempt to save it.
+func (s *urlService) resolveDestination(
+	ctx context.Context,
+	req *valueobject.CreateURLRequest,
+) (*valueobject.CreateURLRequest, error) {
+	longURL, err := s.resolver.Resolve(ctx, req.LongURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := *req
+	resolved.LongURL = longURL
+	return &resolved, nil
+}
+
+// newURL builds a URL entity of the workspace for the given domain and short code,
+// applying any expiration limits from the request
+func (s *urlService) newURL(
+	ctx context.Context,
+	userID string,
+	workspaceID string,
+	domain string,
+	shortCode string,
+	req *valueobject.CreateURLRequest,
+) (*entity.URL, error) {
+	// Generate UUID for new URL
+	urlID := utils.GenerateRandomUUID()
+
+	// Create new URL entity (validation happens in domain)
+	url, err := entity.NewURL(urlID, userID, workspaceID, domain, shortCode, req.LongURL, s.validator)
+	if err != nil {
+		return nil, errors.ValidationError(err.Error())
+	}
+	if err := s.checkSafety(ctx, url.LongURL()); err != nil {
+		return nil, err
+	}
+	if err := s.checkDomainDestination(ctx, url.LongURL()); err != nil {
+		return nil, err
+	}
+
+	if req.ExpiresAt != nil {
+		if err := url.UpdateExpiresAt(*req.ExpiresAt, s.validator); err != nil {
+			return nil, errors.ValidationError(err.Error())
+		}
+	}
+	if req.MaxClicks != nil {
+		if err := url.UpdateMaxClicks(*req.MaxClicks, s.validator); err != nil {
+			return nil, errors.ValidationError(err.Error())
+		}
+	}
+	if req.RedirectType != 0 {
+		if err := url.UpdateRedirectType(req.RedirectType, s.validator); err != nil {
+			return nil, errors.ValidationError(err.Error())
+		}
+	}
+	if req.ForwardQuery {
+		url.UpdateForwardQuery(true)
+	}
+	if req.Title != "" {
+		if err := url.UpdateTitle(req.Title, s.validator); err != nil {
+			return nil, errors.ValidationError(err.Error())
+		}
+	}
+	if req.Description != "" {
+		if err := url.UpdateDescription(req.Description, s.validator); err != nil {
+			return nil, errors.ValidationError(err.Error())
+		}
+	}
+	if len(req.Tags) > 0 {
+		if err := url.SetTags(req.Tags, s.validator); err != nil {
+			return nil, errors.ValidationError(err.Error())
+		}
+	}
+	if req.Password != "" {
+		if err := s.setPassword(url, req.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	return url, nil
+}
+
+// checkSafety rejects destinations flagged by the safety checker
+func (s *urlService) checkSafety(ctx context.Context, longURL string) error {
+	reason, err := s.safetyChecker.CheckURL(ctx, longURL)
+	if err != nil {
+		s.logger.Error(ctx, "URL safety check failed",
+			logger.String("longURL", longURL),
+			logger.Error(err))
+		return errors.InternalError("URL safety check failed")
+	}
+	if reason != "" {
+		s.logger.Warn(ctx, "Unsafe URL rejected",
+			logger.String("longURL", longURL),
+			logger.String("reason", reason))
+		return errors.ValidationError(fmt.Sprintf("URL is flagged as unsafe (%s)", reason))
+	}
+	return nil
+}
+
+// checkDomainDestination rejects destinations pointing at short links on verified
+// custom domains, which the validator only knows for the hosts of this service
+func (s *urlService) checkDomainDestination(ctx context.Context, longURL string) error {
+	parsed, err := neturl.Parse(longURL)
+	if err != nil {
+		return errors.ValidationError("invalid URL format")
+	}
+	shortCode := strings.Trim(parsed.Path, "/")
+	if strings.Contains(shortCode, "/") || s.validator.ValidateShortCode(shortCode) != nil {
+		return nil
+	}
+
+	hostname := entity.NormalizeHostname(parsed.Hostname())
+	if _, err := s.domains.FindVerified(ctx, hostname); err != nil {
+		if errors.GetErrorType(err) == errors.ErrorTypeNotFound {
+			return nil
+		}
+		s.logger.Error(ctx, "Domain lookup failed",
+			logger.String("hostname", hostname),
+			logger.Error(err))
+		return errors.InternalError("database query failed")
+	}
+	return errors.ValidationError("URL cannot point to a short link")
+}
+
+// setPassword protects the URL with a password, separating invalid passwords from hashing failures
+func (s *urlService) setPassword(url *entity.URL, password string) error {
+	if err := s.validator.ValidateLinkPassword(password); err != nil {
+		return errors.ValidationError(err.Error())
+	}
+	if err := url.SetPassword(password, s.validator, s.hasher); err != nil {
+		return errors.InternalError("password hashing failed")
+	}
+	return nil
+}
+
+// GetOriginalURL resolves a short code requested through host to its destination. Hosts
+// that are verified custom domains serve their own short codes, any other host serves
+// those of the default domain. Password protected URLs are only resolved with an unlock
+// token issued by UnlockURL for their current password.
 func (s *urlService) GetOriginalURL(
 	ctx context.Context,
+	host string,
 	shortCode string,
-) (string, error) {
+	unlockToken string,
+) (*valueobject.Redirect, error) {
 	s.logger.Info(ctx, "Processing get original URL request",
 		logger.String("service", "URLService"),
 		logger.String("operation", "GetOriginalURL"),
+		logger.String("host", host),
 		logger.String("shortCode", shortCode))
 
-	// Try to get from cache first
-	if cachedURL, err := s.cache.GetOriginalURL(ctx, shortCode); err == nil && cachedURL != "" {
-		s.logger.Debug(ctx, "URL found in cache",
-			logger.String("shortCode", shortCode))
-		s.repository.IncrementRedirects(ctx, shortCode)
-		return cachedURL, nil
+	// Try to get from cache first. URLs of custom domains are cached under the hostname,
+	// so cached redirects need no lookup of the domain.
+	hostname := requestHostname(host)
+	if !s.isOwnHost(hostname) {
+		if cached := s.cachedRedirect(ctx, hostname, shortCode); cached != nil {
+			return cached, nil
+		}
+	}
+	domain, err := s.hostDomain(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+	if domain == "" {
+		if cached := s.cachedRedirect(ctx, "", shortCode); cached != nil {
+			return cached, nil
+		}
 	}
 
 	// Find URL by short code in repository
-	url, err := s.repository.FindByShortCode(ctx, shortCode)
+	url, err := s.repository.FindByShortCode(ctx, domain, shortCode)
 	if err != nil {
 		s.logger.Warn(ctx, "URL not found",
 			logger.String("shortCode", shortCode))
-		return "", errors.NotFoundError("URL not found")
+		return nil, errors.NotFoundError("URL not found")
+	}
+
+	// Click limits have to account for redirects that are not persisted yet. Those of
+	// click-limited URLs are added to the repository right away, but ones counted before
+	// the limit was set can still be pending.
+	pending := 0
+	if url.MaxClicks() != nil {
+		if counts, err := s.counter.Pending(ctx, url.ID()); err == nil {
+			pending = counts[url.ID()]
+		}
+		url.IncludePendingRedirects(pending)
+	}
+
+	if url.IsDisabled() {
+		s.logger.Info(ctx, "URL is disabled",
+			logger.String("shortCode", shortCode))
+		return nil, errors.GoneError("URL has been disabled")
 	}
 
-	// Cache the result for future requests
-	s.cache.SetShortURL(ctx, shortCode, url.LongURL(), time.Minute*5)
+	if url.IsExpired(time.Now().UTC()) {
+		s.logger.Info(ctx, "URL has expired",
+			logger.String("shortCode", shortCode))
+		return nil, errors.GoneError("URL has expired")
+	}
+
+	if url.IsPasswordProtected() && !s.unlockTokens.VerifyUnlockToken(unlockToken, shortCode, url.PasswordHash()) {
+		s.logger.Info(ctx, "URL is password protected",
+			logger.String("shortCode", shortCode))
+		return nil, errors.ForbiddenError("URL is password protected")
+	}
+
+	// Cache the result for future requests. Click-limited URLs are never cached since
+	// the limit has to be checked against the stored redirect count on every request,
+	// and neither are password protected URLs, which have to check the unlock token.
+	redirect := valueobject.CreateRedirect(url)
+	if url.MaxClicks() == nil && !url.IsPasswordProtected() {
+		s.cache.SetShortURL(ctx, domain, shortCode, redirect, cacheTTL(url))
+	}
 
-	s.repository.IncrementRedirects(ctx, shortCode)
+	// The redirect count read above may be stale by now, so the redirect of a
+	// click-limited URL is only served if it can still be claimed
+	if url.MaxClicks() != nil {
+		claimed, err := s.repository.ClaimRedirect(ctx, url.ID(), pending)
+		if err != nil {
+			return nil, err
+		}
+		if !claimed {
+			s.logger.Info(ctx, "URL has reached its click limit",
+				logger.String("shortCode", shortCode))
+			return nil, errors.GoneError("URL has expired")
+		}
+	} else {
+		s.countRedirect(ctx, url.ID())
+	}
 
 	s.logger.Info(ctx, "URL retrieval successful",
 		logger.String("shortCode", shortCode))
 
-	return url.LongURL(), nil
+	return &redirect, nil
+}
+
+// cachedRedirect returns the cached redirect for a short code of the domain, counting
+// the redirect, or nil when it is not cached
+func (s *urlService) cachedRedirect(ctx context.Context, domain, shortCode string) *valueobject.Redirect {
+	cached, err := s.cache.GetRedirect(ctx, domain, shortCode)
+	// Entries cached before redirects were counted by URL ID are reloaded
+	if err != nil || cached == nil || cached.URLID == "" {
+		return nil
+	}
+
+	s.logger.Debug(ctx, "URL found in cache",
+		logger.String("domain", domain),
+		logger.String("shortCode", shortCode))
+	s.countRedirect(ctx, cached.URLID)
+	return cached
+}
+
+// requestHostname returns the normalized hostname of a Host header, without its port
+func requestHostname(host string) string {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	return entity.NormalizeHostname(strings.Trim(host, "[]"))
+}
+
+// isOwnHost checks if the hostname serves the default domain
+func (s *urlService) isOwnHost(hostname string) bool {
+	_, ok := s.ownHosts[hostname]
+	return hostname == "" || ok
+}
+
+// hostDomain returns the domain whose short codes the hostname serves: the hostname
+// itself when it is a verified custom domain, the default domain otherwise
+func (s *urlService) hostDomain(ctx context.Context, hostname string) (string, error) {
+	if s.isOwnHost(hostname) {
+		return "", nil
+	}
+
+	domain, err := s.domains.FindVerified(ctx, hostname)
+	if err != nil {
+		if errors.GetErrorType(err) == errors.ErrorTypeNotFound {
+			return "", nil
+		}
+		s.logger.Error(ctx, "Domain lookup failed",
+			logger.String("hostname", hostname),
+			logger.Error(err))
+		return "", errors.InternalError("database query failed")
+	}
+	return domain.Hostname(), nil
+}
+
+func (s *urlService) UnlockURL(
+	ctx context.Context,
+	host string,
+	shortCode string,
+	password string,
+) (*valueobject.UnlockURLResponse, error) {
+	s.logger.Info(ctx, "Processing unlock URL request",
+		logger.String("service", "URLService"),
+		logger.String("operation", "UnlockURL"),
+		logger.String("host", host),
+		logger.String("shortCode", shortCode))
+
+	domain, err := s.hostDomain(ctx, requestHostname(host))
+	if err != nil {
+		return nil, err
+	}
+	url, err := s.repository.FindByShortCode(ctx, domain, shortCode)
+	if err != nil {
+		return nil, errors.NotFoundError("URL not found")
+	}
+
+	if url.IsDisabled() {
+		return nil, errors.GoneError("URL has been disabled")
+	}
+	if url.MaxClicks() != nil {
+		s.includePendingRedirects(ctx, url)
+	}
+	if url.IsExpired(time.Now().UTC()) {
+		return nil, errors.GoneError("URL has expired")
+	}
+
+	if !url.IsPasswordProtected() {
+		return nil, errors.ValidationError("URL is not password protected")
+	}
+
+	if err := url.VerifyPassword(password, s.hasher); err != nil {
+		s.logger.Warn(ctx, "Incorrect URL password",
+			logger.String("shortCode", shortCode))
+		return nil, errors.UnauthorizedError("incorrect password")
+	}
+
+	token, expiresAt, err := s.unlockTokens.GenerateUnlockToken(shortCode, url.PasswordHash())
+	if err != nil {
+		return nil, errors.InternalError("unlock token generation failed")
+	}
+
+	s.logger.Info(ctx, "URL unlocked",
+		logger.String("shortCode", shortCode))
+
+	return &valueobject.UnlockURLResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// countRedirect adds a redirect to the pending counts flushed in the background,
+// falling back to a direct repository update when the counter is unavailable
+func (s *urlService) countRedirect(ctx context.Context, urlID string) {
+	if err := s.counter.Increment(ctx, urlID); err != nil {
+		s.repository.AddRedirects(ctx, map[string]int{urlID: 1})
+	}
+}
+
+// includePendingRedirects merges redirects counted but not yet flushed into the URLs,
+// leaving the persisted counts in place if the counter is unavailable
+func (s *urlService) includePendingRedirects(ctx context.Context, urls ...*entity.URL) {
+	if len(urls) == 0 {
+		return
+	}
+
+	urlIDs := make([]string, len(urls))
+	for i, url := range urls {
+		urlIDs[i] = url.ID()
+	}
+
+	pending, err := s.counter.Pending(ctx, urlIDs...)
+	if err != nil {
+		return
+	}
+
+	for _, url := range urls {
+		url.IncludePendingRedirects(pending[url.ID()])
+	}
+}
+
+// cacheTTL returns how long a URL may be cached without outliving its expiration time
+func cacheTTL(url *entity.URL) time.Duration {
+	ttl := defaultCacheTTL
+	if expiresAt := url.ExpiresAt(); expiresAt != nil {
+		if remaining := time.Until(*expiresAt); remaining < ttl {
+			ttl = remaining
+		}
+	}
+	return ttl
+}
+
+func (s *urlService) RecordClick(
+	ctx context.Context,
+	urlID string,
+	req *valueobject.ClickRequest,
+) {
+	click := entity.NewClick(
+		urlID,
+		req.Referrer,
+		req.UserAgent,
+		req.IPAddress,
+		req.AcceptLanguage,
+		time.Now().UTC(),
+	)
+	s.clickRecorder.Record(ctx, click)
 }
 
 func (s *urlService) GetAnalytics(
 	ctx context.Context,
 	shortCode string,
 	userID string,
-) (int, error) {
-	url, err := s.repository.FindByShortCode(ctx, shortCode)
+	req *valueobject.AnalyticsRequest,
+) (*valueobject.AnalyticsResponse, error) {
+	to := time.Now().UTC()
+	if req.To != nil {
+		to = req.To.UTC()
+	}
+	from := to.Add(-defaultAnalyticsRange)
+	if req.From != nil {
+		from = req.From.UTC()
+	}
+	if !from.Before(to) {
+		return nil, errors.ValidationError("from must be before to")
+	}
+
+	interval := entity.ClickIntervalDay
+	if req.Interval != "" {
+		interval = entity.ClickInterval(req.Interval)
+	}
+	if !interval.IsValid() {
+		return nil, errors.ValidationError("interval must be one of hour, day, week or month")
+	}
+
+	buckets := 0
+	for start := interval.Truncate(from); start.Before(to); start = interval.Next(start) {
+		if buckets++; buckets > maxAnalyticsBuckets {
+			return nil, errors.ValidationError("date range is too large for the requested interval")
+		}
+	}
+
+	top := req.Top
+	if top <= 0 {
+		top = defaultAnalyticsTop
+	}
+	if top > maxAnalyticsTop {
+		top = maxAnalyticsTop
+	}
+
+	url, err := s.repository.FindByShortCode(ctx, entity.NormalizeHostname(req.Domain), shortCode)
 	if err != nil {
-		return -1, errors.NotFoundError("URL not found")
+		return nil, errors.NotFoundError("URL not found")
 	}
 
-	// Check ownership using domain method
-	if !url.IsOwnedBy(userID) {
-		return -1, errors.UnauthorizedError("not authorized to view analytics")
+	if _, err := s.workspaces.authorize(ctx, userID, url.WorkspaceID(), workspaceEntity.RoleViewer,
+		"view analytics"); err != nil {
+		return nil, err
 	}
 
-	return url.Redirects(), nil
+	s.includePendingRedirects(ctx, url)
+
+	stats, err := s.clickRepository.GetStats(ctx, url.ID(), from, to, interval, top)
+	if err != nil {
+		return nil, errors.InternalError("analytics query failed")
+	}
+
+	analytics := valueobject.CreateAnalyticsResponse(url, stats, from, to, interval)
+	return &analytics, nil
 }
 
 func (s *urlService) GetPaginatedURLs(
 	ctx context.Context,
 	userID string,
-	limit int,
-	offset int,
-) ([]valueobject.URLResponse, error) {
-	urls, err := s.repository.FindByUserID(ctx, userID, limit, offset)
+	req *valueobject.URLListRequest,
+) (*valueobject.URLListResponse, error) {
+	filter, err := urlFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.urlPage(req)
+	if err != nil {
+		return nil, err
+	}
+	workspaceID, err := s.workspaces.resolve(ctx, userID, req.WorkspaceID, workspaceEntity.RoleViewer, viewURLsAction)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.listURLs(ctx, workspaceID, filter, page)
+}
+
+func (s *urlService) ListAllURLs(
+	ctx context.Context,
+	req *valueobject.URLListRequest,
+) (*valueobject.URLListResponse, error) {
+	filter, err := urlFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.urlPage(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.listURLs(ctx, req.WorkspaceID, filter, page)
+}
+
+// listURLs returns a page of the URLs of the workspace, or of every workspace when
+// workspaceID is empty, that match the filter
+func (s *urlService) listURLs(
+	ctx context.Context,
+	workspaceID string,
+	filter repository.URLFilter,
+	page repository.URLPage,
+) (*valueobject.URLListResponse, error) {
+	// One URL more than the page holds tells whether another page follows
+	limit := page.Limit
+	page.Limit++
+
+	var urls []*entity.URL
+	var total int
+	var err error
+	if workspaceID == "" {
+		urls, err = s.repository.FindAll(ctx, filter, page)
+		if err == nil {
+			total, err = s.repository.CountAll(ctx, filter)
+		}
+	} else {
+		urls, err = s.repository.FindByWorkspaceID(ctx, workspaceID, filter, page)
+		if err == nil {
+			total, err = s.repository.CountByWorkspaceID(ctx, workspaceID, filter)
+		}
+	}
 	if err != nil {
 		return nil, errors.InternalError("query failed")
 	}
 
-	return valueobject.CreateGetURLsResponse(urls), nil
+	var nextCursor string
+	if len(urls) > limit {
+		urls = urls[:limit]
+		nextCursor = encodeURLCursor(page.Sort, repository.NewURLCursor(urls[limit-1]))
+	}
+
+	s.includePendingRedirects(ctx, urls...)
+
+	return &valueobject.URLListResponse{
+		Items:      valueobject.CreateGetURLsResponse(urls),
+		NextCursor: nextCursor,
+		Total:      total,
+	}, nil
+}
+
+// urlPage validates the order, size and cursor of the requested page of a URL listing
+func (s *urlService) urlPage(req *valueobject.URLListRequest) (repository.URLPage, error) {
+	page := repository.URLPage{Sort: repository.URLSort(req.Sort), Limit: req.Limit}
+	if page.Sort == "" {
+		page.Sort = repository.SortCreated
+	}
+	if !slices.Contains(repository.URLSorts, page.Sort) {
+		return page, errors.ValidationError(fmt.Sprintf("sort must be one of %v", repository.URLSorts))
+	}
+
+	limit, err := pageLimit(req.Limit, s.maxPageSize)
+	if err != nil {
+		return page, err
+	}
+	page.Limit = limit
+
+	if req.Cursor != "" {
+		after, ok := decodeURLCursor(page.Sort, req.Cursor)
+		if !ok {
+			return page, errors.ValidationError("invalid cursor")
+		}
+		page.After = after
+	}
+
+	return page, nil
+}
+
+// pageLimit validates a requested page size, defaulting and capping it
+func pageLimit(limit, maxPageSize int) (int, error) {
+	switch {
+	case limit < 0:
+		return 0, errors.ValidationError("limit must be positive")
+	case limit == 0:
+		return min(defaultPageSize, maxPageSize), nil
+	case limit > maxPageSize:
+		return maxPageSize, nil
+	}
+	return limit, nil
+}
+
+// urlFilter validates the filters of a URL listing
+func urlFilter(req *valueobject.URLListRequest) (repository.URLFilter, error) {
+	filter := repository.URLFilter{
+		UserID:      req.UserID,
+		Tags:        entity.NormalizeTags(req.Tags),
+		Search:      strings.TrimSpace(req.Search),
+		CreatedFrom: req.From,
+		CreatedTo:   req.To,
+		Deleted:     req.Trash,
+	}
+	if len(filter.Search) > maxSearchLength {
+		return filter, errors.ValidationError(fmt.Sprintf("search cannot exceed %d characters", maxSearchLength))
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return filter, errors.ValidationError("from must be before to")
+	}
+	return filter, nil
 }
 
 func (s *urlService) UpdateURL(
 	ctx context.Context,
-	urlID string,
 	userID string,
-	newURL string,
+	req *valueobject.URLUpdateRequest,
 ) error {
+	if req.NewURL != "" && req.RevertTo != 0 {
+		return errors.ValidationError("new_url and revert_to cannot be combined")
+	}
+	if req.NewURL == "" && req.RevertTo == 0 && req.ExpiresAt == nil && req.MaxClicks == nil && !req.ClearExpiration &&
+		req.Password == "" && !req.ClearPassword && req.RedirectType == 0 && req.ForwardQuery == nil &&
+		req.Title == nil && req.Description == nil && req.Tags == nil {
+		return errors.ValidationError("nothing to update")
+	}
+
+	url, err := s.findAuthorizedURL(ctx, userID, req.ID, workspaceEntity.RoleEditor, "update this URL")
+	if err != nil {
+		return err
+	}
+
+	newURL := req.NewURL
+	if req.RevertTo != 0 {
+		revision, err := s.repository.FindRevision(ctx, url.ID(), req.RevertTo)
+		if err != nil {
+			if errors.GetErrorType(err) == errors.ErrorTypeNotFound {
+				return errors.ValidationError("revision not found")
+			}
+			return errors.InternalError("query failed")
+		}
+		newURL = revision.OldURL()
+	}
+
+	// Update URL using domain methods (includes validation)
+	if newURL != "" {
+		if newURL, err = s.resolver.Resolve(ctx, newURL); err != nil {
+			return err
+		}
+		if err := url.UpdateLongURL(newURL, userID, s.validator); err != nil {
+			return errors.ValidationError(err.Error())
+		}
+		if url.PendingRevision() != nil {
+			if err := s.checkSafety(ctx, url.LongURL()); err != nil {
+				return err
+			}
+			if err := s.checkDomainDestination(ctx, url.LongURL()); err != nil {
+				return err
+			}
+		}
+	}
+	if req.ClearExpiration {
+		url.ClearExpiration()
+	}
+	if req.ExpiresAt != nil {
+		if err := url.UpdateExpiresAt(*req.ExpiresAt, s.validator); err != nil {
+			return errors.ValidationError(err.Error())
+		}
+	}
+	if req.MaxClicks != nil {
+		if err := url.UpdateMaxClicks(*req.MaxClicks, s.validator); err != nil {
+			return errors.ValidationError(err.Error())
+		}
+	}
+	if req.RedirectType != 0 {
+		if err := url.UpdateRedirectType(req.RedirectType, s.validator); err != nil {
+			return errors.ValidationError(err.Error())
+		}
+	}
+	if req.ForwardQuery != nil {
+		url.UpdateForwardQuery(*req.ForwardQuery)
+	}
+	if req.Title != nil {
+		if err := url.UpdateTitle(*req.Title, s.validator); err != nil {
+			return errors.ValidationError(err.Error())
+		}
+	}
+	if req.Description != nil {
+		if err := url.UpdateDescription(*req.Description, s.validator); err != nil {
+			return errors.ValidationError(err.Error())
+		}
+	}
+	if req.Tags != nil {
+		if err := url.SetTags(*req.Tags, s.validator); err != nil {
+			return errors.ValidationError(err.Error())
+		}
+	}
+	if req.ClearPassword {
+		url.ClearPassword()
+	}
+	if req.Password != "" {
+		if err := s.setPassword(url, req.Password); err != nil {
+			return err
+		}
+	}
+
+	// Save changes
+	if err := s.repository.Update(ctx, url); err != nil {
+		return err
+	}
+
+	// Invalidating only after the update keeps redirects in between from caching the
+	// old destination again
+	if err := s.cache.InvalidateShortURL(ctx, url.Domain(), url.ShortCode()); err != nil {
+		return errors.InternalError("cache invalidation failed")
+	}
+	return nil
+}
+
+func (s *urlService) DisableURL(ctx context.Context, urlID string, req *valueobject.DisableURLRequest) error {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return errors.ValidationError("reason is required")
+	}
+	if len(reason) > maxDisableReasonLength {
+		return errors.ValidationError(fmt.Sprintf("reason cannot exceed %d characters", maxDisableReasonLength))
+	}
+
 	url, err := s.repository.FindByID(ctx, urlID)
 	if err != nil {
-		return errors.NotFoundError("URL not found")
+		return err
+	}
+	if err := s.repository.Disable(ctx, url.ID(), reason); err != nil {
+		return err
 	}
 
-	// Check ownership using domain method
-	if !url.IsOwnedBy(userID) {
-		return errors.UnauthorizedError("not authorized to update this URL")
+	// Cached redirects would otherwise keep working until they expire, and redirects that
+	// loaded the link before it was disabled are kept from caching it again. Disabling
+	// again is harmless, so the request can be retried when the cache cannot be reached.
+	if err := s.cache.InvalidateShortURL(ctx, url.Domain(), url.ShortCode()); err != nil {
+		return errors.InternalError("cache invalidation failed")
 	}
 
-	// Update URL using domain method (includes validation)
-	if err := url.UpdateLongURL(newURL, s.validator); err != nil {
-		return errors.ValidationError(err.Error())
+	s.logger.Info(ctx, "URL disabled by administrator",
+		logger.String("urlID", url.ID()),
+		logger.String("shortCode", url.ShortCode()),
+		logger.String("reason", reason),
+		logger.String("operation", "DisableURL"))
+	return nil
+}
+
+func (s *urlService) GetURLHistory(
+	ctx context.Context,
+	userID, urlID string,
+	req *valueobject.URLHistoryRequest,
+) (*valueobject.URLHistoryResponse, error) {
+	if req.Before < 0 {
+		return nil, errors.ValidationError("before must be a revision ID")
+	}
+	limit, err := pageLimit(req.Limit, s.maxPageSize)
+	if err != nil {
+		return nil, err
 	}
 
-	// Invalidate cache
-	s.cache.InvalidateShortURL(ctx, url.ShortCode())
+	url, err := s.findAuthorizedURL(ctx, userID, urlID, workspaceEntity.RoleViewer, "view this URL's history")
+	if err != nil {
+		return nil, err
+	}
 
-	// Save changes
-	return s.repository.Update(ctx, url)
+	// One extra revision tells whether another page follows
+	revisions, err := s.repository.FindRevisions(ctx, url.ID(), req.Before, limit+1)
+	if err != nil {
+		return nil, errors.InternalError("query failed")
+	}
+
+	history := &valueobject.URLHistoryResponse{}
+	if len(revisions) > limit {
+		revisions = revisions[:limit]
+		history.NextBefore = revisions[limit-1].ID()
+	}
+	history.Revisions = valueobject.CreateURLRevisionsResponse(revisions)
+	return history, nil
+}
+
+func (s *urlService) AddURLTags(
+	ctx context.Context,
+	userID, urlID string,
+	tags []string,
+) (*valueobject.URLTagsResponse, error) {
+	if len(tags) == 0 {
+		return nil, errors.ValidationError("at least one tag is required")
+	}
+
+	url, err := s.findAuthorizedURL(ctx, userID, urlID, workspaceEntity.RoleEditor, "update this URL")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := url.SetTags(append(slices.Clone(url.Tags()), tags...), s.validator); err != nil {
+		return nil, errors.ValidationError(err.Error())
+	}
+	if err := s.repository.Update(ctx, url); err != nil {
+		return nil, err
+	}
+
+	response := valueobject.CreateURLTagsResponse(url)
+	return &response, nil
+}
+
+func (s *urlService) RemoveURLTag(
+	ctx context.Context,
+	userID, urlID, tag string,
+) (*valueobject.URLTagsResponse, error) {
+	url, err := s.findAuthorizedURL(ctx, userID, urlID, workspaceEntity.RoleEditor, "update this URL")
+	if err != nil {
+		return nil, err
+	}
+	if !url.HasTag(tag) {
+		return nil, errors.NotFoundError("tag not found")
+	}
+
+	remaining := slices.DeleteFunc(slices.Clone(url.Tags()), func(t string) bool {
+		return t == entity.NormalizeTag(tag)
+	})
+	if err := url.SetTags(remaining, s.validator); err != nil {
+		return nil, errors.ValidationError(err.Error())
+	}
+	if err := s.repository.Update(ctx, url); err != nil {
+		return nil, err
+	}
+
+	response := valueobject.CreateURLTagsResponse(url)
+	return &response, nil
+}
+
+// findAuthorizedURL loads a URL for a user whose role in its workspace includes the
+// required one. Action describes the request in errors.
+func (s *urlService) findAuthorizedURL(
+	ctx context.Context,
+	userID, urlID string,
+	required workspaceEntity.Role,
+	action string,
+) (*entity.URL, error) {
+	url, err := s.repository.FindByID(ctx, urlID)
+	if err != nil {
+		return nil, errors.NotFoundError("URL not found")
+	}
+	if _, err := s.workspaces.authorize(ctx, userID, url.WorkspaceID(), required, action); err != nil {
+		return nil, err
+	}
+	return url, nil
+}
+
+func (s *urlService) ListTags(ctx context.Context, userID, workspaceID string) ([]valueobject.TagResponse, error) {
+	workspaceID, err := s.workspaces.resolve(ctx, userID, workspaceID, workspaceEntity.RoleViewer, viewURLsAction)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.repository.ListTags(ctx, workspaceID)
+	if err != nil {
+		return nil, errors.InternalError("query failed")
+	}
+	return valueobject.CreateTagsResponse(tags), nil
 }
 
 func (s *urlService) DeleteURL(
@@ -247,18 +1654,36 @@ func (s *urlService) DeleteURL(
 	urlID string,
 	userID string,
 ) error {
-	url, err := s.repository.FindByID(ctx, urlID)
+	url, err := s.findAuthorizedURL(ctx, userID, urlID, workspaceEntity.RoleEditor, "delete this URL")
 	if err != nil {
-		return errors.NotFoundError("URL not found")
+		return err
 	}
 
-	// Check ownership using domain method
-	if !url.IsOwnedBy(userID) {
-		return errors.UnauthorizedError("not authorized to delete this URL")
+	if err := s.repository.MoveToTrash(ctx, urlID, url.WorkspaceID()); err != nil {
+		return err
 	}
 
-	// Invalidate cache
-	s.cache.InvalidateShortURL(ctx, url.ShortCode())
+	// Redirects stop as soon as the cached entry is gone, since lookups skip the trash
+	if err := s.cache.InvalidateShortURL(ctx, url.Domain(), url.ShortCode()); err != nil {
+		return errors.InternalError("cache invalidation failed")
+	}
+	return nil
+}
+
+func (s *urlService) RestoreURL(
+	ctx context.Context,
+	urlID string,
+	userID string,
+) error {
+	url, err := s.repository.FindDeletedByID(ctx, urlID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.workspaces.authorize(ctx, userID, url.WorkspaceID(), workspaceEntity.RoleEditor,
+		"restore this URL"); err != nil {
+		return err
+	}
 
-	return s.repository.Delete(ctx, urlID, userID)
+	// The short code of a URL in the trash is never reissued, so it is still free
+	return s.repository.Restore(ctx, urlID, url.WorkspaceID())
 }
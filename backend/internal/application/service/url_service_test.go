@@ -0,0 +1,87 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	zl "github.com/rs/zerolog"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/logging/zerolog"
+)
+
+// countingResolver resolves URLs to themselves with a suffix, rejecting the ones in
+// failing, and records how many resolutions ran at once
+type countingResolver struct {
+	failing map[string]bool
+
+	mu      sync.Mutex
+	running int
+	peak    int
+}
+
+func (r *countingResolver) Resolve(ctx context.Context, longURL string) (string, error) {
+	r.mu.Lock()
+	r.running++
+	r.peak = max(r.peak, r.running)
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		r.running--
+		r.mu.Unlock()
+	}()
+
+	time.Sleep(time.Millisecond)
+	if r.failing[longURL] {
+		return "", errors.ValidationError("shortened URL could not be resolved")
+	}
+	return longURL + "/resolved", nil
+}
+
+func TestResolveBulkDestinations(t *testing.T) {
+	resolver := &countingResolver{failing: map[string]bool{"https://sho.rt/3": true}}
+	service := NewURLService(nil, nil, nil, resolver, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, zerolog.NewWithLogger(zl.Nop()), nil, 1, 100, 10).(*urlService)
+
+	items := make([]*bulkURL, 40)
+	for i := range items {
+		items[i] = &bulkURL{index: i, req: &valueobject.CreateURLRequest{LongURL: fmt.Sprintf("https://sho.rt/%d", i)}}
+	}
+	results := make([]valueobject.BulkCreateURLResult, len(items))
+
+	resolved := service.resolveBulkDestinations(context.Background(), items, results)
+	if len(resolved) != len(items)-1 {
+		t.Fatalf("resolved %d items, want %d", len(resolved), len(items)-1)
+	}
+	for _, item := range resolved {
+		if want := fmt.Sprintf("https://sho.rt/%d/resolved", item.index); item.req.LongURL != want {
+			t.Errorf("item %d LongURL = %q, want %q", item.index, item.req.LongURL, want)
+		}
+	}
+	if results[3].ErrorType != string(errors.ErrorTypeValidation) {
+		t.Errorf("results[3] = %+v, want validation error", results[3])
+	}
+	if resolver.peak > bulkResolveConcurrency {
+		t.Errorf("%d resolutions ran at once, want at most %d", resolver.peak, bulkResolveConcurrency)
+	}
+}
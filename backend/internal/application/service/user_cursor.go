@@ -0,0 +1,52 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/user/repository"
+)
+
+// userCursor is the encoded form of a position in a user listing
+type userCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"i"`
+}
+
+// encodeUserCursor encodes a position in a user listing as an opaque string
+func encodeUserCursor(cursor repository.UserCursor) string {
+	// Marshalling cannot fail for these field types
+	data, _ := json.Marshal(userCursor{CreatedAt: cursor.CreatedAt, ID: cursor.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeUserCursor decodes a position encoded by encodeUserCursor, reporting false for
+// malformed values
+func decodeUserCursor(value string) (*repository.UserCursor, bool) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, false
+	}
+	var cursor userCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, false
+	}
+	return &repository.UserCursor{CreatedAt: cursor.CreatedAt, ID: cursor.ID}, true
+}
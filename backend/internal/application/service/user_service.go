@@ -20,32 +20,61 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/PraveenGongada/shortly/internal/domain/interfaces"
 	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/user/cache"
 	"github.com/PraveenGongada/shortly/internal/domain/user/entity"
 	"github.com/PraveenGongada/shortly/internal/domain/user/repository"
 	"github.com/PraveenGongada/shortly/internal/domain/user/valueobject"
+	workspaceEntity "github.com/PraveenGongada/shortly/internal/domain/workspace/entity"
+	workspaceRepository "github.com/PraveenGongada/shortly/internal/domain/workspace/repository"
 	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
 	"github.com/PraveenGongada/shortly/internal/shared/utils"
 )
 
+// tokenVersionCacheTTL bounds how long a cached token version is trusted before it
+// is read from the repository again
+const tokenVersionCacheTTL = 5 * time.Minute
+
 // TokenGenerator defines interface for token generation
 type TokenGenerator interface {
-	GenerateToken(userID string) (string, string, error) // returns type, token, error
+	GenerateToken(userID, role string, tokenVersion int) (string, string, error) // returns type, token, error
+	GenerateRefreshToken() (string, time.Time, error)                            // returns token, expiry, error
+	ParseToken(token string) (*valueobject.AccessTokenClaims, error)
 }
 
 // UserService defines the interface for user use cases
 type UserService interface {
 	Login(ctx context.Context, req *valueobject.LoginRequest) (*valueobject.TokenResponse, error)
-	Logout(ctx context.Context) error
+	Logout(ctx context.Context, req *valueobject.LogoutRequest) error
+	LogoutEverywhere(ctx context.Context, userID string) error
+	ValidateSession(ctx context.Context, claims *valueobject.AccessTokenClaims) error
 	Register(ctx context.Context, req *valueobject.RegisterRequest) (*valueobject.TokenResponse, error)
+	Refresh(ctx context.Context, req *valueobject.RefreshRequest) (*valueobject.TokenResponse, error)
+	GetProfile(ctx context.Context, userID string) (*valueobject.UserResponse, error)
+	UpdateProfile(
+		ctx context.Context,
+		userID string,
+		req *valueobject.UpdateProfileRequest,
+	) (*valueobject.UserResponse, error)
+	ChangePassword(
+		ctx context.Context,
+		userID string,
+		req *valueobject.ChangePasswordRequest,
+	) (*valueobject.TokenResponse, error)
 }
 
 type userService struct {
 	validator      interfaces.UserValidator
 	hasher         interfaces.PasswordHasher
 	repository     repository.UserRepository
+	refreshTokens  repository.RefreshTokenRepository
+	revocations    cache.TokenRevocationCache
+	sessions       sessionRevoker
+	workspaces     workspaceRepository.WorkspaceRepository
 	tokenGenerator TokenGenerator
 	logger         logger.Logger
 }
@@ -54,6 +83,9 @@ func NewUserService(
 	validator interfaces.UserValidator,
 	hasher interfaces.PasswordHasher,
 	repository repository.UserRepository,
+	refreshTokens repository.RefreshTokenRepository,
+	revocations cache.TokenRevocationCache,
+	workspaces workspaceRepository.WorkspaceRepository,
 	tokenGenerator TokenGenerator,
 	logger logger.Logger,
 ) UserService {
@@ -61,6 +93,10 @@ func NewUserService(
 		validator:      validator,
 		hasher:         hasher,
 		repository:     repository,
+		refreshTokens:  refreshTokens,
+		revocations:    revocations,
+		sessions:       sessionRevoker{refreshTokens: refreshTokens, revocations: revocations, logger: logger},
+		workspaces:     workspaces,
 		tokenGenerator: tokenGenerator,
 		logger:         logger,
 	}
@@ -86,17 +122,16 @@ func (s *userService) Login(
 		return nil, errors.UnauthorizedError("invalid email or password")
 	}
 
-	tokenType, token, err := s.tokenGenerator.GenerateToken(user.ID())
-	if err != nil {
-		s.logger.Error(ctx, "Token generation failed",
-			logger.String("userID", user.ID()),
-			logger.Error(err))
-		return nil, errors.InternalError("token generation failed")
+	// Checked after the password so that suspensions are only revealed to the user
+	if user.IsSuspended() {
+		s.logger.Warn(ctx, "Login of suspended user refused",
+			logger.String("userID", user.ID()))
+		return nil, errors.ForbiddenError("account is suspended")
 	}
 
-	tokenRes := &valueobject.TokenResponse{
-		Type:  tokenType,
-		Token: token,
+	tokenRes, err := s.issueTokens(ctx, user, utils.GenerateRandomUUID())
+	if err != nil {
+		return nil, err
 	}
 
 	s.logger.Info(ctx, "Login successful",
@@ -104,10 +139,135 @@ func (s *userService) Login(
 	return tokenRes, nil
 }
 
-func (s *userService) Logout(ctx context.Context) error {
+// Logout revokes the access token of the session until it expires, together with the
+// refresh token family the session was issued from. Either token may be absent.
+func (s *userService) Logout(ctx context.Context, req *valueobject.LogoutRequest) error {
+	if req.AccessToken != "" {
+		claims, err := s.tokenGenerator.ParseToken(req.AccessToken)
+		if err == nil {
+			ttl := time.Until(claims.ExpiresAt)
+			if err := s.revocations.RevokeToken(ctx, claims.TokenID, ttl); err != nil {
+				return errors.InternalError("token revocation failed")
+			}
+			s.logger.Info(ctx, "Access token revoked",
+				logger.String("userID", claims.UserID),
+				logger.String("operation", "Logout"))
+		}
+	}
+
+	if req.RefreshToken != "" {
+		token, err := s.refreshTokens.FindByHash(ctx, hashToken(req.RefreshToken))
+		if err != nil {
+			if errors.GetErrorType(err) == errors.ErrorTypeNotFound {
+				return nil
+			}
+			return err
+		}
+		return s.refreshTokens.RevokeFamily(ctx, token.FamilyID())
+	}
+
+	return nil
+}
+
+// LogoutEverywhere invalidates every access and refresh token issued to the user
+func (s *userService) LogoutEverywhere(ctx context.Context, userID string) error {
+	tokenVersion, err := s.repository.IncrementTokenVersion(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.sessions.revokeAll(ctx, userID, tokenVersion); err != nil {
+		return err
+	}
+
+	s.logger.Info(ctx, "User logged out everywhere",
+		logger.String("userID", userID),
+		logger.String("operation", "LogoutEverywhere"))
+	return nil
+}
+
+// sessionRevoker finishes invalidating the tokens of a user after their token version
+// was bumped. It is shared by the services that end every session of a user.
+type sessionRevoker struct {
+	refreshTokens repository.RefreshTokenRepository
+	revocations   cache.TokenRevocationCache
+	logger        logger.Logger
+}
+
+// revokeAll caches the new token version of the user and revokes their refresh tokens
+func (r sessionRevoker) revokeAll(ctx context.Context, userID string, tokenVersion int) error {
+	// A stale cached version would keep old tokens valid until it expires
+	if err := r.revocations.SetTokenVersion(ctx, userID, tokenVersion, tokenVersionCacheTTL); err != nil {
+		r.logger.Warn(ctx, "Failed to cache token version",
+			logger.String("userID", userID),
+			logger.Error(err))
+	}
+
+	return r.refreshTokens.RevokeByUserID(ctx, userID)
+}
+
+// ValidateSession rejects access tokens that were revoked on logout or issued before
+// the user last logged out everywhere. The denylist fails open when the cache is
+// unavailable, while the token version falls back to the repository.
+func (s *userService) ValidateSession(ctx context.Context, claims *valueobject.AccessTokenClaims) error {
+	if claims.TokenID != "" {
+		revoked, err := s.revocations.IsTokenRevoked(ctx, claims.TokenID)
+		if err != nil {
+			s.logger.Warn(ctx, "Token denylist unavailable",
+				logger.String("userID", claims.UserID),
+				logger.Error(err))
+		}
+		if revoked {
+			return errors.UnauthorizedError("token has been revoked")
+		}
+	}
+
+	tokenVersion, err := s.currentTokenVersion(ctx, claims.UserID)
+	if err != nil {
+		return err
+	}
+	if claims.TokenVersion < tokenVersion {
+		return errors.UnauthorizedError("token has been revoked")
+	}
+
+	// Roles are granted and withdrawn in the database without revoking tokens, so tokens
+	// carrying an elevated role are checked against the stored role on every request.
+	// Tokens of demoted users are rejected and have to be refreshed for the new role.
+	if claims.Role != "" && claims.Role != entity.RoleUser {
+		user, err := s.repository.FindByID(ctx, claims.UserID)
+		if err != nil {
+			if errors.GetErrorType(err) == errors.ErrorTypeNotFound {
+				return errors.UnauthorizedError("user not found")
+			}
+			return err
+		}
+		if user.Role() != claims.Role {
+			return errors.UnauthorizedError("token role is no longer held")
+		}
+	}
+
 	return nil
 }
 
+// currentTokenVersion returns the token version of the user, preferring the cache
+func (s *userService) currentTokenVersion(ctx context.Context, userID string) (int, error) {
+	tokenVersion, found, err := s.revocations.GetTokenVersion(ctx, userID)
+	if err == nil && found {
+		return tokenVersion, nil
+	}
+
+	user, err := s.repository.FindByID(ctx, userID)
+	if err != nil {
+		if errors.GetErrorType(err) == errors.ErrorTypeNotFound {
+			return 0, errors.UnauthorizedError("user not found")
+		}
+		return 0, err
+	}
+
+	s.revocations.SetTokenVersion(ctx, userID, user.TokenVersion(), tokenVersionCacheTTL)
+	return user.TokenVersion(), nil
+}
+
 func (s *userService) Register(
 	ctx context.Context,
 	req *valueobject.RegisterRequest,
@@ -140,20 +300,243 @@ func (s *userService) Register(
 		return nil, errors.InternalError("save operation failed")
 	}
 
-	// Generate token
-	tokenType, token, err := s.tokenGenerator.GenerateToken(savedUser.ID())
+	// Links are created in the personal workspace of the user unless another is chosen
+	workspace := workspaceEntity.NewPersonalWorkspace(utils.GenerateRandomUUID(), savedUser.ID())
+	owner, err := workspaceEntity.NewMember(workspace.ID(), savedUser.ID(), workspaceEntity.RoleOwner)
+	if err != nil {
+		return nil, errors.InternalError(err.Error())
+	}
+	if err := s.workspaces.Save(ctx, workspace, owner); err != nil {
+		return nil, errors.InternalError("save operation failed")
+	}
+
+	// Generate tokens, starting a new refresh token family
+	tokenRes, err := s.issueTokens(ctx, savedUser, utils.GenerateRandomUUID())
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "User registered successfully",
+		logger.String("userID", savedUser.ID()))
+
+	return tokenRes, nil
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh token in
+// the same family. Presenting a token that was already rotated is treated as theft:
+// the whole family is revoked, logging out both the attacker and the legitimate client.
+func (s *userService) Refresh(
+	ctx context.Context,
+	req *valueobject.RefreshRequest,
+) (*valueobject.TokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, errors.UnauthorizedError("refresh token is required")
+	}
+
+	token, err := s.refreshTokens.FindByHash(ctx, hashToken(req.RefreshToken))
+	if err != nil {
+		if errors.GetErrorType(err) == errors.ErrorTypeNotFound {
+			return nil, errors.UnauthorizedError("invalid refresh token")
+		}
+		return nil, err
+	}
+
+	if token.IsRevoked() {
+		return nil, s.handleRefreshTokenReuse(ctx, token)
+	}
+
+	if token.IsExpired(time.Now().UTC()) {
+		return nil, errors.UnauthorizedError("refresh token has expired")
+	}
+
+	// Revoking is conditional, so only one of several concurrent refreshes with the
+	// same token wins; the others are treated as reuse
+	active, err := s.refreshTokens.Revoke(ctx, token.ID())
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, s.handleRefreshTokenReuse(ctx, token)
+	}
+
+	user, err := s.repository.FindByID(ctx, token.UserID())
+	if err != nil {
+		if errors.GetErrorType(err) == errors.ErrorTypeNotFound {
+			return nil, errors.UnauthorizedError("invalid refresh token")
+		}
+		return nil, err
+	}
+	if user.IsSuspended() {
+		return nil, errors.ForbiddenError("account is suspended")
+	}
+
+	tokenRes, err := s.issueTokens(ctx, user, token.FamilyID())
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Refresh token rotated",
+		logger.String("userID", token.UserID()),
+		logger.String("operation", "Refresh"))
+	return tokenRes, nil
+}
+
+// handleRefreshTokenReuse revokes every token in the family of a reused refresh token
+func (s *userService) handleRefreshTokenReuse(ctx context.Context, token *entity.RefreshToken) error {
+	s.logger.Warn(ctx, "Refresh token reuse detected, revoking token family",
+		logger.String("userID", token.UserID()),
+		logger.String("familyID", token.FamilyID()),
+		logger.String("operation", "Refresh"))
+
+	if err := s.refreshTokens.RevokeFamily(ctx, token.FamilyID()); err != nil {
+		return err
+	}
+	return errors.UnauthorizedError("invalid refresh token")
+}
+
+// issueTokens generates an access token and a refresh token for the user, persisting
+// the refresh token hash under the given family
+func (s *userService) issueTokens(
+	ctx context.Context,
+	user *entity.User,
+	familyID string,
+) (*valueobject.TokenResponse, error) {
+	userID := user.ID()
+	tokenType, token, err := s.tokenGenerator.GenerateToken(userID, user.Role(), user.TokenVersion())
 	if err != nil {
 		s.logger.Error(ctx, "Token generation failed",
-			logger.String("userID", savedUser.ID()),
+			logger.String("userID", userID),
 			logger.Error(err))
 		return nil, errors.InternalError("token generation failed")
 	}
 
-	s.logger.Info(ctx, "User registered successfully",
-		logger.String("userID", savedUser.ID()))
+	refreshToken, expiresAt, err := s.tokenGenerator.GenerateRefreshToken()
+	if err != nil {
+		s.logger.Error(ctx, "Refresh token generation failed",
+			logger.String("userID", userID),
+			logger.Error(err))
+		return nil, errors.InternalError("token generation failed")
+	}
+
+	record := entity.NewRefreshToken(utils.GenerateRandomUUID(), userID, familyID, hashToken(refreshToken), expiresAt)
+	if err := s.refreshTokens.Save(ctx, record); err != nil {
+		return nil, err
+	}
 
 	return &valueobject.TokenResponse{
-		Type:  tokenType,
-		Token: token,
+		Type:         tokenType,
+		Token:        token,
+		RefreshToken: refreshToken,
 	}, nil
 }
+
+// hashToken returns the hex encoded SHA-256 digest under which a refresh token is stored.
+// Refresh tokens carry enough randomness that a fast hash is sufficient.
+func hashToken(token string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(token)))
+}
+
+func (s *userService) GetProfile(ctx context.Context, userID string) (*valueobject.UserResponse, error) {
+	user, err := s.repository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	userRes := valueobject.CreateUserResponse(user)
+	return &userRes, nil
+}
+
+func (s *userService) UpdateProfile(
+	ctx context.Context,
+	userID string,
+	req *valueobject.UpdateProfileRequest,
+) (*valueobject.UserResponse, error) {
+	if req.Name == nil && req.Email == nil {
+		return nil, errors.ValidationError("nothing to update")
+	}
+
+	user, err := s.repository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		if err := user.UpdateName(*req.Name, s.validator); err != nil {
+			return nil, errors.ValidationError(err.Error())
+		}
+	}
+
+	if req.Email != nil && !strings.EqualFold(strings.TrimSpace(*req.Email), user.Email()) {
+		if err := user.UpdateEmail(*req.Email, s.validator); err != nil {
+			return nil, errors.ValidationError(err.Error())
+		}
+
+		exists, err := s.repository.ExistsByEmail(ctx, user.Email())
+		if err != nil {
+			return nil, errors.InternalError("database query failed")
+		}
+		if exists {
+			return nil, errors.ConflictError("email already registered")
+		}
+	}
+
+	updatedUser, err := s.repository.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "User profile updated",
+		logger.String("userID", userID),
+		logger.String("operation", "UpdateProfile"))
+
+	userRes := valueobject.CreateUserResponse(updatedUser)
+	return &userRes, nil
+}
+
+// ChangePassword replaces the user's password after verifying the current one. All
+// existing sessions are revoked; the caller receives tokens for a new session.
+func (s *userService) ChangePassword(
+	ctx context.Context,
+	userID string,
+	req *valueobject.ChangePasswordRequest,
+) (*valueobject.TokenResponse, error) {
+	user, err := s.repository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.VerifyPassword(req.CurrentPassword, s.hasher); err != nil {
+		s.logger.Warn(ctx, "Current password verification failed",
+			logger.String("userID", userID),
+			logger.String("operation", "ChangePassword"))
+		return nil, errors.ValidationError("current password is incorrect")
+	}
+
+	if err := user.ChangePassword(req.NewPassword, s.validator, s.hasher); err != nil {
+		return nil, errors.ValidationError(err.Error())
+	}
+
+	if _, err := s.repository.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	if err := s.LogoutEverywhere(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// Reload the user for the token version bumped by the logout
+	user, err = s.repository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenRes, err := s.issueTokens(ctx, user, utils.GenerateRandomUUID())
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "User password changed",
+		logger.String("userID", userID),
+		logger.String("operation", "ChangePassword"))
+	return tokenRes, nil
+}
@@ -0,0 +1,453 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/interfaces"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	userRepository "github.com/PraveenGongada/shortly/internal/domain/user/repository"
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/repository"
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/valueobject"
+	"github.com/PraveenGongada/shortly/internal/shared/utils"
+)
+
+const (
+	// invitationTTL is how long an invitation can be accepted for
+	invitationTTL = 7 * 24 * time.Hour
+
+	// invitationTokenBytes is the length of the random invitation tokens
+	invitationTokenBytes = 32
+)
+
+// WorkspaceService defines the interface for workspace use cases
+type WorkspaceService interface {
+	CreateWorkspace(
+		ctx context.Context,
+		userID string,
+		req *valueobject.CreateWorkspaceRequest,
+	) (*valueobject.WorkspaceResponse, error)
+	ListWorkspaces(ctx context.Context, userID string) ([]valueobject.WorkspaceResponse, error)
+	ListMembers(ctx context.Context, userID, workspaceID string) ([]valueobject.MemberResponse, error)
+	UpdateMember(
+		ctx context.Context,
+		userID, workspaceID, memberID string,
+		req *valueobject.UpdateMemberRequest,
+	) (*valueobject.MemberResponse, error)
+	RemoveMember(ctx context.Context, userID, workspaceID, memberID string) error
+	CreateInvitation(
+		ctx context.Context,
+		userID, workspaceID string,
+		req *valueobject.CreateInvitationRequest,
+	) (*valueobject.CreateInvitationResponse, error)
+	ListInvitations(ctx context.Context, userID, workspaceID string) ([]valueobject.InvitationResponse, error)
+	RevokeInvitation(ctx context.Context, userID, workspaceID, invitationID string) error
+	AcceptInvitation(
+		ctx context.Context,
+		userID string,
+		req *valueobject.AcceptInvitationRequest,
+	) (*valueobject.WorkspaceResponse, error)
+}
+
+type workspaceService struct {
+	repository     repository.WorkspaceRepository
+	userRepository userRepository.UserRepository
+	access         workspaceAccess
+	validator      interfaces.UserValidator
+	logger         logger.Logger
+}
+
+func NewWorkspaceService(
+	repository repository.WorkspaceRepository,
+	userRepository userRepository.UserRepository,
+	validator interfaces.UserValidator,
+	logger logger.Logger,
+) WorkspaceService {
+	return &workspaceService{
+		repository:     repository,
+		userRepository: userRepository,
+		access:         workspaceAccess{repository: repository},
+		validator:      validator,
+		logger:         logger,
+	}
+}
+
+func (s *workspaceService) CreateWorkspace(
+	ctx context.Context,
+	userID string,
+	req *valueobject.CreateWorkspaceRequest,
+) (*valueobject.WorkspaceResponse, error) {
+	workspace, err := entity.NewWorkspace(utils.GenerateRandomUUID(), req.Name)
+	if err != nil {
+		return nil, errors.ValidationError(err.Error())
+	}
+	owner, err := entity.NewMember(workspace.ID(), userID, entity.RoleOwner)
+	if err != nil {
+		return nil, errors.InternalError(err.Error())
+	}
+
+	if err := s.repository.Save(ctx, workspace, owner); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Workspace created",
+		logger.String("userID", userID),
+		logger.String("workspaceID", workspace.ID()),
+		logger.String("operation", "CreateWorkspace"))
+
+	response := valueobject.CreateWorkspaceResponse(workspace, owner.Role())
+	return &response, nil
+}
+
+func (s *workspaceService) ListWorkspaces(ctx context.Context, userID string) ([]valueobject.WorkspaceResponse, error) {
+	memberships, err := s.repository.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]valueobject.WorkspaceResponse, len(memberships))
+	for i, membership := range memberships {
+		responses[i] = valueobject.CreateWorkspaceResponse(membership.Workspace, membership.Role)
+	}
+
+	return responses, nil
+}
+
+func (s *workspaceService) ListMembers(
+	ctx context.Context,
+	userID, workspaceID string,
+) ([]valueobject.MemberResponse, error) {
+	if _, err := s.access.authorize(ctx, userID, workspaceID, entity.RoleViewer, "view this workspace"); err != nil {
+		return nil, err
+	}
+
+	members, err := s.repository.FindMembers(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]valueobject.MemberResponse, len(members))
+	for i, member := range members {
+		responses[i] = valueobject.CreateMemberResponse(member)
+	}
+
+	return responses, nil
+}
+
+// UpdateMember changes the role of a member. Admins manage the members who are not
+// owners; granting or revoking the owner role takes an owner, and the last owner of a
+// workspace cannot step down.
+func (s *workspaceService) UpdateMember(
+	ctx context.Context,
+	userID, workspaceID, memberID string,
+	req *valueobject.UpdateMemberRequest,
+) (*valueobject.MemberResponse, error) {
+	role := entity.Role(req.Role)
+	if !role.IsValid() {
+		return nil, errors.ValidationError("role must be one of owner, admin, editor or viewer")
+	}
+
+	member, err := s.manageableMember(ctx, userID, workspaceID, memberID, role)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role() == role {
+		response := valueobject.CreateMemberResponse(member)
+		return &response, nil
+	}
+
+	if err := s.repository.UpdateMemberRole(ctx, workspaceID, memberID, role); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Workspace member role changed",
+		logger.String("userID", userID),
+		logger.String("workspaceID", workspaceID),
+		logger.String("memberID", memberID),
+		logger.String("role", string(role)),
+		logger.String("operation", "UpdateMember"))
+
+	response := valueobject.CreateMemberResponse(entity.NewMemberFromRepository(
+		workspaceID, memberID, member.Email(), role, member.CreatedAt(),
+	))
+	return &response, nil
+}
+
+// RemoveMember removes a member from a workspace under the rules of UpdateMember.
+// Members can always leave, unless they are the last owner.
+func (s *workspaceService) RemoveMember(ctx context.Context, userID, workspaceID, memberID string) error {
+	var err error
+	if userID == memberID {
+		_, err = s.access.authorize(ctx, userID, workspaceID, entity.RoleViewer, "leave this workspace")
+	} else {
+		_, err = s.manageableMember(ctx, userID, workspaceID, memberID, entity.RoleViewer)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.RemoveMember(ctx, workspaceID, memberID); err != nil {
+		return err
+	}
+
+	s.logger.Info(ctx, "Workspace member removed",
+		logger.String("userID", userID),
+		logger.String("workspaceID", workspaceID),
+		logger.String("memberID", memberID),
+		logger.String("operation", "RemoveMember"))
+	return nil
+}
+
+// manageableMember returns a member the user may give the role to, or take their
+// current one from
+func (s *workspaceService) manageableMember(
+	ctx context.Context,
+	userID, workspaceID, memberID string,
+	role entity.Role,
+) (*entity.Member, error) {
+	manager, err := s.access.authorize(ctx, userID, workspaceID, entity.RoleAdmin, "manage members")
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.repository.FindMember(ctx, workspaceID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if (member.Role() == entity.RoleOwner || role == entity.RoleOwner) && !manager.Can(entity.RoleOwner) {
+		return nil, errors.ForbiddenError("only owners can manage owners")
+	}
+
+	return member, nil
+}
+
+// CreateInvitation invites an email address to a shared workspace. Inviting the same
+// address again replaces the earlier invitation.
+func (s *workspaceService) CreateInvitation(
+	ctx context.Context,
+	userID, workspaceID string,
+	req *valueobject.CreateInvitationRequest,
+) (*valueobject.CreateInvitationResponse, error) {
+	role := entity.Role(req.Role)
+	if !role.IsValid() {
+		return nil, errors.ValidationError("role must be one of owner, admin, editor or viewer")
+	}
+
+	manager, err := s.access.authorize(ctx, userID, workspaceID, entity.RoleAdmin, "invite members")
+	if err != nil {
+		return nil, err
+	}
+	if role == entity.RoleOwner && !manager.Can(entity.RoleOwner) {
+		return nil, errors.ForbiddenError("only owners can manage owners")
+	}
+
+	workspace, err := s.repository.FindByID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if workspace.IsPersonal() {
+		return nil, errors.ValidationError("personal workspaces cannot be shared")
+	}
+
+	token, err := generateInvitationToken()
+	if err != nil {
+		return nil, errors.InternalError("invitation token generation failed")
+	}
+
+	invitation, err := entity.NewInvitation(
+		utils.GenerateRandomUUID(),
+		workspaceID,
+		req.Email,
+		role,
+		hashToken(token),
+		userID,
+		time.Now().Add(invitationTTL),
+		s.validator,
+	)
+	if err != nil {
+		return nil, errors.ValidationError(err.Error())
+	}
+
+	if err := s.repository.SaveInvitation(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Workspace invitation created",
+		logger.String("userID", userID),
+		logger.String("workspaceID", workspaceID),
+		logger.String("invitationID", invitation.ID()),
+		logger.String("operation", "CreateInvitation"))
+
+	return &valueobject.CreateInvitationResponse{
+		InvitationResponse: valueobject.CreateInvitationResponseFromEntity(invitation),
+		Token:              token,
+	}, nil
+}
+
+func (s *workspaceService) ListInvitations(
+	ctx context.Context,
+	userID, workspaceID string,
+) ([]valueobject.InvitationResponse, error) {
+	if _, err := s.access.authorize(ctx, userID, workspaceID, entity.RoleAdmin, "invite members"); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.repository.FindInvitations(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]valueobject.InvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		responses[i] = valueobject.CreateInvitationResponseFromEntity(invitation)
+	}
+
+	return responses, nil
+}
+
+func (s *workspaceService) RevokeInvitation(ctx context.Context, userID, workspaceID, invitationID string) error {
+	if _, err := s.access.authorize(ctx, userID, workspaceID, entity.RoleAdmin, "invite members"); err != nil {
+		return err
+	}
+
+	if err := s.repository.DeleteInvitation(ctx, invitationID, workspaceID); err != nil {
+		return err
+	}
+
+	s.logger.Info(ctx, "Workspace invitation revoked",
+		logger.String("userID", userID),
+		logger.String("workspaceID", workspaceID),
+		logger.String("invitationID", invitationID),
+		logger.String("operation", "RevokeInvitation"))
+	return nil
+}
+
+// AcceptInvitation makes the user a member of the workspace they were invited to. The
+// invitation has to be addressed to the email of the user.
+func (s *workspaceService) AcceptInvitation(
+	ctx context.Context,
+	userID string,
+	req *valueobject.AcceptInvitationRequest,
+) (*valueobject.WorkspaceResponse, error) {
+	invitation, err := s.repository.FindInvitationByHash(ctx, hashToken(req.Token))
+	if err != nil {
+		if errors.GetErrorType(err) == errors.ErrorTypeNotFound {
+			return nil, errors.NotFoundError("invitation not found")
+		}
+		return nil, err
+	}
+	if invitation.IsExpired(time.Now().UTC()) {
+		return nil, errors.GoneError("invitation has expired")
+	}
+
+	user, err := s.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !invitation.IsFor(user.Email()) {
+		return nil, errors.ForbiddenError("invitation was sent to another email address")
+	}
+
+	workspace, err := s.repository.FindByID(ctx, invitation.WorkspaceID())
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := entity.NewMember(invitation.WorkspaceID(), userID, invitation.Role())
+	if err != nil {
+		return nil, errors.InternalError(err.Error())
+	}
+	if err := s.repository.AcceptInvitation(ctx, invitation.ID(), member); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info(ctx, "Workspace invitation accepted",
+		logger.String("userID", userID),
+		logger.String("workspaceID", workspace.ID()),
+		logger.String("invitationID", invitation.ID()),
+		logger.String("operation", "AcceptInvitation"))
+
+	response := valueobject.CreateWorkspaceResponse(workspace, member.Role())
+	return &response, nil
+}
+
+// generateInvitationToken returns a random token for an invitation
+func generateInvitationToken() (string, error) {
+	token := make([]byte, invitationTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// workspaceAccess checks the role of users in the workspaces they act in
+type workspaceAccess struct {
+	repository repository.WorkspaceRepository
+}
+
+// authorize returns the membership of the user in the workspace, failing unless their
+// role includes the required one. Action describes the request in errors.
+func (a workspaceAccess) authorize(
+	ctx context.Context,
+	userID, workspaceID string,
+	required entity.Role,
+	action string,
+) (*entity.Member, error) {
+	member, err := a.repository.FindMember(ctx, workspaceID, userID)
+	if err != nil {
+		if errors.GetErrorType(err) == errors.ErrorTypeNotFound {
+			return nil, errors.ForbiddenError("not authorized to " + action)
+		}
+		return nil, err
+	}
+	if !member.Can(required) {
+		return nil, errors.ForbiddenError("not authorized to " + action)
+	}
+	return member, nil
+}
+
+// resolve returns the workspace a request is made in after checking the role of the
+// user there: the requested workspace, or the personal workspace of the user, which
+// they own, when none is requested
+func (a workspaceAccess) resolve(
+	ctx context.Context,
+	userID, workspaceID string,
+	required entity.Role,
+	action string,
+) (string, error) {
+	if workspaceID == "" {
+		workspace, err := a.repository.FindPersonal(ctx, userID)
+		if err != nil {
+			if errors.GetErrorType(err) == errors.ErrorTypeNotFound {
+				return "", errors.InternalError("personal workspace not found")
+			}
+			return "", err
+		}
+		return workspace.ID(), nil
+	}
+
+	if _, err := a.authorize(ctx, userID, workspaceID, required, action); err != nil {
+		return "", err
+	}
+	return workspaceID, nil
+}
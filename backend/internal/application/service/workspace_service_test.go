@@ -0,0 +1,126 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	zl "github.com/rs/zerolog"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	userEntity "github.com/PraveenGongada/shortly/internal/domain/user/entity"
+	userDomainService "github.com/PraveenGongada/shortly/internal/domain/user/service"
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/logging/zerolog"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/persistence/memory"
+)
+
+func TestWorkspaceRoles(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	workspaces := memory.NewWorkspaceRepository(store)
+	users := memory.NewUserRepository(store)
+	s := NewWorkspaceService(workspaces, users, userDomainService.NewValidator(), zerolog.NewWithLogger(zl.Nop()))
+
+	for _, id := range []string{"owner", "admin", "viewer", "outsider"} {
+		user := userEntity.NewUserFromRepository(id, id+"@example.com", "hash", id, userEntity.RoleUser, 0, time.Now(), nil, nil)
+		if _, err := users.Save(ctx, user); err != nil {
+			t.Fatalf("Save(%s) error = %v", id, err)
+		}
+	}
+
+	assertErrorType := func(err error, want errors.ErrorType) {
+		t.Helper()
+		if err == nil || errors.GetErrorType(err) != want {
+			t.Fatalf("error = %v, want %s", err, want)
+		}
+	}
+	join := func(workspaceID, inviterID, userID string, role entity.Role) {
+		t.Helper()
+		invitation, err := s.CreateInvitation(ctx, inviterID, workspaceID, &valueobject.CreateInvitationRequest{
+			Email: userID + "@example.com",
+			Role:  string(role),
+		})
+		if err != nil {
+			t.Fatalf("CreateInvitation(%s) error = %v", userID, err)
+		}
+		if _, err := s.AcceptInvitation(ctx, userID, &valueobject.AcceptInvitationRequest{
+			Token: invitation.Token,
+		}); err != nil {
+			t.Fatalf("AcceptInvitation(%s) error = %v", userID, err)
+		}
+	}
+
+	workspace, err := s.CreateWorkspace(ctx, "owner", &valueobject.CreateWorkspaceRequest{Name: " Marketing "})
+	if err != nil {
+		t.Fatalf("CreateWorkspace() error = %v", err)
+	}
+	if workspace.Name != "Marketing" || workspace.Role != string(entity.RoleOwner) {
+		t.Fatalf("CreateWorkspace() = %+v, want Marketing owned by the user", workspace)
+	}
+	join(workspace.ID, "owner", "admin", entity.RoleAdmin)
+	join(workspace.ID, "admin", "viewer", entity.RoleViewer)
+
+	// Invitations only work for the email address they were sent to
+	invitation, err := s.CreateInvitation(ctx, "owner", workspace.ID, &valueobject.CreateInvitationRequest{
+		Email: "someone@example.com",
+		Role:  string(entity.RoleEditor),
+	})
+	if err != nil {
+		t.Fatalf("CreateInvitation() error = %v", err)
+	}
+	_, err = s.AcceptInvitation(ctx, "outsider", &valueobject.AcceptInvitationRequest{Token: invitation.Token})
+	assertErrorType(err, errors.ErrorTypeForbidden)
+	_, err = s.AcceptInvitation(ctx, "outsider", &valueobject.AcceptInvitationRequest{Token: "unknown"})
+	assertErrorType(err, errors.ErrorTypeNotFound)
+
+	// Viewers cannot manage members, admins cannot manage owners
+	_, err = s.ListMembers(ctx, "outsider", workspace.ID)
+	assertErrorType(err, errors.ErrorTypeForbidden)
+	_, err = s.CreateInvitation(ctx, "viewer", workspace.ID, &valueobject.CreateInvitationRequest{
+		Email: "someone@example.com",
+		Role:  string(entity.RoleViewer),
+	})
+	assertErrorType(err, errors.ErrorTypeForbidden)
+	_, err = s.UpdateMember(ctx, "admin", workspace.ID, "viewer",
+		&valueobject.UpdateMemberRequest{Role: string(entity.RoleOwner)})
+	assertErrorType(err, errors.ErrorTypeForbidden)
+	assertErrorType(s.RemoveMember(ctx, "admin", workspace.ID, "owner"), errors.ErrorTypeForbidden)
+
+	member, err := s.UpdateMember(ctx, "admin", workspace.ID, "viewer",
+		&valueobject.UpdateMemberRequest{Role: string(entity.RoleEditor)})
+	if err != nil || member.Role != string(entity.RoleEditor) || member.Email != "viewer@example.com" {
+		t.Fatalf("UpdateMember() = %+v, %v, want viewer as editor", member, err)
+	}
+
+	// The last owner cannot step down or leave
+	_, err = s.UpdateMember(ctx, "owner", workspace.ID, "owner",
+		&valueobject.UpdateMemberRequest{Role: string(entity.RoleAdmin)})
+	assertErrorType(err, errors.ErrorTypeConflict)
+	assertErrorType(s.RemoveMember(ctx, "owner", workspace.ID, "owner"), errors.ErrorTypeConflict)
+
+	if err := s.RemoveMember(ctx, "viewer", workspace.ID, "viewer"); err != nil {
+		t.Fatalf("RemoveMember(self) error = %v", err)
+	}
+	members, err := s.ListMembers(ctx, "admin", workspace.ID)
+	if err != nil || len(members) != 2 {
+		t.Fatalf("ListMembers() = %+v, %v, want the owner and the admin", members, err)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+)
+
+// flushTimeout bounds a single batch write so a slow database cannot stall the recorder
+const flushTimeout = 10 * time.Second
+
+// ClickRecorder buffers click events in memory and writes them to the repository
+// in batches, so that recording a click never blocks the redirect that produced it
+type ClickRecorder struct {
+	repository    repository.ClickRepository
+	logger        logger.Logger
+	batchSize     int
+	flushInterval time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+	clicks chan *entity.Click
+	done   chan struct{}
+}
+
+// NewClickRecorder creates a click recorder and starts its background writer
+func NewClickRecorder(
+	repository repository.ClickRepository,
+	logger logger.Logger,
+	analyticsConfig config.AnalyticsConfig,
+) *ClickRecorder {
+	r := &ClickRecorder{
+		repository:    repository,
+		logger:        logger,
+		batchSize:     analyticsConfig.ClickBatchSize(),
+		flushInterval: analyticsConfig.ClickFlushInterval(),
+		clicks:        make(chan *entity.Click, analyticsConfig.ClickBufferSize()),
+		done:          make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+// Record queues a click for persistence. When the buffer is full or the recorder
+// has been shut down the click is dropped rather than blocking the caller.
+func (r *ClickRecorder) Record(ctx context.Context, click *entity.Click) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		return
+	}
+
+	select {
+	case r.clicks <- click:
+	default:
+		r.logger.Warn(ctx, "Click buffer full, dropping click",
+			logger.String("worker", "ClickRecorder"),
+			logger.String("urlId", click.URLID()))
+	}
+}
+
+// Shutdown stops accepting clicks and waits for the buffered ones to be written
+func (r *ClickRecorder) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.clicks)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *ClickRecorder) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*entity.Click, 0, r.batchSize)
+	for {
+		select {
+		case click, ok := <-r.clicks:
+			if !ok {
+				r.flush(batch)
+				return
+			}
+			batch = append(batch, click)
+			if len(batch) >= r.batchSize {
+				r.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			r.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (r *ClickRecorder) flush(batch []*entity.Click) {
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+
+	if err := r.repository.SaveBatch(ctx, batch); err != nil {
+		r.logger.Error(ctx, "Failed to write click batch, clicks dropped",
+			logger.String("worker", "ClickRecorder"),
+			logger.Int("count", len(batch)),
+			logger.Error(err))
+	}
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/url/cache"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+)
+
+// RedirectFlusher periodically moves the redirect counts accumulated by a
+// RedirectCounter into the URL repository in a single batched update
+type RedirectFlusher struct {
+	counter       cache.RedirectCounter
+	repository    repository.URLRepository
+	logger        logger.Logger
+	flushInterval time.Duration
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewRedirectFlusher creates a redirect flusher and starts its background loop
+func NewRedirectFlusher(
+	counter cache.RedirectCounter,
+	repository repository.URLRepository,
+	logger logger.Logger,
+	analyticsConfig config.AnalyticsConfig,
+) *RedirectFlusher {
+	f := &RedirectFlusher{
+		counter:       counter,
+		repository:    repository,
+		logger:        logger,
+		flushInterval: analyticsConfig.RedirectFlushInterval(),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go f.run()
+	return f
+}
+
+// Shutdown stops the periodic flushing and performs a final flush so no counts are lost
+func (f *RedirectFlusher) Shutdown(ctx context.Context) error {
+	f.stopOnce.Do(func() { close(f.stop) })
+
+	select {
+	case <-f.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (f *RedirectFlusher) run() {
+	defer close(f.done)
+
+	ticker := time.NewTicker(f.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.flush()
+		case <-f.stop:
+			f.flush()
+			return
+		}
+	}
+}
+
+func (f *RedirectFlusher) flush() {
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+
+	counts, err := f.counter.Drain(ctx)
+	if err != nil || len(counts) == 0 {
+		return
+	}
+
+	if err := f.repository.AddRedirects(ctx, counts); err != nil {
+		// Put the counts back so the next flush retries them
+		if restoreErr := f.counter.Restore(ctx, counts); restoreErr != nil {
+			f.logger.Error(ctx, "Failed to restore redirect counts, counts lost",
+				logger.String("worker", "RedirectFlusher"),
+				logger.Int("count", len(counts)),
+				logger.Error(restoreErr))
+		}
+		return
+	}
+
+	f.logger.Debug(ctx, "Redirect counts flushed",
+		logger.String("worker", "RedirectFlusher"),
+		logger.Int("count", len(counts)))
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+)
+
+// purgeBatchSize bounds how many URLs a single purge statement deletes, so purging a
+// large backlog does not hold locks on many rows at once
+const purgeBatchSize = 500
+
+// TrashPurger periodically deletes the URLs that have been in the trash for longer
+// than the retention period
+type TrashPurger struct {
+	repository    repository.URLRepository
+	logger        logger.Logger
+	retention     time.Duration
+	purgeInterval time.Duration
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewTrashPurger creates a trash purger and starts its background loop
+func NewTrashPurger(
+	repository repository.URLRepository,
+	logger logger.Logger,
+	trashConfig config.TrashConfig,
+) *TrashPurger {
+	p := &TrashPurger{
+		repository:    repository,
+		logger:        logger,
+		retention:     trashConfig.TrashRetention(),
+		purgeInterval: trashConfig.TrashPurgeInterval(),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// Shutdown stops the periodic purging, waiting for a purge in progress to finish
+func (p *TrashPurger) Shutdown(ctx context.Context) error {
+	p.stopOnce.Do(func() { close(p.stop) })
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *TrashPurger) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.purge()
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+func (p *TrashPurger) purge() {
+	deletedBefore := time.Now().Add(-p.retention)
+
+	total := 0
+	for {
+		select {
+		case <-p.stop:
+			return
+		default:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+		purged, err := p.repository.PurgeTrash(ctx, deletedBefore, purgeBatchSize)
+		cancel()
+		if err != nil {
+			return
+		}
+
+		total += purged
+		if purged < purgeBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		p.logger.Info(context.Background(), "Trash purged",
+			logger.String("worker", "TrashPurger"),
+			logger.Int("count", total))
+	}
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/interfaces"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/url/cache"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+)
+
+// rescanBatchSize is how many URLs are loaded at once while rescanning
+const rescanBatchSize = 500
+
+// URLRescanner periodically checks the destinations of all enabled URLs with the
+// safety checker, and disables the ones that have been flagged since they were saved
+type URLRescanner struct {
+	repository     repository.URLRepository
+	cache          cache.URLCache
+	checker        interfaces.URLSafetyChecker
+	logger         logger.Logger
+	rescanInterval time.Duration
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewURLRescanner creates a URL rescanner and starts its background loop, unless
+// rescanning is turned off
+func NewURLRescanner(
+	repository repository.URLRepository,
+	cache cache.URLCache,
+	checker interfaces.URLSafetyChecker,
+	logger logger.Logger,
+	safetyConfig config.URLSafetyConfig,
+) *URLRescanner {
+	s := &URLRescanner{
+		repository:     repository,
+		cache:          cache,
+		checker:        checker,
+		logger:         logger,
+		rescanInterval: safetyConfig.SafetyRescanInterval(),
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+	if s.rescanInterval > 0 {
+		go s.run()
+	} else {
+		close(s.done)
+	}
+	return s
+}
+
+// Shutdown stops the periodic rescans, waiting for the batch in progress to finish
+func (s *URLRescanner) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stop) })
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *URLRescanner) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.rescanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.rescan()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *URLRescanner) rescan() {
+	scanned, disabled := 0, 0
+	afterID := ""
+	for {
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+		urls, err := s.repository.FindEnabled(ctx, afterID, rescanBatchSize)
+		if err != nil {
+			cancel()
+			s.logger.Error(context.Background(), "Failed to load URLs to rescan, rescan aborted",
+				logger.String("worker", "URLRescanner"),
+				logger.Int("scanned", scanned),
+				logger.Error(err))
+			return
+		}
+
+		for _, url := range urls {
+			reason, err := s.checker.CheckURL(ctx, url.LongURL())
+			if err != nil {
+				s.logger.Error(ctx, "Failed to check URL safety, URL skipped",
+					logger.String("worker", "URLRescanner"),
+					logger.String("urlId", url.ID()),
+					logger.Error(err))
+				continue
+			}
+			if reason == "" {
+				continue
+			}
+			if err := s.repository.Disable(ctx, url.ID(), reason); err != nil {
+				s.logger.Error(ctx, "Failed to disable unsafe URL, URL skipped",
+					logger.String("worker", "URLRescanner"),
+					logger.String("urlId", url.ID()),
+					logger.String("reason", reason),
+					logger.Error(err))
+				continue
+			}
+			// Cached redirects would otherwise keep working until they expire
+			if err := s.cache.InvalidateShortURL(ctx, url.Domain(), url.ShortCode()); err != nil {
+				s.logger.Error(ctx, "Failed to invalidate cached URL, redirects continue until it expires",
+					logger.String("worker", "URLRescanner"),
+					logger.String("urlId", url.ID()),
+					logger.Error(err))
+			}
+			disabled++
+
+			s.logger.Warn(ctx, "Unsafe URL disabled",
+				logger.String("worker", "URLRescanner"),
+				logger.String("urlId", url.ID()),
+				logger.String("shortCode", url.ShortCode()),
+				logger.String("reason", reason))
+		}
+		cancel()
+
+		scanned += len(urls)
+		if len(urls) < rescanBatchSize {
+			break
+		}
+		afterID = urls[len(urls)-1].ID()
+	}
+
+	s.logger.Info(context.Background(), "URL rescan completed",
+		logger.String("worker", "URLRescanner"),
+		logger.Int("scanned", scanned),
+		logger.Int("disabled", disabled))
+}
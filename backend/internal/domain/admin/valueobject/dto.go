@@ -0,0 +1,91 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package valueobject
+
+import (
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/user/entity"
+)
+
+// UserListRequest represents the query of a page of the users listed to administrators
+type UserListRequest struct {
+	// Limit is the page size, zero for the default
+	Limit int
+	// Cursor is the next_cursor of the previous page, empty for the first page
+	Cursor string
+	// Search matches part of the email or name
+	Search string
+	// Role lists only the users holding the role
+	Role string
+	// Suspended lists only the suspended users
+	Suspended bool
+}
+
+// AdminUserResponse represents a user as administrators see them
+type AdminUserResponse struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Email       string     `json:"email"`
+	Role        string     `json:"role"`
+	CreatedAt   time.Time  `json:"created_at"`
+	SuspendedAt *time.Time `json:"suspended_at,omitempty"`
+}
+
+// UserListResponse represents a page of the users listed to administrators
+type UserListResponse struct {
+	Items []AdminUserResponse `json:"items"`
+	// NextCursor requests the following page, and is omitted on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Total counts the users matching the filters across all pages
+	Total int `json:"total"`
+}
+
+// StatsResponse represents figures about the whole system
+type StatsResponse struct {
+	Users UserStats `json:"users"`
+	URLs  URLStats  `json:"urls"`
+}
+
+// UserStats counts the registered users
+type UserStats struct {
+	Total     int `json:"total"`
+	Admins    int `json:"admins"`
+	Suspended int `json:"suspended"`
+}
+
+// URLStats counts the URLs of every workspace
+type URLStats struct {
+	// Active counts the URLs outside the trash, disabled ones included
+	Active   int `json:"active"`
+	Disabled int `json:"disabled"`
+	Trashed  int `json:"trashed"`
+	// Redirects sums the redirects of every URL, as last flushed to storage
+	Redirects int `json:"redirects"`
+}
+
+// CreateAdminUserResponse creates an AdminUserResponse from a User entity
+func CreateAdminUserResponse(user *entity.User) AdminUserResponse {
+	return AdminUserResponse{
+		ID:          user.ID(),
+		Name:        user.Name(),
+		Email:       user.Email(),
+		Role:        user.Role(),
+		CreatedAt:   user.CreatedAt(),
+		SuspendedAt: user.SuspendedAt(),
+	}
+}
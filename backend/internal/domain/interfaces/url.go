@@ -16,14 +16,34 @@
 
 package interfaces
 
+import (
+	"context"
+	"time"
+)
+
 // URLValidator defines the interface for URL validation
 type URLValidator interface {
 	ValidateURL(longURL string) error
 	ValidateShortCode(shortCode string) error
 	ValidateUserID(userID string) error
+	ValidateExpiresAt(expiresAt time.Time) error
+	ValidateMaxClicks(maxClicks int) error
+	ValidateLinkPassword(password string) error
+	ValidateRedirectType(redirectType int) error
+	ValidateTitle(title string) error
+	ValidateDescription(description string) error
+	ValidateTags(tags []string) error
+	ValidateHostname(hostname string) error
 }
 
 // ShortCodeGenerator defines the interface for generating short codes
 type ShortCodeGenerator interface {
 	GenerateShortCode() (string, error)
 }
+
+// URLSafetyChecker screens destinations for phishing, malware and other abuse
+type URLSafetyChecker interface {
+	// CheckURL returns why the URL is unsafe, or an empty reason when it is not flagged.
+	// An error means the URL could not be checked.
+	CheckURL(ctx context.Context, longURL string) (reason string, err error)
+}
@@ -18,11 +18,13 @@ package config
 
 import (
 	"crypto/rsa"
+	"net/netip"
 	"time"
 )
 
 // DatabaseConfig defines configuration needed for database operations
 type DatabaseConfig interface {
+	Storage() string
 	Host() string
 	Port() int
 	Name() string
@@ -36,6 +38,7 @@ type DatabaseConfig interface {
 	HealthCheckPeriod() time.Duration
 	QueryTimeout() time.Duration
 	ConnectTimeout() time.Duration
+	MigrateOnStart() bool
 }
 
 // AuthConfig defines configuration needed for authentication
@@ -43,6 +46,7 @@ type AuthConfig interface {
 	JWTTokenType() string
 	JWTTokenExpiry() string
 	JWTRefreshExpiry() string
+	JWTLinkUnlockExpiry() string
 	GetRSAPublicKey() *rsa.PublicKey
 	GetRSAPrivateKey() *rsa.PrivateKey
 }
@@ -51,8 +55,62 @@ type AuthConfig interface {
 type URLConfig interface {
 	ShortURLLength() int
 	MaxCollisionRetries() int
+	MaxBulkURLs() int
+	MaxPageSize() int
+	PublicURL() string
 }
 
+// AnalyticsConfig defines configuration needed for click analytics recording
+type AnalyticsConfig interface {
+	ClickBufferSize() int
+	ClickBatchSize() int
+	ClickFlushInterval() time.Duration
+	RedirectFlushInterval() time.Duration
+}
+
+// TrashConfig defines configuration needed for purging deleted URLs
+type TrashConfig interface {
+	TrashRetention() time.Duration
+	TrashPurgeInterval() time.Duration
+}
+
+// URLSafetyConfig defines configuration needed for screening destinations against blocklists
+type URLSafetyConfig interface {
+	Blocklists() []Blocklist
+	BlocklistReloadInterval() time.Duration
+	// SafetyRescanInterval is zero when existing URLs are not rescanned
+	SafetyRescanInterval() time.Duration
+}
+
+// Blocklist is a file listing unsafe destinations
+type Blocklist struct {
+	// Name is reported as the reason a URL on the list is unsafe
+	Name string
+	Type string
+	Path string
+}
+
+// DestinationConfig defines configuration needed for keeping links from pointing at short URLs
+type DestinationConfig interface {
+	// OwnHosts are the hostnames short links are served from
+	OwnHosts() []string
+	// ShortenerHosts are the hostnames of other URL shorteners
+	ShortenerHosts() []string
+	ShortenerPolicy() string
+	MaxShortenerHops() int
+	ShortenerTimeout() time.Duration
+}
+
+// Policies for destinations on other URL shorteners
+const (
+	// ShortenerPolicyAllow stores links to other shorteners as given
+	ShortenerPolicyAllow = "allow"
+	// ShortenerPolicyReject rejects links to other shorteners
+	ShortenerPolicyReject = "reject"
+	// ShortenerPolicyResolve follows links to other shorteners and stores where they end up
+	ShortenerPolicyResolve = "resolve"
+)
+
 // ServerConfig defines configuration needed for HTTP server
 type ServerConfig interface {
 	Port() int
@@ -97,4 +155,16 @@ type SecurityConfig interface {
 	AllowCredentials() bool
 	MaxAge() int
 	RequestTimeout() time.Duration
+	RateLimitEnabled() bool
+	// RateLimitPolicy reports false when no policy with the name is configured
+	RateLimitPolicy(name string) (RateLimitPolicy, bool)
+	// TrustedProxies are the networks of the reverse proxies whose forwarding headers
+	// identify the client
+	TrustedProxies() []netip.Prefix
+}
+
+// RateLimitPolicy allows a number of requests per window
+type RateLimitPolicy struct {
+	Requests int
+	Window   time.Duration
 }
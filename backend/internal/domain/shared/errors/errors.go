@@ -22,12 +22,14 @@ import "fmt"
 type ErrorType string
 
 const (
-	ErrorTypeValidation     ErrorType = "validation"
-	ErrorTypeNotFound       ErrorType = "not_found"
-	ErrorTypeConflict       ErrorType = "conflict"
-	ErrorTypeUnauthorized   ErrorType = "unauthorized"
-	ErrorTypeForbidden      ErrorType = "forbidden"
-	ErrorTypeInternal       ErrorType = "internal"
+	ErrorTypeValidation      ErrorType = "validation"
+	ErrorTypeNotFound        ErrorType = "not_found"
+	ErrorTypeConflict        ErrorType = "conflict"
+	ErrorTypeUnauthorized    ErrorType = "unauthorized"
+	ErrorTypeForbidden       ErrorType = "forbidden"
+	ErrorTypeGone            ErrorType = "gone"
+	ErrorTypeTooManyRequests ErrorType = "too_many_requests"
+	ErrorTypeInternal        ErrorType = "internal"
 )
 
 // DomainError represents an error in the domain layer
@@ -76,6 +78,20 @@ func ForbiddenError(msg string) error {
 	}
 }
 
+func GoneError(msg string) error {
+	return &DomainError{
+		Type:    ErrorTypeGone,
+		Message: msg,
+	}
+}
+
+func TooManyRequestsError(msg string) error {
+	return &DomainError{
+		Type:    ErrorTypeTooManyRequests,
+		Message: msg,
+	}
+}
+
 func InternalError(msg string) error {
 	return &DomainError{
 		Type:    ErrorTypeInternal,
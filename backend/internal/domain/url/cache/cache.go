@@ -19,10 +19,54 @@ package cache
 import (
 	"context"
 	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
 )
 
+// InvalidationWindow is how long an invalidated short code refuses new entries. A
+// redirect that loaded the link before it changed would otherwise cache the old version
+// right after the invalidation; loading a link and caching it takes far less than this.
+const InvalidationWindow = time.Minute
+
+// URLCache caches short code lookups for the redirect path, per domain with an empty
+// domain for the default one. A miss is reported as a nil redirect.
 type URLCache interface {
-	SetShortURL(ctx context.Context, shortCode, originalURL string, ttl time.Duration) error
-	GetOriginalURL(ctx context.Context, shortCode string) (string, error)
-	InvalidateShortURL(ctx context.Context, shortCode string) error
+	// SetShortURL caches the redirect of a short code, unless the short code was
+	// invalidated within the InvalidationWindow
+	SetShortURL(
+		ctx context.Context,
+		domain, shortCode string,
+		redirect valueobject.Redirect,
+		ttl time.Duration,
+	) error
+	GetRedirect(ctx context.Context, domain, shortCode string) (*valueobject.Redirect, error)
+	// InvalidateShortURL drops the cached redirect of a short code and keeps it from
+	// being cached again during the InvalidationWindow
+	InvalidateShortURL(ctx context.Context, domain, shortCode string) error
+}
+
+// ShortURLKey returns the key a short code on a domain is cached under. Short codes of
+// the default domain are their own key; the others are prefixed with their domain, which
+// cannot clash since short codes never contain a slash.
+func ShortURLKey(domain, shortCode string) string {
+	if domain == "" {
+		return shortCode
+	}
+	return domain + "/" + shortCode
+}
+
+// QRCodeCache caches rendered QR code images under a key derived from their content
+// and rendering options
+type QRCodeCache interface {
+	SetQRCode(ctx context.Context, key string, image []byte, ttl time.Duration) error
+	GetQRCode(ctx context.Context, key string) ([]byte, error)
+}
+
+// RedirectCounter accumulates redirect counts per URL ID until they are drained and
+// persisted in batches
+type RedirectCounter interface {
+	Increment(ctx context.Context, urlID string) error
+	Pending(ctx context.Context, urlIDs ...string) (map[string]int, error)
+	Drain(ctx context.Context) (map[string]int, error)
+	Restore(ctx context.Context, counts map[string]int) error
 }
@@ -0,0 +1,222 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cachetest provides the behaviour every URL cache implementation must share,
+// as a test suite to run against each of them.
+package cachetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/url/cache"
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+)
+
+// URLCacheHarness creates the cache under test
+type URLCacheHarness struct {
+	// New returns an empty cache. It is called once per subtest.
+	New func(t *testing.T) cache.URLCache
+	// Wait lets d pass for the cache, so that entries can expire. It defaults to
+	// time.Sleep; caches with a fake clock can advance it instead.
+	Wait func(d time.Duration)
+}
+
+// TestURLCache runs the URL cache contract against the harness
+func TestURLCache(t *testing.T, harness URLCacheHarness) {
+	if harness.Wait == nil {
+		harness.Wait = time.Sleep
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, c cache.URLCache, wait func(time.Duration))
+	}{
+		{"Miss", testMiss},
+		{"SetAndGet", testSetAndGet},
+		{"Overwrite", testOverwrite},
+		{"RedirectSettings", testRedirectSettings},
+		{"NonPositiveTTL", testNonPositiveTTL},
+		{"Invalidate", testInvalidate},
+		{"SetAfterInvalidate", testSetAfterInvalidate},
+		{"Domains", testDomains},
+		{"Expiry", testExpiry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, harness.New(t), harness.Wait)
+		})
+	}
+}
+
+func get(t *testing.T, c cache.URLCache, shortCode string) *valueobject.Redirect {
+	t.Helper()
+	redirect, err := c.GetRedirect(context.Background(), "", shortCode)
+	if err != nil {
+		t.Fatalf("GetRedirect(%s) error = %v", shortCode, err)
+	}
+	return redirect
+}
+
+// assertCached checks the destination cached for the short code, with "" for a miss
+func assertCached(t *testing.T, c cache.URLCache, shortCode, want string) {
+	t.Helper()
+	var got string
+	if redirect := get(t, c, shortCode); redirect != nil {
+		got = redirect.LongURL
+	}
+	if got != want {
+		t.Fatalf("GetRedirect(%s) = %q, want %q", shortCode, got, want)
+	}
+}
+
+func set(t *testing.T, c cache.URLCache, shortCode, originalURL string, ttl time.Duration) {
+	t.Helper()
+	setRedirect(t, c, shortCode, valueobject.Redirect{
+		LongURL:      originalURL,
+		RedirectType: entity.DefaultRedirectType,
+	}, ttl)
+}
+
+func setRedirect(t *testing.T, c cache.URLCache, shortCode string, redirect valueobject.Redirect, ttl time.Duration) {
+	t.Helper()
+	if err := c.SetShortURL(context.Background(), "", shortCode, redirect, ttl); err != nil {
+		t.Fatalf("SetShortURL(%s) error = %v", shortCode, err)
+	}
+}
+
+// A miss is reported as a nil redirect rather than an error
+func testMiss(t *testing.T, c cache.URLCache, _ func(time.Duration)) {
+	assertCached(t, c, "missing", "")
+}
+
+func testSetAndGet(t *testing.T, c cache.URLCache, _ func(time.Duration)) {
+	set(t, c, "abc1234", "https://example.com/a", time.Hour)
+	set(t, c, "def5678", "https://example.com/b", time.Hour)
+
+	assertCached(t, c, "abc1234", "https://example.com/a")
+	assertCached(t, c, "def5678", "https://example.com/b")
+}
+
+func testOverwrite(t *testing.T, c cache.URLCache, _ func(time.Duration)) {
+	set(t, c, "abc1234", "https://example.com/old", time.Hour)
+	set(t, c, "abc1234", "https://example.com/new", time.Hour)
+
+	assertCached(t, c, "abc1234", "https://example.com/new")
+}
+
+// Entries carry the redirect settings of the link along with its destination
+func testRedirectSettings(t *testing.T, c cache.URLCache, _ func(time.Duration)) {
+	want := valueobject.Redirect{
+		URLID:        "5f1c3b9e-2a47-4d8b-9c3e-0a1b2c3d4e5f",
+		LongURL:      "https://example.com/campaign?utm_source=print",
+		RedirectType: entity.RedirectPermanentRedirect,
+		ForwardQuery: true,
+	}
+	setRedirect(t, c, "abc1234", want, time.Hour)
+
+	got := get(t, c, "abc1234")
+	if got == nil || *got != want {
+		t.Fatalf("GetRedirect(abc1234) = %+v, want %+v", got, want)
+	}
+}
+
+// An entry that would never expire could outlive the link, so it is not stored
+func testNonPositiveTTL(t *testing.T, c cache.URLCache, _ func(time.Duration)) {
+	set(t, c, "zero123", "https://example.com/zero", 0)
+	set(t, c, "negative", "https://example.com/negative", -time.Second)
+
+	assertCached(t, c, "zero123", "")
+	assertCached(t, c, "negative", "")
+}
+
+func testInvalidate(t *testing.T, c cache.URLCache, _ func(time.Duration)) {
+	set(t, c, "abc1234", "https://example.com/a", time.Hour)
+
+	if err := c.InvalidateShortURL(context.Background(), "", "abc1234"); err != nil {
+		t.Fatalf("InvalidateShortURL() error = %v", err)
+	}
+	assertCached(t, c, "abc1234", "")
+
+	if err := c.InvalidateShortURL(context.Background(), "", "missing"); err != nil {
+		t.Fatalf("InvalidateShortURL(missing) error = %v", err)
+	}
+}
+
+// A redirect that loaded a link before it was invalidated cannot cache it again
+func testSetAfterInvalidate(t *testing.T, c cache.URLCache, _ func(time.Duration)) {
+	ctx := context.Background()
+	set(t, c, "abc1234", "https://example.com/a", time.Hour)
+	if err := c.InvalidateShortURL(ctx, "", "abc1234"); err != nil {
+		t.Fatalf("InvalidateShortURL() error = %v", err)
+	}
+	if err := c.InvalidateShortURL(ctx, "", "never12"); err != nil {
+		t.Fatalf("InvalidateShortURL(never12) error = %v", err)
+	}
+
+	set(t, c, "abc1234", "https://example.com/stale", time.Hour)
+	set(t, c, "never12", "https://example.com/stale", time.Hour)
+	assertCached(t, c, "abc1234", "")
+	assertCached(t, c, "never12", "")
+
+	// Other short codes and domains are cached as usual
+	set(t, c, "def5678", "https://example.com/b", time.Hour)
+	assertCached(t, c, "def5678", "https://example.com/b")
+	redirect := valueobject.Redirect{LongURL: "https://example.com/brand", RedirectType: entity.DefaultRedirectType}
+	if err := c.SetShortURL(ctx, "go.brand.test", "abc1234", redirect, time.Hour); err != nil {
+		t.Fatalf("SetShortURL(go.brand.test, abc1234) error = %v", err)
+	}
+	if got, err := c.GetRedirect(ctx, "go.brand.test", "abc1234"); err != nil || got == nil {
+		t.Fatalf("GetRedirect(go.brand.test, abc1234) = %+v, %v, want the brand destination", got, err)
+	}
+}
+
+// The same short code on different domains is cached separately
+func testDomains(t *testing.T, c cache.URLCache, _ func(time.Duration)) {
+	ctx := context.Background()
+	set(t, c, "abc1234", "https://example.com/default", time.Hour)
+	redirect := valueobject.Redirect{LongURL: "https://example.com/brand", RedirectType: entity.DefaultRedirectType}
+	if err := c.SetShortURL(ctx, "go.brand.test", "abc1234", redirect, time.Hour); err != nil {
+		t.Fatalf("SetShortURL(go.brand.test, abc1234) error = %v", err)
+	}
+
+	got, err := c.GetRedirect(ctx, "go.brand.test", "abc1234")
+	if err != nil || got == nil || got.LongURL != "https://example.com/brand" {
+		t.Fatalf("GetRedirect(go.brand.test, abc1234) = %+v, %v, want the brand destination", got, err)
+	}
+	assertCached(t, c, "abc1234", "https://example.com/default")
+
+	if err := c.InvalidateShortURL(ctx, "go.brand.test", "abc1234"); err != nil {
+		t.Fatalf("InvalidateShortURL(go.brand.test, abc1234) error = %v", err)
+	}
+	if got, err := c.GetRedirect(ctx, "go.brand.test", "abc1234"); err != nil || got != nil {
+		t.Fatalf("GetRedirect(go.brand.test, abc1234) after invalidation = %+v, %v, want a miss", got, err)
+	}
+	assertCached(t, c, "abc1234", "https://example.com/default")
+}
+
+func testExpiry(t *testing.T, c cache.URLCache, wait func(time.Duration)) {
+	set(t, c, "short12", "https://example.com/short", 100*time.Millisecond)
+	set(t, c, "long123", "https://example.com/long", time.Hour)
+
+	wait(250 * time.Millisecond)
+
+	assertCached(t, c, "short12", "")
+	assertCached(t, c, "long123", "https://example.com/long")
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+import (
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// maxClickFieldLength caps client-supplied header values stored with a click
+	maxClickFieldLength = 512
+
+	ipv4AnonymizationBits = 24
+	ipv6AnonymizationBits = 48
+)
+
+// Click represents a single redirect of a short URL
+type Click struct {
+	urlID          string
+	referrer       string
+	userAgent      string
+	ipAddress      string
+	acceptLanguage string
+	clickedAt      time.Time
+}
+
+// NewClick creates a click event, anonymising the client IP address so that
+// individual visitors cannot be identified from stored analytics
+func NewClick(urlID, referrer, userAgent, ipAddress, acceptLanguage string, clickedAt time.Time) *Click {
+	return &Click{
+		urlID:          urlID,
+		referrer:       sanitizeClickField(referrer),
+		userAgent:      sanitizeClickField(userAgent),
+		ipAddress:      AnonymizeIP(ipAddress),
+		acceptLanguage: sanitizeClickField(acceptLanguage),
+		clickedAt:      clickedAt.UTC(),
+	}
+}
+
+// AnonymizeIP zeroes the host part of an IP address, keeping the /24 network for
+// IPv4 and the /48 network for IPv6. Unparseable addresses yield an empty string.
+func AnonymizeIP(ipAddress string) string {
+	ip := net.ParseIP(strings.TrimSpace(ipAddress))
+	if ip == nil {
+		return ""
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return ipv4.Mask(net.CIDRMask(ipv4AnonymizationBits, 32)).String()
+	}
+	return ip.Mask(net.CIDRMask(ipv6AnonymizationBits, 128)).String()
+}
+
+// Getters
+func (c *Click) URLID() string          { return c.urlID }
+func (c *Click) Referrer() string       { return c.referrer }
+func (c *Click) UserAgent() string      { return c.userAgent }
+func (c *Click) IPAddress() string      { return c.ipAddress }
+func (c *Click) AcceptLanguage() string { return c.acceptLanguage }
+func (c *Click) ClickedAt() time.Time   { return c.clickedAt }
+
+// sanitizeClickField caps a header value at maxClickFieldLength bytes and drops
+// invalid UTF-8, which would otherwise be rejected by the database
+func sanitizeClickField(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) > maxClickFieldLength {
+		value = value[:maxClickFieldLength]
+	}
+	return strings.ToValidUTF8(value, "")
+}
+
+// ClickInterval is the width of the time buckets click counts are grouped into
+type ClickInterval string
+
+const (
+	ClickIntervalHour  ClickInterval = "hour"
+	ClickIntervalDay   ClickInterval = "day"
+	ClickIntervalWeek  ClickInterval = "week"
+	ClickIntervalMonth ClickInterval = "month"
+)
+
+// IsValid checks if the interval is one of the supported bucket widths
+func (i ClickInterval) IsValid() bool {
+	switch i {
+	case ClickIntervalHour, ClickIntervalDay, ClickIntervalWeek, ClickIntervalMonth:
+		return true
+	}
+	return false
+}
+
+// Truncate returns the start of the bucket containing t, in UTC. Weeks start on Monday.
+func (i ClickInterval) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	switch i {
+	case ClickIntervalHour:
+		return t.Truncate(time.Hour)
+	case ClickIntervalWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case ClickIntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// Next returns the start of the bucket following the one starting at t
+func (i ClickInterval) Next(t time.Time) time.Time {
+	switch i {
+	case ClickIntervalHour:
+		return t.Add(time.Hour)
+	case ClickIntervalWeek:
+		return t.AddDate(0, 0, 7)
+	case ClickIntervalMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// ClickBucket is the number of clicks in the time bucket starting at Start
+type ClickBucket struct {
+	Start time.Time
+	Count int
+}
+
+// ClickCount is the number of clicks sharing the same value, such as a referrer or user agent
+type ClickCount struct {
+	Value string
+	Count int
+}
+
+// ClickStats summarises the clicks of a URL over a time range
+type ClickStats struct {
+	Total         int
+	Timeline      []ClickBucket
+	TopReferrers  []ClickCount
+	TopUserAgents []ClickCount
+}
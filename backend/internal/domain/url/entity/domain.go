@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/interfaces"
+)
+
+const (
+	// DomainVerificationRecord is the name, below the domain, of the DNS TXT record
+	// proving control over it
+	DomainVerificationRecord = "_shortly-verification"
+	// domainVerificationPrefix starts the value of the verification record
+	domainVerificationPrefix = "shortly-verification="
+)
+
+// Domain is a custom domain a user serves short URLs from. Any user may add a
+// hostname, but it can only be used once verified through a DNS TXT record holding
+// its verification token, which only one of them can do.
+type Domain struct {
+	id       string
+	userID   string
+	hostname string
+	// verificationToken is the secret the verification record has to contain
+	verificationToken string
+	verifiedAt        *time.Time
+	createdAt         time.Time
+}
+
+// NewDomain creates an unverified domain with validation
+func NewDomain(
+	id, userID, hostname, verificationToken string,
+	validator interfaces.URLValidator,
+) (*Domain, error) {
+	if err := validator.ValidateUserID(userID); err != nil {
+		return nil, err
+	}
+	hostname = NormalizeHostname(hostname)
+	if err := validator.ValidateHostname(hostname); err != nil {
+		return nil, err
+	}
+
+	return &Domain{
+		id:                id,
+		userID:            userID,
+		hostname:          hostname,
+		verificationToken: verificationToken,
+		createdAt:         time.Now().UTC(),
+	}, nil
+}
+
+// NewDomainFromRepository creates a domain from repository data (already validated)
+func NewDomainFromRepository(
+	id, userID, hostname, verificationToken string,
+	verifiedAt *time.Time,
+	createdAt time.Time,
+) *Domain {
+	return &Domain{
+		id:                id,
+		userID:            userID,
+		hostname:          hostname,
+		verificationToken: verificationToken,
+		verifiedAt:        verifiedAt,
+		createdAt:         createdAt,
+	}
+}
+
+// NormalizeHostname lowercases a hostname and drops a trailing dot, so that the
+// spellings of a domain compare equal
+func NormalizeHostname(hostname string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(hostname)), ".")
+}
+
+// VerificationRecordName is the DNS name the verification record is looked up at
+func (d *Domain) VerificationRecordName() string {
+	return DomainVerificationRecord + "." + d.hostname
+}
+
+// VerificationRecordValue is the TXT record value that verifies the domain
+func (d *Domain) VerificationRecordValue() string {
+	return domainVerificationPrefix + d.verificationToken
+}
+
+// Verify marks the domain as verified at the given time, unless it already is
+func (d *Domain) Verify(at time.Time) {
+	if d.verifiedAt == nil {
+		verifiedAt := at.UTC()
+		d.verifiedAt = &verifiedAt
+	}
+}
+
+// IsVerified checks if control over the domain has been proven
+func (d *Domain) IsVerified() bool {
+	return d.verifiedAt != nil
+}
+
+// IsOwnedBy checks if the domain was added by the specified user
+func (d *Domain) IsOwnedBy(userID string) bool {
+	return d.userID == userID
+}
+
+// Getters
+func (d *Domain) ID() string                { return d.id }
+func (d *Domain) UserID() string            { return d.userID }
+func (d *Domain) Hostname() string          { return d.hostname }
+func (d *Domain) VerificationToken() string { return d.verificationToken }
+func (d *Domain) VerifiedAt() *time.Time    { return d.verifiedAt }
+func (d *Domain) CreatedAt() time.Time      { return d.createdAt }
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+import "time"
+
+// URLRevision records a change of the destination of a URL
+type URLRevision struct {
+	// id increases with every revision, so later revisions have larger IDs
+	id      int64
+	urlID   string
+	oldURL  string
+	newURL  string
+	actorID string
+	// createdAt is when the change was made
+	createdAt time.Time
+}
+
+// NewURLRevision creates a revision for a destination change made now by the actor
+func NewURLRevision(urlID, oldURL, newURL, actorID string) *URLRevision {
+	return &URLRevision{
+		urlID:     urlID,
+		oldURL:    oldURL,
+		newURL:    newURL,
+		actorID:   actorID,
+		createdAt: time.Now().UTC(),
+	}
+}
+
+// NewURLRevisionFromRepository creates a revision from repository data
+func NewURLRevisionFromRepository(
+	id int64,
+	urlID, oldURL, newURL, actorID string,
+	createdAt time.Time,
+) *URLRevision {
+	return &URLRevision{
+		id:        id,
+		urlID:     urlID,
+		oldURL:    oldURL,
+		newURL:    newURL,
+		actorID:   actorID,
+		createdAt: createdAt,
+	}
+}
+
+// Getters
+func (r *URLRevision) ID() int64            { return r.id }
+func (r *URLRevision) URLID() string        { return r.urlID }
+func (r *URLRevision) OldURL() string       { return r.oldURL }
+func (r *URLRevision) NewURL() string       { return r.newURL }
+func (r *URLRevision) ActorID() string      { return r.actorID }
+func (r *URLRevision) CreatedAt() time.Time { return r.createdAt }
@@ -17,24 +17,64 @@
 package entity
 
 import (
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/PraveenGongada/shortly/internal/domain/interfaces"
 )
 
+// Status codes a URL can redirect with
+const (
+	RedirectMovedPermanently  = 301
+	RedirectFound             = 302
+	RedirectTemporaryRedirect = 307
+	RedirectPermanentRedirect = 308
+
+	// DefaultRedirectType is used for URLs created without a redirect type
+	DefaultRedirectType = RedirectFound
+)
+
 // URL represents a URL aggregate root
 type URL struct {
-	id        string
-	userID    string
+	id string
+	// userID is the user who created the URL, and workspaceID the workspace it belongs to,
+	// whose members manage it according to their role
+	userID      string
+	workspaceID string
+	// domain is the custom domain the URL is served from, empty for the default domain.
+	// Short codes are unique per domain.
+	domain    string
 	shortCode string
 	longURL   string
 	redirects int
+	expiresAt *time.Time
+	maxClicks *int
+	// passwordHash is empty for URLs that are not password protected
+	passwordHash string
+	redirectType int
+	// forwardQuery merges the query string of each visit into the destination
+	forwardQuery bool
+	title        string
+	description  string
+	// tags are normalized by SetTags: lower case, without duplicates, sorted
+	tags      []string
 	createdAt time.Time
 	updatedAt *time.Time
+	// deletedAt is set while the URL is in the trash
+	deletedAt *time.Time
+	// disabledAt is set once the URL is taken down, and disabledReason says why
+	disabledAt     *time.Time
+	disabledReason string
+	// revision is the destination change made since the URL was loaded, saved with it
+	revision *URLRevision
 }
 
 // NewURL creates a new URL with validation
-func NewURL(id, userID, shortCode, longURL string, validator interfaces.URLValidator) (*URL, error) {
+func NewURL(
+	id, userID, workspaceID, domain, shortCode, longURL string,
+	validator interfaces.URLValidator,
+) (*URL, error) {
 	if err := validator.ValidateUserID(userID); err != nil {
 		return nil, err
 	}
@@ -46,57 +86,313 @@ func NewURL(id, userID, shortCode, longURL string, validator interfaces.URLValid
 	}
 
 	return &URL{
-		id:        id,
-		userID:    userID,
-		shortCode: shortCode,
-		longURL:   longURL,
-		redirects: 0,
-		createdAt: time.Now().UTC(),
+		id:           id,
+		userID:       userID,
+		workspaceID:  workspaceID,
+		domain:       domain,
+		shortCode:    shortCode,
+		longURL:      longURL,
+		redirects:    0,
+		redirectType: DefaultRedirectType,
+		createdAt:    time.Now().UTC(),
 	}, nil
 }
 
+// URLSnapshot holds the stored state of a URL, from which repositories restore it
+type URLSnapshot struct {
+	ID             string
+	UserID         string
+	WorkspaceID    string
+	Domain         string
+	ShortCode      string
+	LongURL        string
+	Redirects      int
+	ExpiresAt      *time.Time
+	MaxClicks      *int
+	PasswordHash   string
+	RedirectType   int
+	ForwardQuery   bool
+	Title          string
+	Description    string
+	Tags           []string
+	CreatedAt      time.Time
+	UpdatedAt      *time.Time
+	DeletedAt      *time.Time
+	DisabledAt     *time.Time
+	DisabledReason string
+}
+
 // NewURLFromRepository creates URL from repository data (already validated)
-func NewURLFromRepository(id, userID, shortCode, longURL string, redirects int, createdAt time.Time, updatedAt *time.Time) *URL {
+func NewURLFromRepository(snapshot URLSnapshot) *URL {
 	return &URL{
-		id:        id,
-		userID:    userID,
-		shortCode: shortCode,
-		longURL:   longURL,
-		redirects: redirects,
-		createdAt: createdAt,
-		updatedAt: updatedAt,
+		id:             snapshot.ID,
+		userID:         snapshot.UserID,
+		workspaceID:    snapshot.WorkspaceID,
+		domain:         snapshot.Domain,
+		shortCode:      snapshot.ShortCode,
+		longURL:        snapshot.LongURL,
+		redirects:      snapshot.Redirects,
+		expiresAt:      snapshot.ExpiresAt,
+		maxClicks:      snapshot.MaxClicks,
+		passwordHash:   snapshot.PasswordHash,
+		redirectType:   snapshot.RedirectType,
+		forwardQuery:   snapshot.ForwardQuery,
+		title:          snapshot.Title,
+		description:    snapshot.Description,
+		tags:           snapshot.Tags,
+		createdAt:      snapshot.CreatedAt,
+		updatedAt:      snapshot.UpdatedAt,
+		deletedAt:      snapshot.DeletedAt,
+		disabledAt:     snapshot.DisabledAt,
+		disabledReason: snapshot.DisabledReason,
+	}
+}
+
+// Snapshot returns the state of the URL to store, sharing its pointers and tags.
+// A pending revision is not part of it.
+func (u *URL) Snapshot() URLSnapshot {
+	return URLSnapshot{
+		ID:             u.id,
+		UserID:         u.userID,
+		WorkspaceID:    u.workspaceID,
+		Domain:         u.domain,
+		ShortCode:      u.shortCode,
+		LongURL:        u.longURL,
+		Redirects:      u.redirects,
+		ExpiresAt:      u.expiresAt,
+		MaxClicks:      u.maxClicks,
+		PasswordHash:   u.passwordHash,
+		RedirectType:   u.redirectType,
+		ForwardQuery:   u.forwardQuery,
+		Title:          u.title,
+		Description:    u.description,
+		Tags:           u.tags,
+		CreatedAt:      u.createdAt,
+		UpdatedAt:      u.updatedAt,
+		DeletedAt:      u.deletedAt,
+		DisabledAt:     u.disabledAt,
+		DisabledReason: u.disabledReason,
 	}
 }
 
-// UpdateLongURL updates the target URL with validation
-func (u *URL) UpdateLongURL(newURL string, validator interfaces.URLValidator) error {
+// UpdateLongURL updates the target URL with validation, recording the change as a
+// revision made by the actor
+func (u *URL) UpdateLongURL(newURL, actorID string, validator interfaces.URLValidator) error {
 	if err := validator.ValidateURL(newURL); err != nil {
 		return err
 	}
+	if newURL == u.longURL {
+		return nil
+	}
+	oldURL := u.longURL
+	if u.revision != nil {
+		oldURL = u.revision.OldURL()
+	}
+	u.revision = nil
+	if newURL != oldURL {
+		u.revision = NewURLRevision(u.id, oldURL, newURL, actorID)
+	}
 	u.longURL = newURL
 	u.markUpdated()
 	return nil
 }
 
+// PendingRevision returns the unsaved destination change of the URL, or nil
+func (u *URL) PendingRevision() *URLRevision {
+	return u.revision
+}
+
+// UpdateExpiresAt sets the time after which the URL stops redirecting
+func (u *URL) UpdateExpiresAt(expiresAt time.Time, validator interfaces.URLValidator) error {
+	if err := validator.ValidateExpiresAt(expiresAt); err != nil {
+		return err
+	}
+	expiresAt = expiresAt.UTC()
+	u.expiresAt = &expiresAt
+	u.markUpdated()
+	return nil
+}
+
+// UpdateMaxClicks sets the number of redirects after which the URL stops redirecting
+func (u *URL) UpdateMaxClicks(maxClicks int, validator interfaces.URLValidator) error {
+	if err := validator.ValidateMaxClicks(maxClicks); err != nil {
+		return err
+	}
+	u.maxClicks = &maxClicks
+	u.markUpdated()
+	return nil
+}
+
+// ClearExpiration removes both the time and click limits so the URL never expires
+func (u *URL) ClearExpiration() {
+	u.expiresAt = nil
+	u.maxClicks = nil
+	u.markUpdated()
+}
+
+// IsExpired checks if the URL has passed its expiry time or used up its click limit
+func (u *URL) IsExpired(now time.Time) bool {
+	if u.expiresAt != nil && !now.Before(*u.expiresAt) {
+		return true
+	}
+	return u.maxClicks != nil && u.redirects >= *u.maxClicks
+}
+
+// SetPassword protects the URL with a password, storing only its hash
+func (u *URL) SetPassword(
+	password string,
+	validator interfaces.URLValidator,
+	hasher interfaces.PasswordHasher,
+) error {
+	if err := validator.ValidateLinkPassword(password); err != nil {
+		return err
+	}
+	hashedPassword, err := hasher.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	u.passwordHash = hashedPassword
+	u.markUpdated()
+	return nil
+}
+
+// ClearPassword removes the password so the URL redirects without unlocking
+func (u *URL) ClearPassword() {
+	u.passwordHash = ""
+	u.markUpdated()
+}
+
+// IsPasswordProtected checks if the URL has to be unlocked before redirecting
+func (u *URL) IsPasswordProtected() bool {
+	return u.passwordHash != ""
+}
+
+// VerifyPassword checks the password against the stored hash
+func (u *URL) VerifyPassword(password string, hasher interfaces.PasswordHasher) error {
+	return hasher.VerifyPassword(u.passwordHash, password)
+}
+
+// UpdateRedirectType sets the HTTP status code visitors are redirected with
+func (u *URL) UpdateRedirectType(redirectType int, validator interfaces.URLValidator) error {
+	if err := validator.ValidateRedirectType(redirectType); err != nil {
+		return err
+	}
+	u.redirectType = redirectType
+	u.markUpdated()
+	return nil
+}
+
+// UpdateForwardQuery sets whether the query string of a visit is passed on to the destination
+func (u *URL) UpdateForwardQuery(forwardQuery bool) {
+	u.forwardQuery = forwardQuery
+	u.markUpdated()
+}
+
+// UpdateTitle sets the title shown for the URL in listings
+func (u *URL) UpdateTitle(title string, validator interfaces.URLValidator) error {
+	title = strings.TrimSpace(title)
+	if err := validator.ValidateTitle(title); err != nil {
+		return err
+	}
+	u.title = title
+	u.markUpdated()
+	return nil
+}
+
+// UpdateDescription sets the free-form notes kept with the URL
+func (u *URL) UpdateDescription(description string, validator interfaces.URLValidator) error {
+	description = strings.TrimSpace(description)
+	if err := validator.ValidateDescription(description); err != nil {
+		return err
+	}
+	u.description = description
+	u.markUpdated()
+	return nil
+}
+
+// SetTags replaces the tags of the URL. Tags are compared case-insensitively, so they
+// are stored in lower case, and duplicates are dropped.
+func (u *URL) SetTags(tags []string, validator interfaces.URLValidator) error {
+	normalized := NormalizeTags(tags)
+	if err := validator.ValidateTags(normalized); err != nil {
+		return err
+	}
+	u.tags = normalized
+	u.markUpdated()
+	return nil
+}
+
+// HasTag checks if the URL is tagged with the tag, in any case
+func (u *URL) HasTag(tag string) bool {
+	_, found := slices.BinarySearch(u.tags, NormalizeTag(tag))
+	return found
+}
+
 // IncrementRedirects increases the redirect count
 func (u *URL) IncrementRedirects() {
 	u.redirects++
 	u.markUpdated()
 }
 
-// IsOwnedBy checks if the URL belongs to the specified user
-func (u *URL) IsOwnedBy(userID string) bool {
-	return u.userID == userID
+// IncludePendingRedirects adds redirects that have been counted but not yet persisted
+func (u *URL) IncludePendingRedirects(pending int) {
+	u.redirects += pending
+}
+
+// MoveToTrash marks the URL as deleted at the given time
+func (u *URL) MoveToTrash(at time.Time) {
+	at = at.UTC()
+	u.deletedAt = &at
+}
+
+// Restore takes the URL out of the trash
+func (u *URL) Restore() {
+	u.deletedAt = nil
+}
+
+// IsDeleted reports whether the URL is in the trash
+func (u *URL) IsDeleted() bool {
+	return u.deletedAt != nil
+}
+
+// Disable takes the URL down at the given time, so it stops redirecting
+func (u *URL) Disable(reason string, at time.Time) {
+	at = at.UTC()
+	u.disabledAt = &at
+	u.disabledReason = reason
+}
+
+// IsDisabled reports whether the URL has been taken down
+func (u *URL) IsDisabled() bool {
+	return u.disabledAt != nil
+}
+
+// BelongsTo checks if the URL belongs to the specified workspace
+func (u *URL) BelongsTo(workspaceID string) bool {
+	return u.workspaceID == workspaceID
 }
 
 // Getters
-func (u *URL) ID() string            { return u.id }
-func (u *URL) UserID() string        { return u.userID }
-func (u *URL) ShortCode() string     { return u.shortCode }
-func (u *URL) LongURL() string       { return u.longURL }
-func (u *URL) Redirects() int        { return u.redirects }
-func (u *URL) CreatedAt() time.Time  { return u.createdAt }
-func (u *URL) UpdatedAt() *time.Time { return u.updatedAt }
+func (u *URL) ID() string             { return u.id }
+func (u *URL) UserID() string         { return u.userID }
+func (u *URL) WorkspaceID() string    { return u.workspaceID }
+func (u *URL) Domain() string         { return u.domain }
+func (u *URL) ShortCode() string      { return u.shortCode }
+func (u *URL) LongURL() string        { return u.longURL }
+func (u *URL) Redirects() int         { return u.redirects }
+func (u *URL) ExpiresAt() *time.Time  { return u.expiresAt }
+func (u *URL) MaxClicks() *int        { return u.maxClicks }
+func (u *URL) PasswordHash() string   { return u.passwordHash }
+func (u *URL) RedirectType() int      { return u.redirectType }
+func (u *URL) ForwardQuery() bool     { return u.forwardQuery }
+func (u *URL) Title() string          { return u.title }
+func (u *URL) Description() string    { return u.description }
+func (u *URL) Tags() []string         { return u.tags }
+func (u *URL) CreatedAt() time.Time   { return u.createdAt }
+func (u *URL) UpdatedAt() *time.Time  { return u.updatedAt }
+func (u *URL) DeletedAt() *time.Time  { return u.deletedAt }
+func (u *URL) DisabledAt() *time.Time { return u.disabledAt }
+func (u *URL) DisabledReason() string { return u.disabledReason }
 
 func (u *URL) markUpdated() {
 	now := time.Now().UTC()
@@ -104,3 +400,18 @@ func (u *URL) markUpdated() {
 }
 
 type URLList []*URL
+
+// NormalizeTag returns the form a tag is stored and compared in
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// NormalizeTags normalizes each tag and returns them sorted, without duplicates
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, len(tags))
+	for i, tag := range tags {
+		normalized[i] = NormalizeTag(tag)
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
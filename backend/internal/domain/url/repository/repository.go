@@ -18,18 +18,188 @@ package repository
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
 )
 
-// URLRepository defines persistence operations for URLs
+// URLFilter narrows down the URLs listed for a workspace. Zero fields match every URL.
+type URLFilter struct {
+	// UserID lists only the URLs created by the user
+	UserID string
+	// Tags are normalized tags a URL must all carry
+	Tags []string
+	// Search matches part of the short code, destination or title, in any case
+	Search string
+	// CreatedFrom and CreatedTo bound the creation time; CreatedTo is exclusive
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// Deleted lists the URLs in the trash instead of the active ones
+	Deleted bool
+}
+
+// URLSort orders the URLs listed for a workspace
+type URLSort string
+
+const (
+	// SortCreated lists the newest URLs first
+	SortCreated URLSort = "created"
+	// SortUpdated lists the most recently changed URLs first, counting creation as a change
+	SortUpdated URLSort = "updated"
+	// SortRedirects lists the most followed URLs first, by their flushed redirect count
+	SortRedirects URLSort = "redirects"
+	// SortAlphabetical lists URLs by their SortName, from A to Z
+	SortAlphabetical URLSort = "alphabetical"
+)
+
+// URLSorts lists every URL listing order
+var URLSorts = []URLSort{SortCreated, SortUpdated, SortRedirects, SortAlphabetical}
+
+// URLCursor is the position of a URL in a listing: the values it is sorted by, and its
+// ID to tell apart URLs sorting equally
+type URLCursor struct {
+	CreatedAt time.Time
+	// UpdatedAt is the creation time of URLs that were never updated
+	UpdatedAt time.Time
+	Redirects int
+	Name      string
+	ID        string
+}
+
+// NewURLCursor returns the position of the URL in a listing
+func NewURLCursor(url *entity.URL) URLCursor {
+	updatedAt := url.CreatedAt()
+	if url.UpdatedAt() != nil {
+		updatedAt = *url.UpdatedAt()
+	}
+	return URLCursor{
+		CreatedAt: url.CreatedAt(),
+		UpdatedAt: updatedAt,
+		Redirects: url.Redirects(),
+		Name:      SortName(url),
+		ID:        url.ID(),
+	}
+}
+
+// SortName is the name a URL is sorted by alphabetically: its lowercased title, or its
+// short code when it has none
+func SortName(url *entity.URL) string {
+	if url.Title() != "" {
+		return strings.ToLower(url.Title())
+	}
+	return strings.ToLower(url.ShortCode())
+}
+
+// URLPage selects a page of the URLs listed for a workspace
+type URLPage struct {
+	Sort URLSort
+	// After is the position of the last URL of the previous page, nil for the first page
+	After *URLCursor
+	Limit int
+}
+
+// ShortLink identifies a URL by the domain it is served from, empty for the default
+// domain, and its short code
+type ShortLink struct {
+	Domain    string
+	ShortCode string
+}
+
+// URLTotals counts the URLs of every workspace
+type URLTotals struct {
+	// Active counts the URLs outside the trash, disabled ones included
+	Active   int
+	Disabled int
+	// Deleted counts the URLs in the trash
+	Deleted int
+	// Redirects sums the flushed redirect counts of the URLs, including those in the trash
+	Redirects int
+}
+
+// URLRepository defines persistence operations for URLs. Saving and updating a URL
+// also replaces its tags, and updating it records its pending revision. URLs in the trash are only found by FindDeletedByID,
+// and by FindByWorkspaceID and CountByWorkspaceID with URLFilter.Deleted set, but keep their short code taken.
 type URLRepository interface {
 	Save(ctx context.Context, url *entity.URL) (*entity.URL, error)
-	FindByShortCode(ctx context.Context, shortCode string) (*entity.URL, error)
+	// SaveBatch saves the URLs atomically and returns them in input order. URLs whose
+	// short code is already taken are skipped and returned as nil.
+	SaveBatch(ctx context.Context, urls []*entity.URL) ([]*entity.URL, error)
+	FindByShortCode(ctx context.Context, domain, shortCode string) (*entity.URL, error)
 	FindByID(ctx context.Context, id string) (*entity.URL, error)
-	FindByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.URL, error)
-	ExistsByShortCode(ctx context.Context, shortCode string) (bool, error)
+	// FindDeletedByID returns a URL in the trash by its ID
+	FindDeletedByID(ctx context.Context, id string) (*entity.URL, error)
+	// FindByWorkspaceID returns a page of the URLs of the workspace matching the filter,
+	// ordered by page.Sort and then by ID
+	FindByWorkspaceID(ctx context.Context, workspaceID string, filter URLFilter, page URLPage) ([]*entity.URL, error)
+	CountByWorkspaceID(ctx context.Context, workspaceID string, filter URLFilter) (int, error)
+	// ForEachByWorkspaceID streams all URLs of the workspace, oldest first, to fn without
+	// loading them at once. Iteration stops at the first error returned by fn, which is returned.
+	ForEachByWorkspaceID(ctx context.Context, workspaceID string, fn func(url *entity.URL) error) error
+	// FindAll and CountAll are FindByWorkspaceID and CountByWorkspaceID across every workspace
+	FindAll(ctx context.Context, filter URLFilter, page URLPage) ([]*entity.URL, error)
+	CountAll(ctx context.Context, filter URLFilter) (int, error)
+	Totals(ctx context.Context) (URLTotals, error)
+	ExistsByShortCode(ctx context.Context, domain, shortCode string) (bool, error)
+	// ExistsByShortCodes reports which of the short links are already taken
+	ExistsByShortCodes(ctx context.Context, links []ShortLink) (map[ShortLink]bool, error)
+	// ExistsByDomain reports whether any URL, including those in the trash, is served
+	// from the domain
+	ExistsByDomain(ctx context.Context, domain string) (bool, error)
 	Update(ctx context.Context, url *entity.URL) error
+	// MoveToTrash moves an active URL of the workspace to the trash
+	MoveToTrash(ctx context.Context, id, workspaceID string) error
+	// Restore moves a URL of the workspace out of the trash
+	Restore(ctx context.Context, id, workspaceID string) error
+	// Disable takes down an active URL for the given reason. A URL that is already
+	// disabled keeps the time and reason it was first disabled with.
+	Disable(ctx context.Context, id, reason string) error
+	// FindEnabled returns up to limit active URLs that are not disabled, ordered by ID
+	// and starting after afterID, so that all of them can be scanned in batches
+	FindEnabled(ctx context.Context, afterID string, limit int) ([]*entity.URL, error)
+	// PurgeTrash permanently deletes up to limit URLs moved to the trash before the given
+	// time, along with their clicks and tags, and returns how many were deleted
+	PurgeTrash(ctx context.Context, deletedBefore time.Time, limit int) (int, error)
+	// AddRedirects adds the counts, keyed by URL ID, to the redirects of the URLs
+	AddRedirects(ctx context.Context, counts map[string]int) error
+	// ClaimRedirect adds a redirect to the URL unless its redirects, together with the
+	// given redirects counted elsewhere and not added yet, have reached its click limit.
+	// It reports whether the redirect was added, so that concurrent redirects of a
+	// click-limited URL cannot exceed the limit.
+	ClaimRedirect(ctx context.Context, id string, pending int) (bool, error)
+	// ListTags returns the tags used by the URLs of the workspace, by name
+	ListTags(ctx context.Context, workspaceID string) ([]entity.TagCount, error)
+	// FindRevisions returns up to limit destination changes of the URL, newest first,
+	// starting after the revision with ID before, or with the newest when before is 0
+	FindRevisions(ctx context.Context, urlID string, before int64, limit int) ([]*entity.URLRevision, error)
+	// FindRevision returns a destination change of the URL by its ID
+	FindRevision(ctx context.Context, urlID string, id int64) (*entity.URLRevision, error)
+}
+
+// ClickRepository defines persistence operations for click events
+type ClickRepository interface {
+	SaveBatch(ctx context.Context, clicks []*entity.Click) error
+	GetStats(
+		ctx context.Context,
+		urlID string,
+		from, to time.Time,
+		interval entity.ClickInterval,
+		top int,
+	) (*entity.ClickStats, error)
+}
+
+// DomainRepository defines persistence operations for custom domains. A hostname can be
+// added once per user, and verified for only one of them.
+type DomainRepository interface {
+	Save(ctx context.Context, domain *entity.Domain) error
+	FindByID(ctx context.Context, id string) (*entity.Domain, error)
+	// FindByUserID returns the domains added by the user, by hostname
+	FindByUserID(ctx context.Context, userID string) ([]*entity.Domain, error)
+	// FindVerified returns the domain verified for the hostname
+	FindVerified(ctx context.Context, hostname string) (*entity.Domain, error)
+	// MarkVerified records when the domain was verified, failing with a conflict when
+	// its hostname is already verified for another user
+	MarkVerified(ctx context.Context, id string, verifiedAt time.Time) error
+	// Delete removes a domain added by the user
 	Delete(ctx context.Context, id, userID string) error
-	IncrementRedirects(ctx context.Context, shortCode string) error
 }
@@ -0,0 +1,193 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+)
+
+// DomainRepositoryHarness creates the repository under test
+type DomainRepositoryHarness struct {
+	// New returns an empty repository. It is called once per subtest.
+	New func(t *testing.T) repository.DomainRepository
+	// CreateUser makes userID usable as a domain owner, for stores enforcing that
+	// owners exist. It may be nil.
+	CreateUser func(t *testing.T, userID string)
+}
+
+// TestDomainRepository runs the domain repository contract against the harness
+func TestDomainRepository(t *testing.T, harness DomainRepositoryHarness) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, h *domainHarness)
+	}{
+		{"SaveAndFind", testSaveAndFindDomain},
+		{"SaveDuplicateHostname", testSaveDuplicateHostname},
+		{"FindByUserID", testFindDomainsByUserID},
+		{"MarkVerified", testMarkVerified},
+		{"Delete", testDeleteDomain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, &domainHarness{t: t, harness: harness, repo: harness.New(t)})
+		})
+	}
+}
+
+type domainHarness struct {
+	t       *testing.T
+	harness DomainRepositoryHarness
+	repo    repository.DomainRepository
+}
+
+func (h *domainHarness) newUser() string {
+	userID := uuid.NewString()
+	if h.harness.CreateUser != nil {
+		h.harness.CreateUser(h.t, userID)
+	}
+	return userID
+}
+
+func (h *domainHarness) save(userID, hostname string) *entity.Domain {
+	h.t.Helper()
+	domain := entity.NewDomainFromRepository(
+		uuid.NewString(), userID, hostname, uuid.NewString(), nil,
+		time.Now().UTC().Truncate(time.Microsecond),
+	)
+	if err := h.repo.Save(context.Background(), domain); err != nil {
+		h.t.Fatalf("Save(%s) error = %v", hostname, err)
+	}
+	return domain
+}
+
+func assertSameDomain(t *testing.T, got, want *entity.Domain) {
+	t.Helper()
+	if got.ID() != want.ID() || got.UserID() != want.UserID() || got.Hostname() != want.Hostname() ||
+		got.VerificationToken() != want.VerificationToken() {
+		t.Fatalf("Domain = {%s %s %s %s}, want {%s %s %s %s}",
+			got.ID(), got.UserID(), got.Hostname(), got.VerificationToken(),
+			want.ID(), want.UserID(), want.Hostname(), want.VerificationToken())
+	}
+	if !got.CreatedAt().Equal(want.CreatedAt()) {
+		t.Fatalf("CreatedAt = %v, want %v", got.CreatedAt(), want.CreatedAt())
+	}
+	assertTimePtr(t, "VerifiedAt", got.VerifiedAt(), want.VerifiedAt())
+}
+
+func testSaveAndFindDomain(t *testing.T, h *domainHarness) {
+	ctx := context.Background()
+	domain := h.save(h.newUser(), "go.example.com")
+
+	found, err := h.repo.FindByID(ctx, domain.ID())
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	assertSameDomain(t, found, domain)
+
+	_, err = h.repo.FindByID(ctx, uuid.NewString())
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+	// Unverified domains do not serve links
+	_, err = h.repo.FindVerified(ctx, "go.example.com")
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+}
+
+func testSaveDuplicateHostname(t *testing.T, h *domainHarness) {
+	userID := h.newUser()
+	h.save(userID, "go.example.com")
+
+	duplicate := entity.NewDomainFromRepository(
+		uuid.NewString(), userID, "go.example.com", uuid.NewString(), nil, time.Now(),
+	)
+	assertErrorType(t, h.repo.Save(context.Background(), duplicate), errors.ErrorTypeConflict)
+
+	// Another user may claim the hostname until one of them verifies it
+	h.save(h.newUser(), "go.example.com")
+}
+
+func testFindDomainsByUserID(t *testing.T, h *domainHarness) {
+	userID := h.newUser()
+	second := h.save(userID, "links.example.com")
+	first := h.save(userID, "go.example.com")
+	h.save(h.newUser(), "go.example.org")
+
+	domains, err := h.repo.FindByUserID(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("FindByUserID() error = %v", err)
+	}
+	if len(domains) != 2 {
+		t.Fatalf("FindByUserID() returned %d domains, want 2", len(domains))
+	}
+	assertSameDomain(t, domains[0], first)
+	assertSameDomain(t, domains[1], second)
+}
+
+func testMarkVerified(t *testing.T, h *domainHarness) {
+	ctx := context.Background()
+	domain := h.save(h.newUser(), "go.example.com")
+	rival := h.save(h.newUser(), "go.example.com")
+
+	verifiedAt := time.Now().UTC().Truncate(time.Microsecond)
+	if err := h.repo.MarkVerified(ctx, domain.ID(), verifiedAt); err != nil {
+		t.Fatalf("MarkVerified() error = %v", err)
+	}
+	// Verifying again keeps the original time
+	if err := h.repo.MarkVerified(ctx, domain.ID(), verifiedAt.Add(time.Hour)); err != nil {
+		t.Fatalf("MarkVerified(again) error = %v", err)
+	}
+
+	found, err := h.repo.FindVerified(ctx, "go.example.com")
+	if err != nil {
+		t.Fatalf("FindVerified() error = %v", err)
+	}
+	if found.ID() != domain.ID() {
+		t.Fatalf("FindVerified() = %s, want %s", found.ID(), domain.ID())
+	}
+	assertTimePtr(t, "VerifiedAt", found.VerifiedAt(), &verifiedAt)
+
+	assertErrorType(t, h.repo.MarkVerified(ctx, rival.ID(), verifiedAt), errors.ErrorTypeConflict)
+	assertErrorType(t, h.repo.MarkVerified(ctx, uuid.NewString(), verifiedAt), errors.ErrorTypeNotFound)
+
+	// Deleting the verified domain frees the hostname
+	if err := h.repo.Delete(ctx, domain.ID(), domain.UserID()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := h.repo.MarkVerified(ctx, rival.ID(), verifiedAt); err != nil {
+		t.Fatalf("MarkVerified(rival) error = %v", err)
+	}
+}
+
+func testDeleteDomain(t *testing.T, h *domainHarness) {
+	ctx := context.Background()
+	domain := h.save(h.newUser(), "go.example.com")
+
+	assertErrorType(t, h.repo.Delete(ctx, domain.ID(), h.newUser()), errors.ErrorTypeNotFound)
+	if err := h.repo.Delete(ctx, domain.ID(), domain.UserID()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	_, err := h.repo.FindByID(ctx, domain.ID())
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+	assertErrorType(t, h.repo.Delete(ctx, domain.ID(), domain.UserID()), errors.ErrorTypeNotFound)
+}
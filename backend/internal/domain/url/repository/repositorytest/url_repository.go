@@ -0,0 +1,1110 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package repositorytest provides the behaviour every URL repository implementation
+// must share, as a test suite to run against each of them.
+package repositorytest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/PraveenGongada/shortly/internal/domain/interfaces"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+)
+
+// URLRepositoryHarness creates the repository under test
+type URLRepositoryHarness struct {
+	// New returns an empty repository. It is called once per subtest.
+	New func(t *testing.T) repository.URLRepository
+	// CreateUser makes userID usable as a URL creator, and as the ID of a workspace
+	// of theirs, for stores enforcing that both exist. It may be nil.
+	CreateUser func(t *testing.T, userID string)
+}
+
+// TestURLRepository runs the URL repository contract against the harness
+func TestURLRepository(t *testing.T, harness URLRepositoryHarness) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, h *urlHarness)
+	}{
+		{"SaveAndFind", testSaveAndFind},
+		{"SaveDuplicateShortCode", testSaveDuplicateShortCode},
+		{"SaveBatch", testSaveBatch},
+		{"ExistsByShortCodes", testExistsByShortCodes},
+		{"ShortCodesPerDomain", testShortCodesPerDomain},
+		{"ExistsByDomain", testExistsByDomain},
+		{"FindMissing", testFindMissing},
+		{"FindByWorkspaceID", testFindByWorkspaceID},
+		{"FindByWorkspaceIDSorted", testFindByWorkspaceIDSorted},
+		{"FindByWorkspaceIDFiltered", testFindByWorkspaceIDFiltered},
+		{"FindAll", testFindAll},
+		{"ListTags", testListTags},
+		{"ForEachByWorkspaceID", testForEachByWorkspaceID},
+		{"Update", testUpdate},
+		{"Revisions", testRevisions},
+		{"MoveToTrash", testMoveToTrash},
+		{"Restore", testRestore},
+		{"PurgeTrash", testPurgeTrash},
+		{"Disable", testDisable},
+		{"FindEnabled", testFindEnabled},
+		{"AddRedirects", testAddRedirects},
+		{"ClaimRedirect", testClaimRedirect},
+		{"Totals", testTotals},
+		{"ConcurrentSaves", testConcurrentSaves},
+		{"ConcurrentClaims", testConcurrentClaims},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, &urlHarness{t: t, harness: harness, repo: harness.New(t)})
+		})
+	}
+}
+
+type urlHarness struct {
+	t       *testing.T
+	harness URLRepositoryHarness
+	repo    repository.URLRepository
+}
+
+// newUser returns the ID of a new user and of their workspace
+func (h *urlHarness) newUser() string {
+	userID := uuid.NewString()
+	if h.harness.CreateUser != nil {
+		h.harness.CreateUser(h.t, userID)
+	}
+	return userID
+}
+
+// newURL builds a URL with a timestamp at the precision databases store
+func newURL(userID, shortCode string, createdAt time.Time) *entity.URL {
+	return newTaggedURL(userID, shortCode, "", createdAt)
+}
+
+// newTaggedURL builds a URL with a title and tags
+func newTaggedURL(userID, shortCode, title string, createdAt time.Time, tags ...string) *entity.URL {
+	return newDomainURL(userID, "", shortCode, title, createdAt, tags...)
+}
+
+// newDomainURL builds a URL served from a custom domain, in the workspace of the user
+func newDomainURL(userID, domain, shortCode, title string, createdAt time.Time, tags ...string) *entity.URL {
+	return entity.NewURLFromRepository(entity.URLSnapshot{
+		ID:           uuid.NewString(),
+		UserID:       userID,
+		WorkspaceID:  userID,
+		Domain:       domain,
+		ShortCode:    shortCode,
+		LongURL:      "https://example.com/" + shortCode,
+		RedirectType: entity.DefaultRedirectType,
+		Title:        title,
+		Tags:         entity.NormalizeTags(tags),
+		CreatedAt:    createdAt.UTC().Truncate(time.Microsecond),
+	})
+}
+
+func (h *urlHarness) save(url *entity.URL) *entity.URL {
+	h.t.Helper()
+	saved, err := h.repo.Save(context.Background(), url)
+	if err != nil {
+		h.t.Fatalf("Save(%s) error = %v", url.ShortCode(), err)
+	}
+	return saved
+}
+
+func assertErrorType(t *testing.T, err error, want errors.ErrorType) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("error = nil, want %s", want)
+	}
+	if got := errors.GetErrorType(err); got != want {
+		t.Fatalf("error type = %s (%v), want %s", got, err, want)
+	}
+}
+
+func assertSameURL(t *testing.T, got, want *entity.URL) {
+	t.Helper()
+	if got.ID() != want.ID() || got.UserID() != want.UserID() || got.WorkspaceID() != want.WorkspaceID() ||
+		got.Domain() != want.Domain() || got.ShortCode() != want.ShortCode() || got.LongURL() != want.LongURL() ||
+		got.Redirects() != want.Redirects() {
+		t.Fatalf("URL = {%s %s %s %q %s %s %d}, want {%s %s %s %q %s %s %d}",
+			got.ID(), got.UserID(), got.WorkspaceID(), got.Domain(), got.ShortCode(), got.LongURL(), got.Redirects(),
+			want.ID(), want.UserID(), want.WorkspaceID(), want.Domain(), want.ShortCode(), want.LongURL(),
+			want.Redirects())
+	}
+	if !got.CreatedAt().Equal(want.CreatedAt()) {
+		t.Fatalf("CreatedAt = %v, want %v", got.CreatedAt(), want.CreatedAt())
+	}
+	assertTimePtr(t, "ExpiresAt", got.ExpiresAt(), want.ExpiresAt())
+	assertIntPtr(t, "MaxClicks", got.MaxClicks(), want.MaxClicks())
+	if got.PasswordHash() != want.PasswordHash() {
+		t.Fatalf("PasswordHash = %q, want %q", got.PasswordHash(), want.PasswordHash())
+	}
+	if got.RedirectType() != want.RedirectType() || got.ForwardQuery() != want.ForwardQuery() {
+		t.Fatalf("RedirectType, ForwardQuery = %d, %t, want %d, %t",
+			got.RedirectType(), got.ForwardQuery(), want.RedirectType(), want.ForwardQuery())
+	}
+	if got.Title() != want.Title() || got.Description() != want.Description() {
+		t.Fatalf("Title, Description = %q, %q, want %q, %q",
+			got.Title(), got.Description(), want.Title(), want.Description())
+	}
+	if !slices.Equal(got.Tags(), want.Tags()) {
+		t.Fatalf("Tags = %v, want %v", got.Tags(), want.Tags())
+	}
+	assertTimePtr(t, "DisabledAt", got.DisabledAt(), want.DisabledAt())
+	if got.DisabledReason() != want.DisabledReason() {
+		t.Fatalf("DisabledReason = %q, want %q", got.DisabledReason(), want.DisabledReason())
+	}
+}
+
+func assertTimePtr(t *testing.T, name string, got, want *time.Time) {
+	t.Helper()
+	if (got == nil) != (want == nil) || (got != nil && !got.Equal(*want)) {
+		t.Fatalf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func assertIntPtr(t *testing.T, name string, got, want *int) {
+	t.Helper()
+	if (got == nil) != (want == nil) || (got != nil && *got != *want) {
+		t.Fatalf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func testSaveAndFind(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	url := newURL(h.newUser(), "find123", time.Now())
+
+	saved := h.save(url)
+	assertSameURL(t, saved, url)
+	if saved.UpdatedAt() != nil {
+		t.Fatalf("UpdatedAt = %v, want nil for a new URL", saved.UpdatedAt())
+	}
+
+	byCode, err := h.repo.FindByShortCode(ctx, "", url.ShortCode())
+	if err != nil {
+		t.Fatalf("FindByShortCode() error = %v", err)
+	}
+	assertSameURL(t, byCode, url)
+
+	byID, err := h.repo.FindByID(ctx, url.ID())
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	assertSameURL(t, byID, url)
+
+	exists, err := h.repo.ExistsByShortCode(ctx, "", url.ShortCode())
+	if err != nil || !exists {
+		t.Fatalf("ExistsByShortCode(saved) = %v, %v, want true", exists, err)
+	}
+	exists, err = h.repo.ExistsByShortCode(ctx, "", "absent1")
+	if err != nil || exists {
+		t.Fatalf("ExistsByShortCode(absent) = %v, %v, want false", exists, err)
+	}
+}
+
+func testSaveDuplicateShortCode(t *testing.T, h *urlHarness) {
+	userID := h.newUser()
+	h.save(newURL(userID, "dup1234", time.Now()))
+
+	_, err := h.repo.Save(context.Background(), newURL(userID, "dup1234", time.Now()))
+	assertErrorType(t, err, errors.ErrorTypeConflict)
+}
+
+func testSaveBatch(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	userID := h.newUser()
+	h.save(newURL(userID, "bat0001", time.Now()))
+
+	first := newURL(userID, "bat0002", time.Now())
+	second := newURL(userID, "bat0003", time.Now())
+	saved, err := h.repo.SaveBatch(ctx, []*entity.URL{
+		first,
+		newURL(userID, "bat0001", time.Now()),
+		second,
+		newURL(userID, "bat0002", time.Now()),
+	})
+	if err != nil {
+		t.Fatalf("SaveBatch() error = %v", err)
+	}
+	if len(saved) != 4 {
+		t.Fatalf("SaveBatch() returned %d URLs, want 4", len(saved))
+	}
+	assertSameURL(t, saved[0], first)
+	assertSameURL(t, saved[2], second)
+	if saved[1] != nil || saved[3] != nil {
+		t.Fatalf("SaveBatch() saved URLs with taken short codes: %v, %v", saved[1], saved[3])
+	}
+
+	found, err := h.repo.FindByShortCode(ctx, "", "bat0002")
+	if err != nil {
+		t.Fatalf("FindByShortCode() error = %v", err)
+	}
+	assertSameURL(t, found, first)
+
+	if _, err := h.repo.SaveBatch(ctx, nil); err != nil {
+		t.Fatalf("SaveBatch(nil) error = %v", err)
+	}
+}
+
+func testExistsByShortCodes(t *testing.T, h *urlHarness) {
+	userID := h.newUser()
+	h.save(newURL(userID, "exi0001", time.Now()))
+	h.save(newURL(userID, "exi0002", time.Now()))
+
+	h.save(newDomainURL(userID, "go.example.com", "exi0003", "", time.Now()))
+
+	links := []repository.ShortLink{
+		{ShortCode: "exi0001"},
+		{ShortCode: "exi0002"},
+		{ShortCode: "exi0003"},
+		{Domain: "go.example.com", ShortCode: "exi0001"},
+		{Domain: "go.example.com", ShortCode: "exi0003"},
+	}
+	existing, err := h.repo.ExistsByShortCodes(context.Background(), links)
+	if err != nil {
+		t.Fatalf("ExistsByShortCodes() error = %v", err)
+	}
+	if len(existing) != 3 || !existing[links[0]] || !existing[links[1]] || !existing[links[4]] {
+		t.Fatalf("ExistsByShortCodes() = %v, want exi0001, exi0002 and go.example.com/exi0003", existing)
+	}
+}
+
+func testShortCodesPerDomain(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	userID := h.newUser()
+	onDefault := h.save(newURL(userID, "dom1234", time.Now()))
+	onCustom := h.save(newDomainURL(userID, "go.example.com", "dom1234", "", time.Now()))
+
+	_, err := h.repo.Save(ctx, newDomainURL(userID, "go.example.com", "dom1234", "", time.Now()))
+	assertErrorType(t, err, errors.ErrorTypeConflict)
+
+	for domain, want := range map[string]*entity.URL{"": onDefault, "go.example.com": onCustom} {
+		found, err := h.repo.FindByShortCode(ctx, domain, "dom1234")
+		if err != nil {
+			t.Fatalf("FindByShortCode(%q) error = %v", domain, err)
+		}
+		assertSameURL(t, found, want)
+	}
+	_, err = h.repo.FindByShortCode(ctx, "go.example.org", "dom1234")
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+	exists, err := h.repo.ExistsByShortCode(ctx, "go.example.org", "dom1234")
+	if err != nil || exists {
+		t.Fatalf("ExistsByShortCode(other domain) = %v, %v, want false", exists, err)
+	}
+}
+
+func testExistsByDomain(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	userID := h.newUser()
+	url := h.save(newDomainURL(userID, "go.example.com", "exd1234", "", time.Now()))
+
+	exists, err := h.repo.ExistsByDomain(ctx, "go.example.com")
+	if err != nil || !exists {
+		t.Fatalf("ExistsByDomain(used) = %v, %v, want true", exists, err)
+	}
+	exists, err = h.repo.ExistsByDomain(ctx, "go.example.org")
+	if err != nil || exists {
+		t.Fatalf("ExistsByDomain(unused) = %v, %v, want false", exists, err)
+	}
+
+	// Links in the trash can still be restored onto the domain
+	if err := h.repo.MoveToTrash(ctx, url.ID(), userID); err != nil {
+		t.Fatalf("MoveToTrash() error = %v", err)
+	}
+	exists, err = h.repo.ExistsByDomain(ctx, "go.example.com")
+	if err != nil || !exists {
+		t.Fatalf("ExistsByDomain(trashed) = %v, %v, want true", exists, err)
+	}
+}
+
+func testFindMissing(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+
+	_, err := h.repo.FindByShortCode(ctx, "", "missing")
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+
+	_, err = h.repo.FindByID(ctx, uuid.NewString())
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+}
+
+func testFindByWorkspaceID(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	userID := h.newUser()
+	otherUserID := h.newUser()
+	now := time.Now()
+
+	oldest := h.save(newURL(userID, "old1234", now.Add(-2*time.Hour)))
+	newest := h.save(newURL(userID, "new1234", now))
+	middle := h.save(newURL(userID, "mid1234", now.Add(-time.Hour)))
+	h.save(newURL(otherUserID, "oth1234", now.Add(-30*time.Minute)))
+
+	firstPage, err := h.repo.FindByWorkspaceID(ctx, userID, repository.URLFilter{}, repository.URLPage{Limit: 2})
+	if err != nil {
+		t.Fatalf("FindByWorkspaceID() error = %v", err)
+	}
+	assertShortCodes(t, firstPage, newest, middle)
+
+	after := repository.NewURLCursor(firstPage[1])
+	secondPage, err := h.repo.FindByWorkspaceID(ctx, userID, repository.URLFilter{},
+		repository.URLPage{Sort: repository.SortCreated, After: &after, Limit: 2})
+	if err != nil {
+		t.Fatalf("FindByWorkspaceID() error = %v", err)
+	}
+	assertShortCodes(t, secondPage, oldest)
+
+	none, err := h.repo.FindByWorkspaceID(ctx, uuid.NewString(), repository.URLFilter{}, repository.URLPage{Limit: 10})
+	if err != nil {
+		t.Fatalf("FindByWorkspaceID(unknown workspace) error = %v", err)
+	}
+	assertShortCodes(t, none)
+
+	count, err := h.repo.CountByWorkspaceID(ctx, userID, repository.URLFilter{})
+	if err != nil || count != 3 {
+		t.Fatalf("CountByWorkspaceID() = %d, %v, want 3", count, err)
+	}
+	count, err = h.repo.CountByWorkspaceID(ctx, userID, repository.URLFilter{Search: "mid"})
+	if err != nil || count != 1 {
+		t.Fatalf("CountByWorkspaceID(filtered) = %d, %v, want 1", count, err)
+	}
+}
+
+func testFindByWorkspaceIDSorted(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	userID := h.newUser()
+	now := time.Now()
+
+	// Equal creation times and redirect counts are ordered by id
+	tied := now.Add(-time.Hour)
+	first := newTaggedURL(userID, "srt0001", "Banana", tied)
+	second := newTaggedURL(userID, "srt0002", "", tied)
+	third := newTaggedURL(userID, "srt0003", "apple", now)
+	fourth := newTaggedURL(userID, "srt0004", "cherry", now.Add(-2*time.Hour))
+	for _, url := range []*entity.URL{first, second, third, fourth} {
+		h.save(url)
+	}
+	if err := h.repo.AddRedirects(ctx, map[string]int{first.ID(): 5, second.ID(): 5, fourth.ID(): 9}); err != nil {
+		t.Fatalf("AddRedirects() error = %v", err)
+	}
+	// Updating the oldest URL makes it the most recently updated
+	fourth = h.reload(fourth)
+	if err := h.repo.Update(ctx, fourth); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	byDescendingID := func(a, b *entity.URL) []*entity.URL {
+		if a.ID() > b.ID() {
+			return []*entity.URL{a, b}
+		}
+		return []*entity.URL{b, a}
+	}
+	tests := []struct {
+		sort repository.URLSort
+		want []*entity.URL
+	}{
+		{repository.SortCreated, append(append([]*entity.URL{third}, byDescendingID(first, second)...), fourth)},
+		{repository.SortUpdated, append([]*entity.URL{fourth, third}, byDescendingID(first, second)...)},
+		{repository.SortRedirects, append(append([]*entity.URL{fourth}, byDescendingID(first, second)...), third)},
+		// The short code stands in for a missing title
+		{repository.SortAlphabetical, []*entity.URL{third, first, fourth, second}},
+	}
+	for _, tt := range tests {
+		// Pages of one URL walk the whole order through the cursors
+		var got []*entity.URL
+		page := repository.URLPage{Sort: tt.sort, Limit: 1}
+		for len(got) <= len(tt.want) {
+			urls, err := h.repo.FindByWorkspaceID(ctx, userID, repository.URLFilter{}, page)
+			if err != nil {
+				t.Fatalf("FindByWorkspaceID(%s) error = %v", tt.sort, err)
+			}
+			if len(urls) == 0 {
+				break
+			}
+			got = append(got, urls...)
+			after := repository.NewURLCursor(urls[0])
+			page.After = &after
+		}
+		assertShortCodes(t, got, tt.want...)
+	}
+}
+
+func (h *urlHarness) reload(url *entity.URL) *entity.URL {
+	h.t.Helper()
+	found, err := h.repo.FindByID(context.Background(), url.ID())
+	if err != nil {
+		h.t.Fatalf("FindByID(%s) error = %v", url.ID(), err)
+	}
+	return found
+}
+
+func testFindByWorkspaceIDFiltered(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	userID := h.newUser()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	spring := h.save(newTaggedURL(userID, "spring1", "Spring Sale", now.Add(-3*time.Hour), "promo", "sale"))
+	summer := h.save(newTaggedURL(userID, "summer1", "Summer 100% off", now.Add(-2*time.Hour), "promo"))
+	docs := h.save(newTaggedURL(userID, "docs123", "", now.Add(-time.Hour), "internal"))
+	h.save(newTaggedURL(h.newUser(), "spring2", "Spring Sale", now, "promo"))
+
+	tests := []struct {
+		name   string
+		filter repository.URLFilter
+		want   []*entity.URL
+	}{
+		{"tag", repository.URLFilter{Tags: []string{"promo"}}, []*entity.URL{summer, spring}},
+		{"all tags", repository.URLFilter{Tags: []string{"promo", "sale"}}, []*entity.URL{spring}},
+		{"unknown tag", repository.URLFilter{Tags: []string{"absent"}}, nil},
+		{"search title", repository.URLFilter{Search: "sale"}, []*entity.URL{spring}},
+		{"search code", repository.URLFilter{Search: "DOCS"}, []*entity.URL{docs}},
+		{"search destination", repository.URLFilter{Search: "example.com/summer"}, []*entity.URL{summer}},
+		{"search wildcard", repository.URLFilter{Search: "100%"}, []*entity.URL{summer}},
+		{"search underscore", repository.URLFilter{Search: "_"}, nil},
+		{
+			"created range",
+			repository.URLFilter{CreatedFrom: ptr(now.Add(-2 * time.Hour)), CreatedTo: ptr(now.Add(-time.Hour))},
+			[]*entity.URL{summer},
+		},
+		{
+			"combined",
+			repository.URLFilter{Tags: []string{"promo"}, Search: "s", CreatedFrom: ptr(now.Add(-150 * time.Minute))},
+			[]*entity.URL{summer},
+		},
+	}
+	for _, tt := range tests {
+		got, err := h.repo.FindByWorkspaceID(ctx, userID, tt.filter, repository.URLPage{Limit: 10})
+		if err != nil {
+			t.Fatalf("FindByWorkspaceID(%s) error = %v", tt.name, err)
+		}
+		if len(got) > 0 {
+			assertSameURL(t, got[0], tt.want[0])
+		}
+		assertShortCodes(t, got, tt.want...)
+	}
+}
+
+func testFindAll(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	userID := h.newUser()
+	otherUserID := h.newUser()
+	now := time.Now()
+
+	oldest := h.save(newURL(userID, "all0001", now.Add(-2*time.Hour)))
+	other := h.save(newURL(otherUserID, "all0002", now.Add(-time.Hour)))
+	newest := h.save(newURL(userID, "all0003", now))
+	trashed := h.save(newURL(otherUserID, "all0004", now.Add(-30*time.Minute)))
+	if err := h.repo.MoveToTrash(ctx, trashed.ID(), otherUserID); err != nil {
+		t.Fatalf("MoveToTrash() error = %v", err)
+	}
+
+	firstPage, err := h.repo.FindAll(ctx, repository.URLFilter{}, repository.URLPage{Limit: 2})
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	assertShortCodes(t, firstPage, newest, other)
+
+	after := repository.NewURLCursor(firstPage[1])
+	secondPage, err := h.repo.FindAll(ctx, repository.URLFilter{},
+		repository.URLPage{Sort: repository.SortCreated, After: &after, Limit: 2})
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	assertShortCodes(t, secondPage, oldest)
+
+	tests := []struct {
+		name   string
+		filter repository.URLFilter
+		want   []*entity.URL
+	}{
+		{"user", repository.URLFilter{UserID: userID}, []*entity.URL{newest, oldest}},
+		{"search", repository.URLFilter{Search: "all0002"}, []*entity.URL{other}},
+		{"trash", repository.URLFilter{Deleted: true}, []*entity.URL{trashed}},
+		{"unknown user", repository.URLFilter{UserID: uuid.NewString()}, nil},
+	}
+	for _, tt := range tests {
+		got, err := h.repo.FindAll(ctx, tt.filter, repository.URLPage{Limit: 10})
+		if err != nil {
+			t.Fatalf("FindAll(%s) error = %v", tt.name, err)
+		}
+		assertShortCodes(t, got, tt.want...)
+
+		count, err := h.repo.CountAll(ctx, tt.filter)
+		if err != nil || count != len(tt.want) {
+			t.Fatalf("CountAll(%s) = %d, %v, want %d", tt.name, count, err, len(tt.want))
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func testListTags(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	userID := h.newUser()
+	h.save(newTaggedURL(userID, "tag0001", "", time.Now(), "promo", "sale"))
+	h.save(newTaggedURL(userID, "tag0002", "", time.Now(), "promo"))
+	h.save(newTaggedURL(h.newUser(), "tag0003", "", time.Now(), "other"))
+
+	tags, err := h.repo.ListTags(ctx, userID)
+	if err != nil {
+		t.Fatalf("ListTags() error = %v", err)
+	}
+	want := []entity.TagCount{{Name: "promo", URLs: 2}, {Name: "sale", URLs: 1}}
+	if !slices.Equal(tags, want) {
+		t.Fatalf("ListTags() = %v, want %v", tags, want)
+	}
+
+	none, err := h.repo.ListTags(ctx, uuid.NewString())
+	if err != nil || len(none) != 0 {
+		t.Fatalf("ListTags(unknown workspace) = %v, %v, want none", none, err)
+	}
+}
+
+func assertShortCodes(t *testing.T, got []*entity.URL, want ...*entity.URL) {
+	t.Helper()
+	gotCodes := make([]string, len(got))
+	for i, url := range got {
+		gotCodes[i] = url.ShortCode()
+	}
+	wantCodes := make([]string, len(want))
+	for i, url := range want {
+		wantCodes[i] = url.ShortCode()
+	}
+	if fmt.Sprint(gotCodes) != fmt.Sprint(wantCodes) {
+		t.Fatalf("short codes = %v, want %v", gotCodes, wantCodes)
+	}
+}
+
+func testForEachByWorkspaceID(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	userID := h.newUser()
+	now := time.Now()
+
+	newest := h.save(newURL(userID, "fen1234", now))
+	oldest := h.save(newURL(userID, "feo1234", now.Add(-2*time.Hour)))
+	middle := h.save(newURL(userID, "fem1234", now.Add(-time.Hour)))
+	h.save(newURL(h.newUser(), "fex1234", now))
+
+	var got []*entity.URL
+	err := h.repo.ForEachByWorkspaceID(ctx, userID, func(url *entity.URL) error {
+		got = append(got, url)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEachByWorkspaceID() error = %v", err)
+	}
+	assertShortCodes(t, got, oldest, middle, newest)
+
+	stop := fmt.Errorf("stop")
+	calls := 0
+	err = h.repo.ForEachByWorkspaceID(ctx, userID, func(url *entity.URL) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Fatalf("ForEachByWorkspaceID() stopping early = %v after %d calls, want %v after 1", err, calls, stop)
+	}
+}
+
+func testUpdate(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	saved := h.save(newURL(h.newUser(), "upd1234", time.Now()))
+
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Microsecond)
+	maxClicks := 10
+	snapshot := saved.Snapshot()
+	snapshot.LongURL = "https://example.org/updated"
+	snapshot.ExpiresAt = &expiresAt
+	snapshot.MaxClicks = &maxClicks
+	snapshot.PasswordHash = "$2a$12$hash"
+	snapshot.RedirectType = entity.RedirectPermanentRedirect
+	snapshot.ForwardQuery = true
+	snapshot.Title = "Updated"
+	snapshot.Description = "Notes"
+	snapshot.Tags = []string{"launch", "promo"}
+	updated := entity.NewURLFromRepository(snapshot)
+	if err := h.repo.Update(ctx, updated); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	found, err := h.repo.FindByID(ctx, saved.ID())
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	assertSameURL(t, found, updated)
+	if found.UpdatedAt() == nil {
+		t.Fatal("UpdatedAt = nil, want the time of the update")
+	}
+
+	missing := newURL(saved.UserID(), "nope123", time.Now())
+	assertErrorType(t, h.repo.Update(ctx, missing), errors.ErrorTypeNotFound)
+}
+
+// acceptingValidator accepts every destination; other validations are not used here
+type acceptingValidator struct {
+	interfaces.URLValidator
+}
+
+func (acceptingValidator) ValidateURL(string) error { return nil }
+
+func testRevisions(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	saved := h.save(newURL(h.newUser(), "rev1234", time.Now()))
+	other := h.save(newURL(saved.UserID(), "rev5678", time.Now()))
+	actorID := h.newUser()
+
+	destinations := []string{"https://example.org/1", "https://example.org/2", "https://example.org/3"}
+	for _, destination := range destinations {
+		url, err := h.repo.FindByID(ctx, saved.ID())
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if err := url.UpdateLongURL(destination, actorID, acceptingValidator{}); err != nil {
+			t.Fatalf("UpdateLongURL() error = %v", err)
+		}
+		if err := h.repo.Update(ctx, url); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+
+	// Updates keeping the destination record nothing
+	if err := h.repo.Update(ctx, h.reload(saved)); err != nil {
+		t.Fatalf("Update(unchanged) error = %v", err)
+	}
+
+	revisions, err := h.repo.FindRevisions(ctx, saved.ID(), 0, 10)
+	if err != nil {
+		t.Fatalf("FindRevisions() error = %v", err)
+	}
+	if len(revisions) != 3 {
+		t.Fatalf("FindRevisions() returned %d revisions, want 3", len(revisions))
+	}
+	wantOld := []string{destinations[1], destinations[0], saved.LongURL()}
+	for i, revision := range revisions {
+		if revision.OldURL() != wantOld[i] || revision.NewURL() != destinations[2-i] {
+			t.Errorf("revision %d = %s -> %s, want %s -> %s",
+				i, revision.OldURL(), revision.NewURL(), wantOld[i], destinations[2-i])
+		}
+		if revision.URLID() != saved.ID() || revision.ActorID() != actorID || revision.CreatedAt().IsZero() {
+			t.Errorf("revision %d = %+v, want URL %s by %s", i, revision, saved.ID(), actorID)
+		}
+		if i > 0 && revision.ID() >= revisions[i-1].ID() {
+			t.Errorf("revision IDs %d, %d are not newest first", revisions[i-1].ID(), revision.ID())
+		}
+	}
+
+	page, err := h.repo.FindRevisions(ctx, saved.ID(), revisions[0].ID(), 1)
+	if err != nil || len(page) != 1 || page[0].ID() != revisions[1].ID() {
+		t.Fatalf("FindRevisions(before newest) = %v, %v, want the second revision", page, err)
+	}
+
+	found, err := h.repo.FindRevision(ctx, saved.ID(), revisions[2].ID())
+	if err != nil || found.OldURL() != saved.LongURL() {
+		t.Fatalf("FindRevision() = %v, %v, want the first revision", found, err)
+	}
+	_, err = h.repo.FindRevision(ctx, other.ID(), revisions[2].ID())
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+	none, err := h.repo.FindRevisions(ctx, other.ID(), 0, 10)
+	if err != nil || len(none) != 0 {
+		t.Fatalf("FindRevisions(other URL) = %v, %v, want none", none, err)
+	}
+}
+
+func testMoveToTrash(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	saved := h.save(newTaggedURL(h.newUser(), "del1234", "", time.Now(), "promo"))
+
+	err := h.repo.MoveToTrash(ctx, saved.ID(), uuid.NewString())
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+	if _, err := h.repo.FindByID(ctx, saved.ID()); err != nil {
+		t.Fatalf("FindByID() after unauthorized delete error = %v", err)
+	}
+
+	if err := h.repo.MoveToTrash(ctx, saved.ID(), saved.WorkspaceID()); err != nil {
+		t.Fatalf("MoveToTrash() error = %v", err)
+	}
+	_, err = h.repo.FindByShortCode(ctx, "", saved.ShortCode())
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+	_, err = h.repo.FindByID(ctx, saved.ID())
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+	deleted, err := h.repo.FindDeletedByID(ctx, saved.ID())
+	if err != nil {
+		t.Fatalf("FindDeletedByID() error = %v", err)
+	}
+	assertSameURL(t, deleted, saved)
+	assertErrorType(t, h.repo.Update(ctx, saved), errors.ErrorTypeNotFound)
+	assertErrorType(t, h.repo.MoveToTrash(ctx, saved.ID(), saved.WorkspaceID()), errors.ErrorTypeNotFound)
+
+	// The short code stays taken while the URL is in the trash
+	exists, err := h.repo.ExistsByShortCode(ctx, "", saved.ShortCode())
+	if err != nil || !exists {
+		t.Fatalf("ExistsByShortCode(trashed) = %v, %v, want true", exists, err)
+	}
+	_, err = h.repo.Save(ctx, newURL(h.newUser(), saved.ShortCode(), time.Now()))
+	assertErrorType(t, err, errors.ErrorTypeConflict)
+
+	active, err := h.repo.FindByWorkspaceID(ctx, saved.WorkspaceID(), repository.URLFilter{}, repository.URLPage{Limit: 10})
+	if err != nil {
+		t.Fatalf("FindByWorkspaceID() error = %v", err)
+	}
+	assertShortCodes(t, active)
+	tags, err := h.repo.ListTags(ctx, saved.WorkspaceID())
+	if err != nil || len(tags) != 0 {
+		t.Fatalf("ListTags() = %v, %v, want none", tags, err)
+	}
+
+	trash := repository.URLFilter{Deleted: true}
+	trashed, err := h.repo.FindByWorkspaceID(ctx, saved.WorkspaceID(), trash, repository.URLPage{Limit: 10})
+	if err != nil {
+		t.Fatalf("FindByWorkspaceID(trash) error = %v", err)
+	}
+	assertShortCodes(t, trashed, saved)
+	if trashed[0].DeletedAt() == nil {
+		t.Fatal("DeletedAt = nil, want the time of the deletion")
+	}
+	count, err := h.repo.CountByWorkspaceID(ctx, saved.WorkspaceID(), trash)
+	if err != nil || count != 1 {
+		t.Fatalf("CountByWorkspaceID(trash) = %d, %v, want 1", count, err)
+	}
+}
+
+func testRestore(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	saved := h.save(newTaggedURL(h.newUser(), "rst1234", "", time.Now(), "promo"))
+
+	assertErrorType(t, h.repo.Restore(ctx, saved.ID(), saved.WorkspaceID()), errors.ErrorTypeNotFound)
+
+	if err := h.repo.MoveToTrash(ctx, saved.ID(), saved.WorkspaceID()); err != nil {
+		t.Fatalf("MoveToTrash() error = %v", err)
+	}
+	assertErrorType(t, h.repo.Restore(ctx, saved.ID(), uuid.NewString()), errors.ErrorTypeNotFound)
+
+	if err := h.repo.Restore(ctx, saved.ID(), saved.WorkspaceID()); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	found, err := h.repo.FindByShortCode(ctx, "", saved.ShortCode())
+	if err != nil {
+		t.Fatalf("FindByShortCode() after restore error = %v", err)
+	}
+	assertSameURL(t, found, saved)
+	if found.DeletedAt() != nil {
+		t.Fatalf("DeletedAt = %v, want nil after restore", found.DeletedAt())
+	}
+	_, err = h.repo.FindDeletedByID(ctx, saved.ID())
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+}
+
+func testPurgeTrash(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	userID := h.newUser()
+	kept := h.save(newURL(userID, "pur0001", time.Now()))
+	for _, shortCode := range []string{"pur0002", "pur0003"} {
+		url := h.save(newTaggedURL(userID, shortCode, "", time.Now(), "promo"))
+		if err := h.repo.MoveToTrash(ctx, url.ID(), userID); err != nil {
+			t.Fatalf("MoveToTrash(%s) error = %v", shortCode, err)
+		}
+	}
+
+	purged, err := h.repo.PurgeTrash(ctx, time.Now().Add(-time.Hour), 10)
+	if err != nil || purged != 0 {
+		t.Fatalf("PurgeTrash(before deletion) = %d, %v, want 0", purged, err)
+	}
+
+	cutoff := time.Now().Add(time.Second)
+	for _, want := range []int{1, 1, 0} {
+		purged, err := h.repo.PurgeTrash(ctx, cutoff, 1)
+		if err != nil || purged != want {
+			t.Fatalf("PurgeTrash() = %d, %v, want %d", purged, err, want)
+		}
+	}
+
+	existing, err := h.repo.ExistsByShortCodes(ctx, []repository.ShortLink{
+		{ShortCode: "pur0001"}, {ShortCode: "pur0002"}, {ShortCode: "pur0003"},
+	})
+	if err != nil {
+		t.Fatalf("ExistsByShortCodes() error = %v", err)
+	}
+	if len(existing) != 1 || !existing[repository.ShortLink{ShortCode: kept.ShortCode()}] {
+		t.Fatalf("ExistsByShortCodes() after purge = %v, want only %s", existing, kept.ShortCode())
+	}
+	count, err := h.repo.CountByWorkspaceID(ctx, userID, repository.URLFilter{Deleted: true})
+	if err != nil || count != 0 {
+		t.Fatalf("CountByWorkspaceID(trash) after purge = %d, %v, want 0", count, err)
+	}
+}
+
+func testDisable(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	saved := h.save(newURL(h.newUser(), "dis1234", time.Now()))
+
+	if err := h.repo.Disable(ctx, saved.ID(), "phishing"); err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+	found, err := h.repo.FindByShortCode(ctx, "", saved.ShortCode())
+	if err != nil {
+		t.Fatalf("FindByShortCode() after disable error = %v", err)
+	}
+	if !found.IsDisabled() || found.DisabledReason() != "phishing" {
+		t.Fatalf("DisabledAt, DisabledReason = %v, %q, want disabled for phishing",
+			found.DisabledAt(), found.DisabledReason())
+	}
+
+	// Disabling again keeps the original reason, and updates do not enable the URL
+	if err := h.repo.Disable(ctx, saved.ID(), "malware"); err != nil {
+		t.Fatalf("Disable(again) error = %v", err)
+	}
+	if err := h.repo.Update(ctx, saved); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	again := h.reload(saved)
+	assertTimePtr(t, "DisabledAt", again.DisabledAt(), found.DisabledAt())
+	if again.DisabledReason() != "phishing" {
+		t.Fatalf("DisabledReason = %q, want phishing", again.DisabledReason())
+	}
+
+	assertErrorType(t, h.repo.Disable(ctx, uuid.NewString(), "phishing"), errors.ErrorTypeNotFound)
+	if err := h.repo.MoveToTrash(ctx, saved.ID(), saved.WorkspaceID()); err != nil {
+		t.Fatalf("MoveToTrash() error = %v", err)
+	}
+	assertErrorType(t, h.repo.Disable(ctx, saved.ID(), "phishing"), errors.ErrorTypeNotFound)
+}
+
+func testFindEnabled(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	userID := h.newUser()
+	var enabled []*entity.URL
+	for _, shortCode := range []string{"ena0001", "ena0002", "ena0003", "ena0004", "ena0005"} {
+		enabled = append(enabled, h.save(newURL(userID, shortCode, time.Now())))
+	}
+	if err := h.repo.Disable(ctx, enabled[1].ID(), "phishing"); err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+	if err := h.repo.MoveToTrash(ctx, enabled[3].ID(), userID); err != nil {
+		t.Fatalf("MoveToTrash() error = %v", err)
+	}
+	enabled = []*entity.URL{enabled[0], enabled[2], enabled[4]}
+	slices.SortFunc(enabled, func(a, b *entity.URL) int { return strings.Compare(a.ID(), b.ID()) })
+
+	var scanned []*entity.URL
+	afterID := ""
+	for {
+		batch, err := h.repo.FindEnabled(ctx, afterID, 2)
+		if err != nil {
+			t.Fatalf("FindEnabled() error = %v", err)
+		}
+		if len(batch) > 2 {
+			t.Fatalf("FindEnabled() returned %d URLs, want at most 2", len(batch))
+		}
+		if len(batch) == 0 {
+			break
+		}
+		scanned = append(scanned, batch...)
+		afterID = batch[len(batch)-1].ID()
+	}
+	assertShortCodes(t, scanned, enabled...)
+}
+
+func testAddRedirects(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	userID := h.newUser()
+	first := h.save(newURL(userID, "red1234", time.Now()))
+	second := h.save(newURL(userID, "red5678", time.Now()))
+
+	if err := h.repo.AddRedirects(ctx, nil); err != nil {
+		t.Fatalf("AddRedirects(nil) error = %v", err)
+	}
+
+	counts := map[string]int{first.ID(): 3, second.ID(): 1, uuid.NewString(): 5}
+	if err := h.repo.AddRedirects(ctx, counts); err != nil {
+		t.Fatalf("AddRedirects() error = %v", err)
+	}
+	if err := h.repo.AddRedirects(ctx, map[string]int{first.ID(): 2}); err != nil {
+		t.Fatalf("AddRedirects() error = %v", err)
+	}
+
+	for shortCode, want := range map[string]int{first.ShortCode(): 5, second.ShortCode(): 1} {
+		url, err := h.repo.FindByShortCode(ctx, "", shortCode)
+		if err != nil {
+			t.Fatalf("FindByShortCode(%s) error = %v", shortCode, err)
+		}
+		if url.Redirects() != want {
+			t.Fatalf("Redirects(%s) = %d, want %d", shortCode, url.Redirects(), want)
+		}
+		// Following a link is not a modification of it
+		if url.UpdatedAt() != nil {
+			t.Fatalf("UpdatedAt(%s) = %v, want nil", shortCode, url.UpdatedAt())
+		}
+	}
+}
+
+// newLimitedURL builds a URL that can be followed maxClicks times
+func newLimitedURL(userID, shortCode string, maxClicks int) *entity.URL {
+	snapshot := newURL(userID, shortCode, time.Now()).Snapshot()
+	snapshot.MaxClicks = &maxClicks
+	return entity.NewURLFromRepository(snapshot)
+}
+
+func testClaimRedirect(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	userID := h.newUser()
+	unlimited := h.save(newURL(userID, "clm0001", time.Now()))
+	limited := h.save(newLimitedURL(userID, "clm0002", 2))
+	pending := h.save(newLimitedURL(userID, "clm0003", 2))
+
+	claim := func(urlID string, pending int) bool {
+		t.Helper()
+		claimed, err := h.repo.ClaimRedirect(ctx, urlID, pending)
+		if err != nil {
+			t.Fatalf("ClaimRedirect(%s) error = %v", urlID, err)
+		}
+		return claimed
+	}
+
+	for i := 0; i < 3; i++ {
+		if !claim(unlimited.ID(), 0) {
+			t.Fatalf("ClaimRedirect(unlimited) #%d = false, want true", i+1)
+		}
+	}
+	if !claim(limited.ID(), 0) || !claim(limited.ID(), 0) {
+		t.Fatalf("ClaimRedirect(limited) within the limit = false, want true")
+	}
+	if claim(limited.ID(), 0) {
+		t.Fatalf("ClaimRedirect(limited) beyond the limit = true, want false")
+	}
+	// Pending redirects count towards the limit without being added
+	if !claim(pending.ID(), 1) {
+		t.Fatalf("ClaimRedirect(pending) within the limit = false, want true")
+	}
+	if claim(pending.ID(), 1) {
+		t.Fatalf("ClaimRedirect(pending) beyond the limit = true, want false")
+	}
+	if claim(uuid.NewString(), 0) {
+		t.Fatalf("ClaimRedirect(missing) = true, want false")
+	}
+
+	for url, want := range map[*entity.URL]int{unlimited: 3, limited: 2, pending: 1} {
+		if got := h.reload(url).Redirects(); got != want {
+			t.Fatalf("Redirects(%s) = %d, want %d", url.ShortCode(), got, want)
+		}
+	}
+}
+
+func testConcurrentClaims(t *testing.T, h *urlHarness) {
+	const workers, maxClicks = 16, 5
+	url := h.save(newLimitedURL(h.newUser(), "clmrace", maxClicks))
+
+	var wg sync.WaitGroup
+	var claims atomic.Int32
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			claimed, err := h.repo.ClaimRedirect(context.Background(), url.ID(), 0)
+			if err != nil {
+				t.Errorf("ClaimRedirect() error = %v", err)
+			}
+			if claimed {
+				claims.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := claims.Load(); got != maxClicks {
+		t.Fatalf("claims = %d, want %d", got, maxClicks)
+	}
+	if got := h.reload(url).Redirects(); got != maxClicks {
+		t.Fatalf("Redirects() = %d, want %d", got, maxClicks)
+	}
+}
+
+func testTotals(t *testing.T, h *urlHarness) {
+	ctx := context.Background()
+	userID := h.newUser()
+
+	totals, err := h.repo.Totals(ctx)
+	if err != nil {
+		t.Fatalf("Totals(empty) error = %v", err)
+	}
+	if totals != (repository.URLTotals{}) {
+		t.Fatalf("Totals(empty) = %+v, want zero", totals)
+	}
+
+	active := h.save(newURL(userID, "tot0001", time.Now()))
+	disabled := h.save(newURL(userID, "tot0002", time.Now()))
+	trashed := h.save(newURL(userID, "tot0003", time.Now()))
+	if err := h.repo.AddRedirects(ctx, map[string]int{active.ID(): 4, trashed.ID(): 2}); err != nil {
+		t.Fatalf("AddRedirects() error = %v", err)
+	}
+	if err := h.repo.Disable(ctx, disabled.ID(), "phishing"); err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+	if err := h.repo.MoveToTrash(ctx, trashed.ID(), userID); err != nil {
+		t.Fatalf("MoveToTrash() error = %v", err)
+	}
+
+	totals, err = h.repo.Totals(ctx)
+	if err != nil {
+		t.Fatalf("Totals() error = %v", err)
+	}
+	want := repository.URLTotals{Active: 2, Disabled: 1, Deleted: 1, Redirects: 6}
+	if totals != want {
+		t.Fatalf("Totals() = %+v, want %+v", totals, want)
+	}
+}
+
+func testConcurrentSaves(t *testing.T, h *urlHarness) {
+	const workers = 16
+	userID := h.newUser()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	saved, conflicts := 0, 0
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := h.repo.Save(context.Background(), newURL(userID, "race123", time.Now()))
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				saved++
+			case errors.GetErrorType(err) == errors.ErrorTypeConflict:
+				conflicts++
+			default:
+				t.Errorf("Save() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if saved != 1 || conflicts != workers-1 {
+		t.Fatalf("saved = %d, conflicts = %d, want 1 and %d", saved, conflicts, workers-1)
+	}
+}
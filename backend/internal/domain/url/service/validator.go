@@ -20,20 +20,52 @@ import (
 	"errors"
 	"net/url"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/PraveenGongada/shortly/internal/domain/interfaces"
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
 )
 
 const (
 	MaxURLLength       = 2048
 	MinShortCodeLength = 4
-	MaxShortCodeLength = 12
+	MaxShortCodeLength = 32
+	MinPasswordLength  = 4
+	// MaxPasswordLength is the longest password bcrypt hashes without truncation
+	MaxPasswordLength    = 72
+	MaxTitleLength       = 200
+	MaxDescriptionLength = 2000
+	MaxTagLength         = 50
+	MaxTagsPerURL        = 20
+	MaxHostnameLength    = 253
+	maxHostnameLabel     = 63
 )
 
-type validator struct{}
+// reservedShortCodes collide with paths served by the router and can never be used as short codes
+var reservedShortCodes = map[string]struct{}{
+	"api":         {},
+	"swagger":     {},
+	"livez":       {},
+	"readyz":      {},
+	"favicon.ico": {},
+}
 
-func NewValidator() interfaces.URLValidator {
-	return &validator{}
+type validator struct {
+	// ownHosts serve short links, which URLs must not point at
+	ownHosts map[string]struct{}
+	// shortenerHosts are other URL shorteners URLs must not point at
+	shortenerHosts map[string]struct{}
+}
+
+// NewValidator creates a URL validator rejecting destinations that are short links on
+// ownHosts or that are on one of shortenerHosts or their subdomains
+func NewValidator(ownHosts, shortenerHosts []string) interfaces.URLValidator {
+	return &validator{
+		ownHosts:       hostSet(ownHosts),
+		shortenerHosts: hostSet(shortenerHosts),
+	}
 }
 
 func (v *validator) ValidateURL(longURL string) error {
@@ -66,25 +98,48 @@ func (v *validator) ValidateURL(longURL string) error {
 		return errors.New("URL must include a valid host")
 	}
 
+	// Short links pointing at short links form chains and loops
+	host := normalizeHost(parsedURL.Hostname())
+	if _, own := v.ownHosts[host]; own && v.isShortCodePath(parsedURL.Path) {
+		return errors.New("URL cannot point to a short link")
+	}
+	if matchesHost(v.shortenerHosts, host) {
+		return errors.New("URL cannot point to another URL shortener")
+	}
+
 	return nil
 }
 
+// isShortCodePath reports whether a path on a short link host is served by the redirect route
+func (v *validator) isShortCodePath(path string) bool {
+	shortCode := strings.Trim(path, "/")
+	return !strings.Contains(shortCode, "/") && v.ValidateShortCode(shortCode) == nil
+}
+
 func (v *validator) ValidateShortCode(shortCode string) error {
 	shortCode = strings.TrimSpace(shortCode)
 	if shortCode == "" {
 		return errors.New("short code cannot be empty")
 	}
+	if _, reserved := reservedShortCodes[strings.ToLower(shortCode)]; reserved {
+		return errors.New("short code is reserved")
+	}
 	if len(shortCode) < MinShortCodeLength || len(shortCode) > MaxShortCodeLength {
-		return errors.New("short code must be between 4 and 12 characters")
+		return errors.New("short code must be between 4 and 32 characters")
 	}
 
-	// Check for valid characters (alphanumeric only)
+	// Check for valid characters (alphanumeric, hyphens and underscores)
 	for _, char := range shortCode {
-		if !((char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9')) {
-			return errors.New("short code can only contain alphanumeric characters")
+		if !isAlphanumeric(char) && char != '-' && char != '_' {
+			return errors.New("short code can only contain alphanumeric characters, hyphens and underscores")
 		}
 	}
 
+	// Separators are only allowed between alphanumeric characters
+	if !isAlphanumeric(rune(shortCode[0])) || !isAlphanumeric(rune(shortCode[len(shortCode)-1])) {
+		return errors.New("short code must start and end with an alphanumeric character")
+	}
+
 	return nil
 }
 
@@ -94,3 +149,140 @@ func (v *validator) ValidateUserID(userID string) error {
 	}
 	return nil
 }
+
+func (v *validator) ValidateExpiresAt(expiresAt time.Time) error {
+	if !expiresAt.After(time.Now()) {
+		return errors.New("expiration time must be in the future")
+	}
+	return nil
+}
+
+func (v *validator) ValidateMaxClicks(maxClicks int) error {
+	if maxClicks <= 0 {
+		return errors.New("max clicks must be greater than zero")
+	}
+	return nil
+}
+
+func (v *validator) ValidateLinkPassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return errors.New("password must be at least 4 characters long")
+	}
+	if len(password) > MaxPasswordLength {
+		return errors.New("password must be at most 72 bytes long")
+	}
+	return nil
+}
+
+func (v *validator) ValidateRedirectType(redirectType int) error {
+	switch redirectType {
+	case entity.RedirectMovedPermanently, entity.RedirectFound,
+		entity.RedirectTemporaryRedirect, entity.RedirectPermanentRedirect:
+		return nil
+	}
+	return errors.New("redirect type must be one of 301, 302, 307 or 308")
+}
+
+func (v *validator) ValidateTitle(title string) error {
+	if utf8.RuneCountInString(title) > MaxTitleLength {
+		return errors.New("title cannot exceed 200 characters")
+	}
+	return nil
+}
+
+func (v *validator) ValidateDescription(description string) error {
+	if utf8.RuneCountInString(description) > MaxDescriptionLength {
+		return errors.New("description cannot exceed 2000 characters")
+	}
+	return nil
+}
+
+func (v *validator) ValidateTags(tags []string) error {
+	if len(tags) > MaxTagsPerURL {
+		return errors.New("a URL can have at most 20 tags")
+	}
+	for _, tag := range tags {
+		if tag == "" {
+			return errors.New("tags cannot be empty")
+		}
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			return errors.New("tags cannot exceed 50 characters")
+		}
+		if strings.ContainsFunc(tag, unicode.IsControl) {
+			return errors.New("tags cannot contain control characters")
+		}
+	}
+	return nil
+}
+
+// ValidateHostname checks a normalized hostname for use as a custom domain: a DNS name
+// of at least two labels that is not an IP address nor a host of this service
+func (v *validator) ValidateHostname(hostname string) error {
+	if hostname == "" {
+		return errors.New("hostname cannot be empty")
+	}
+	if len(hostname) > MaxHostnameLength {
+		return errors.New("hostname cannot exceed 253 characters")
+	}
+
+	labels := strings.Split(hostname, ".")
+	if len(labels) < 2 {
+		return errors.New("hostname must include a top-level domain")
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > maxHostnameLabel {
+			return errors.New("hostname labels must be between 1 and 63 characters")
+		}
+		for _, char := range label {
+			if !isAlphanumeric(char) && char != '-' {
+				return errors.New("hostname can only contain letters, digits, hyphens and dots")
+			}
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return errors.New("hostname labels cannot start or end with a hyphen")
+		}
+	}
+	// Top-level domains are never numeric, which also rules out IPv4 addresses
+	if strings.Trim(labels[len(labels)-1], "0123456789") == "" {
+		return errors.New("hostname cannot be an IP address")
+	}
+
+	if _, own := v.ownHosts[hostname]; own {
+		return errors.New("hostname is already used by this service")
+	}
+	return nil
+}
+
+// normalizeHost lowercases a hostname and drops a trailing dot, so that equal hosts compare equal
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+// matchesHost reports whether host or one of its parent domains is in hosts
+func matchesHost(hosts map[string]struct{}, host string) bool {
+	for host != "" {
+		if _, ok := hosts[host]; ok {
+			return true
+		}
+		_, parent, found := strings.Cut(host, ".")
+		if !found {
+			return false
+		}
+		host = parent
+	}
+	return false
+}
+
+func hostSet(hosts []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(hosts))
+	for _, host := range hosts {
+		if host = normalizeHost(strings.TrimSpace(host)); host != "" {
+			set[host] = struct{}{}
+		}
+	}
+	return set
+}
+
+func isAlphanumeric(char rune) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9')
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateURLDestinationHosts(t *testing.T) {
+	v := NewValidator([]string{"sho.rt"}, []string{"bit.ly"})
+
+	tests := []struct {
+		url   string
+		valid bool
+	}{
+		{"https://sho.rt/spring-sale", false},
+		{"https://SHO.RT./spring-sale/", false},
+		{"http://sho.rt:8080/abc123", false},
+		{"https://sho.rt/", true},
+		{"https://sho.rt/api/abc123", true},
+		{"https://sho.rt/swagger", true},
+		{"https://www.sho.rt/abc123", true},
+		{"https://bit.ly/3xYz", false},
+		{"https://m.bit.ly/3xYz", false},
+		{"https://notbit.ly/3xYz", true},
+		{"https://example.com/abc123", true},
+	}
+	for _, tt := range tests {
+		if err := v.ValidateURL(tt.url); (err == nil) != tt.valid {
+			t.Errorf("ValidateURL(%q) error = %v, want valid %v", tt.url, err, tt.valid)
+		}
+	}
+}
+
+func TestValidateHostname(t *testing.T) {
+	v := NewValidator([]string{"sho.rt"}, nil)
+
+	tests := []struct {
+		hostname string
+		valid    bool
+	}{
+		{"go.brand-a.com", true},
+		{"links.example.co.uk", true},
+		{"xn--bcher-kva.example", true},
+		{"", false},
+		{"localhost", false},
+		{"sho.rt", false},
+		{"-go.example.com", false},
+		{"go-.example.com", false},
+		{"go..example.com", false},
+		{"go_links.example.com", false},
+		{"go.example.com:8080", false},
+		{"192.168.0.1", false},
+		{strings.Repeat("a", 64) + ".example.com", false},
+	}
+	for _, tt := range tests {
+		if err := v.ValidateHostname(tt.hostname); (err == nil) != tt.valid {
+			t.Errorf("ValidateHostname(%q) error = %v, want valid %v", tt.hostname, err, tt.valid)
+		}
+	}
+}
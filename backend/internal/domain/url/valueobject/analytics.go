@@ -0,0 +1,107 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package valueobject
+
+import (
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+)
+
+// ClickRequest represents the visitor details captured when a short URL is followed
+type ClickRequest struct {
+	Referrer       string
+	UserAgent      string
+	IPAddress      string
+	AcceptLanguage string
+}
+
+// AnalyticsRequest represents the range and granularity of requested analytics
+type AnalyticsRequest struct {
+	// Domain is the custom domain the short URL is served from, empty for the default one
+	Domain   string
+	From     *time.Time
+	To       *time.Time
+	Interval string
+	Top      int
+}
+
+// ClickBucketResponse represents the click count of a single time bucket
+type ClickBucketResponse struct {
+	Start  time.Time `json:"start"`
+	Clicks int       `json:"clicks"`
+}
+
+// ClickCountResponse represents the click count for a referrer or user agent
+type ClickCountResponse struct {
+	Value  string `json:"value"`
+	Clicks int    `json:"clicks"`
+}
+
+// AnalyticsResponse represents URL analytics over a date range
+type AnalyticsResponse struct {
+	Domain         string                `json:"domain,omitempty"`
+	ShortCode      string                `json:"short_code"`
+	TotalRedirects int                   `json:"total_redirects"`
+	From           time.Time             `json:"from"`
+	To             time.Time             `json:"to"`
+	Interval       string                `json:"interval"`
+	Clicks         int                   `json:"clicks"`
+	Timeline       []ClickBucketResponse `json:"timeline"`
+	TopReferrers   []ClickCountResponse  `json:"top_referrers"`
+	TopUserAgents  []ClickCountResponse  `json:"top_user_agents"`
+}
+
+// CreateAnalyticsResponse creates an AnalyticsResponse from a URL and its click stats.
+// Buckets without clicks are included with a zero count so the timeline is continuous.
+func CreateAnalyticsResponse(
+	url *entity.URL,
+	stats *entity.ClickStats,
+	from, to time.Time,
+	interval entity.ClickInterval,
+) AnalyticsResponse {
+	counts := make(map[time.Time]int, len(stats.Timeline))
+	for _, bucket := range stats.Timeline {
+		counts[bucket.Start] = bucket.Count
+	}
+
+	timeline := make([]ClickBucketResponse, 0)
+	for start := interval.Truncate(from); start.Before(to); start = interval.Next(start) {
+		timeline = append(timeline, ClickBucketResponse{Start: start, Clicks: counts[start]})
+	}
+
+	return AnalyticsResponse{
+		Domain:         url.Domain(),
+		ShortCode:      url.ShortCode(),
+		TotalRedirects: url.Redirects(),
+		From:           from,
+		To:             to,
+		Interval:       string(interval),
+		Clicks:         stats.Total,
+		Timeline:       timeline,
+		TopReferrers:   createClickCountResponses(stats.TopReferrers),
+		TopUserAgents:  createClickCountResponses(stats.TopUserAgents),
+	}
+}
+
+func createClickCountResponses(counts []entity.ClickCount) []ClickCountResponse {
+	responses := make([]ClickCountResponse, len(counts))
+	for i, count := range counts {
+		responses[i] = ClickCountResponse{Value: count.Value, Clicks: count.Count}
+	}
+	return responses
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package valueobject
+
+import (
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+)
+
+// AddDomainRequest represents a custom domain to add
+type AddDomainRequest struct {
+	Hostname string `json:"hostname" validate:"required,max=253"`
+}
+
+// DomainVerificationRecord represents the DNS record proving control over a domain
+type DomainVerificationRecord struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// DomainResponse represents custom domain data in responses
+type DomainResponse struct {
+	ID                 string                   `json:"id"`
+	Hostname           string                   `json:"hostname"`
+	Verified           bool                     `json:"verified"`
+	VerifiedAt         *time.Time               `json:"verified_at,omitempty"`
+	VerificationRecord DomainVerificationRecord `json:"verification_record"`
+	CreatedAt          time.Time                `json:"created_at"`
+}
+
+// CreateDomainResponse creates a DomainResponse from a Domain entity
+func CreateDomainResponse(domain *entity.Domain) DomainResponse {
+	return DomainResponse{
+		ID:         domain.ID(),
+		Hostname:   domain.Hostname(),
+		Verified:   domain.IsVerified(),
+		VerifiedAt: domain.VerifiedAt(),
+		VerificationRecord: DomainVerificationRecord{
+			Type:  "TXT",
+			Name:  domain.VerificationRecordName(),
+			Value: domain.VerificationRecordValue(),
+		},
+		CreatedAt: domain.CreatedAt(),
+	}
+}
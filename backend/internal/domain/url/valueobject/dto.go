@@ -16,7 +16,11 @@
 
 package valueobject
 
-import "github.com/PraveenGongada/shortly/internal/domain/url/entity"
+import (
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+)
 
 // SuccessResponse represents a generic success response
 type SuccessResponse struct {
@@ -26,12 +30,24 @@ type SuccessResponse struct {
 
 // CreateURLRequest represents URL creation request data
 type CreateURLRequest struct {
-	LongURL string `json:"long_url" validate:"required,url"`
+	LongURL      string     `json:"long_url"                validate:"required,url"`
+	Alias        string     `json:"alias,omitempty"         validate:"omitempty,min=4,max=32"`
+	Domain       string     `json:"domain,omitempty"        validate:"omitempty,max=253"`
+	WorkspaceID  string     `json:"workspace_id,omitempty"  validate:"omitempty,max=36"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	MaxClicks    *int       `json:"max_clicks,omitempty"    validate:"omitempty,min=1"`
+	Password     string     `json:"password,omitempty"      validate:"omitempty,min=4,max=72"`
+	RedirectType int        `json:"redirect_type,omitempty" validate:"omitempty,oneof=301 302 307 308"`
+	ForwardQuery bool       `json:"forward_query,omitempty"`
+	Title        string     `json:"title,omitempty"`
+	Description  string     `json:"description,omitempty"`
+	Tags         []string   `json:"tags,omitempty"`
 }
 
 // CreateURLResponse represents URL creation response data
 type CreateURLResponse struct {
 	ID        string `json:"id"`
+	Domain    string `json:"domain,omitempty"`
 	ShortCode string `json:"short_code"`
 }
 
@@ -39,16 +55,50 @@ type CreateURLResponse struct {
 func CreateShortURLResponse(url *entity.URL) CreateURLResponse {
 	return CreateURLResponse{
 		ID:        url.ID(),
+		Domain:    url.Domain(),
 		ShortCode: url.ShortCode(),
 	}
 }
 
+// BulkCreateURLResult reports the outcome for one request of a bulk creation,
+// identified by its position in the batch
+type BulkCreateURLResult struct {
+	Index     int    `json:"index"`
+	ID        string `json:"id,omitempty"`
+	Domain    string `json:"domain,omitempty"`
+	ShortCode string `json:"short_code,omitempty"`
+	Error     string `json:"error,omitempty"`
+	ErrorType string `json:"error_type,omitempty"`
+}
+
+// BulkCreateURLResponse represents bulk URL creation response data
+type BulkCreateURLResponse struct {
+	Created int                   `json:"created"`
+	Failed  int                   `json:"failed"`
+	Results []BulkCreateURLResult `json:"results"`
+}
+
 // URLResponse represents URL data in responses
 type URLResponse struct {
-	ID        string `json:"id"`
-	ShortCode string `json:"short_code"`
-	LongURL   string `json:"long_url"`
-	Redirects int    `json:"redirects"`
+	ID                string     `json:"id"`
+	WorkspaceID       string     `json:"workspace_id"`
+	CreatedBy         string     `json:"created_by"`
+	Domain            string     `json:"domain,omitempty"`
+	ShortCode         string     `json:"short_code"`
+	LongURL           string     `json:"long_url"`
+	Redirects         int        `json:"redirects"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	MaxClicks         *int       `json:"max_clicks,omitempty"`
+	PasswordProtected bool       `json:"password_protected"`
+	RedirectType      int        `json:"redirect_type"`
+	ForwardQuery      bool       `json:"forward_query"`
+	Title             string     `json:"title,omitempty"`
+	Description       string     `json:"description,omitempty"`
+	Tags              []string   `json:"tags"`
+	CreatedAt         time.Time  `json:"created_at"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
+	DisabledAt        *time.Time `json:"disabled_at,omitempty"`
+	DisabledReason    string     `json:"disabled_reason,omitempty"`
 }
 
 // CreateGetURLsResponse creates a slice of URLResponse from URL entities
@@ -57,20 +107,192 @@ func CreateGetURLsResponse(urls []*entity.URL) []URLResponse {
 
 	for i, url := range urls {
 		urlResponse[i] = URLResponse{
-			ID:        url.ID(),
-			ShortCode: url.ShortCode(),
-			LongURL:   url.LongURL(),
-			Redirects: url.Redirects(),
+			ID:                url.ID(),
+			WorkspaceID:       url.WorkspaceID(),
+			CreatedBy:         url.UserID(),
+			Domain:            url.Domain(),
+			ShortCode:         url.ShortCode(),
+			LongURL:           url.LongURL(),
+			Redirects:         url.Redirects(),
+			ExpiresAt:         url.ExpiresAt(),
+			MaxClicks:         url.MaxClicks(),
+			PasswordProtected: url.IsPasswordProtected(),
+			RedirectType:      url.RedirectType(),
+			ForwardQuery:      url.ForwardQuery(),
+			Title:             url.Title(),
+			Description:       url.Description(),
+			Tags:              nonNilTags(url.Tags()),
+			CreatedAt:         url.CreatedAt(),
+			DeletedAt:         url.DeletedAt(),
+			DisabledAt:        url.DisabledAt(),
+			DisabledReason:    url.DisabledReason(),
 		}
 	}
 
 	return urlResponse
 }
 
-// URLUpdateRequest represents URL update request data
+// nonNilTags keeps URLs without tags from being encoded with null tags
+func nonNilTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+// URLListRequest represents a page of a URL listing. Zero filter fields match every URL.
+type URLListRequest struct {
+	// WorkspaceID is the workspace to list, empty for the personal workspace of the user
+	WorkspaceID string
+	// UserID lists only the URLs created by the user
+	UserID string
+	// Limit is the page size, zero for the default
+	Limit int
+	// Cursor is the next_cursor of the previous page, empty for the first page
+	Cursor string
+	// Sort is one of created (the default), updated, redirects or alphabetical
+	Sort string
+	// Tags lists tags a URL must all carry
+	Tags []string
+	// Search matches part of the short code, destination or title
+	Search string
+	// From and To bound the creation time; To is exclusive
+	From *time.Time
+	To   *time.Time
+	// Trash lists the URLs in the trash instead of the active ones
+	Trash bool
+}
+
+// URLListResponse represents a page of a URL listing
+type URLListResponse struct {
+	Items []URLResponse `json:"items"`
+	// NextCursor requests the following page, and is omitted on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Total counts the URLs matching the filters across all pages
+	Total int `json:"total"`
+}
+
+// DisableURLRequest represents an administrator taking down a URL
+type DisableURLRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// URLTagsRequest represents tags added to a URL
+type URLTagsRequest struct {
+	Tags []string `json:"tags" validate:"required,min=1"`
+}
+
+// URLTagsResponse represents the tags of a URL
+type URLTagsResponse struct {
+	Tags []string `json:"tags"`
+}
+
+// CreateURLTagsResponse creates a URLTagsResponse from a URL entity
+func CreateURLTagsResponse(url *entity.URL) URLTagsResponse {
+	return URLTagsResponse{Tags: nonNilTags(url.Tags())}
+}
+
+// TagResponse represents a tag of a user and the number of URLs carrying it
+type TagResponse struct {
+	Name string `json:"name"`
+	URLs int    `json:"urls"`
+}
+
+// CreateTagsResponse creates a slice of TagResponse from tag counts
+func CreateTagsResponse(tags []entity.TagCount) []TagResponse {
+	tagResponse := make([]TagResponse, len(tags))
+	for i, tag := range tags {
+		tagResponse[i] = TagResponse{Name: tag.Name, URLs: tag.URLs}
+	}
+	return tagResponse
+}
+
+// URLRecord represents a URL as it is exported and imported
+type URLRecord struct {
+	ShortCode string     `json:"short_code"`
+	LongURL   string     `json:"long_url"`
+	Redirects int        `json:"redirects"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	Domain    string     `json:"domain,omitempty"`
+}
+
+// CreateURLRecord creates a URLRecord from a URL entity
+func CreateURLRecord(url *entity.URL) URLRecord {
+	return URLRecord{
+		ShortCode: url.ShortCode(),
+		LongURL:   url.LongURL(),
+		Redirects: url.Redirects(),
+		CreatedAt: url.CreatedAt(),
+		UpdatedAt: url.UpdatedAt(),
+		Domain:    url.Domain(),
+	}
+}
+
+// URLUpdateRequest represents URL update request data. Fields left empty are not changed;
+// ClearExpiration removes any existing limits before ExpiresAt and MaxClicks are applied,
+// and ClearPassword removes the password unless a new one is set. RevertTo sets the
+// destination back to the one replaced by the revision with that ID, instead of NewURL.
 type URLUpdateRequest struct {
-	ID     string `json:"id" validate:"required"`
-	NewURL string `json:"new_url" validate:"required,url"`
+	ID              string     `json:"id"                         validate:"required"`
+	NewURL          string     `json:"new_url,omitempty"          validate:"omitempty,url"`
+	RevertTo        int64      `json:"revert_to,omitempty"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	MaxClicks       *int       `json:"max_clicks,omitempty"       validate:"omitempty,min=1"`
+	ClearExpiration bool       `json:"clear_expiration,omitempty"`
+	Password        string     `json:"password,omitempty"         validate:"omitempty,min=4,max=72"`
+	ClearPassword   bool       `json:"clear_password,omitempty"`
+	RedirectType    int        `json:"redirect_type,omitempty"    validate:"omitempty,oneof=301 302 307 308"`
+	ForwardQuery    *bool      `json:"forward_query,omitempty"`
+	Title           *string    `json:"title,omitempty"`
+	Description     *string    `json:"description,omitempty"`
+	// Tags replaces all tags of the URL; an empty list removes them
+	Tags *[]string `json:"tags,omitempty"`
+}
+
+// URLHistoryRequest represents a page of the destination changes of a URL
+type URLHistoryRequest struct {
+	Limit int
+	// Before continues the history after the revision with this ID, 0 starts with the newest
+	Before int64
+}
+
+// URLRevisionResponse represents a destination change of a URL
+type URLRevisionResponse struct {
+	ID     int64  `json:"id"`
+	OldURL string `json:"old_url"`
+	NewURL string `json:"new_url"`
+	// ActorID is the user who made the change, omitted once their account is deleted
+	ActorID   string    `json:"actor_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// URLHistoryResponse represents a page of the destination changes of a URL, newest first
+type URLHistoryResponse struct {
+	Revisions []URLRevisionResponse `json:"revisions"`
+	// NextBefore requests the following page as before, and is omitted on the last page
+	NextBefore int64 `json:"next_before,omitempty"`
+}
+
+// CreateURLRevisionsResponse creates a slice of URLRevisionResponse from revision entities
+func CreateURLRevisionsResponse(revisions []*entity.URLRevision) []URLRevisionResponse {
+	revisionResponse := make([]URLRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		revisionResponse[i] = URLRevisionResponse{
+			ID:        revision.ID(),
+			OldURL:    revision.OldURL(),
+			NewURL:    revision.NewURL(),
+			ActorID:   revision.ActorID(),
+			CreatedAt: revision.CreatedAt(),
+		}
+	}
+	return revisionResponse
+}
+
+// UnlockURLResponse carries the token that lets a visitor through a password protected URL
+type UnlockURLResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 // DeleteURLRequest represents URL deletion request data
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package valueobject
+
+import "image/color"
+
+const (
+	QRCodeFormatPNG = "png"
+	QRCodeFormatSVG = "svg"
+)
+
+// QRCodeRequest represents the requested rendering of a short URL as a QR code.
+// Empty fields take their defaults.
+type QRCodeRequest struct {
+	// Domain is the custom domain the short URL is served from, empty for the default one
+	Domain     string
+	Format     string
+	Size       int
+	Level      string
+	Margin     *int
+	Foreground string
+	Background string
+	// BaseURL is where the short URL is served from when no public URL is configured
+	BaseURL string
+}
+
+// QRCodeOptions are validated QR code rendering options
+type QRCodeOptions struct {
+	Format string
+	// Size is the width and height of the image in pixels
+	Size int
+	// Level is the error correction level: L, M, Q or H
+	Level string
+	// Margin is the width of the quiet zone around the code, in modules
+	Margin     int
+	Foreground color.NRGBA
+	Background color.NRGBA
+}
+
+// QRCodeResponse carries a rendered QR code image
+type QRCodeResponse struct {
+	ContentType string
+	Image       []byte
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package valueobject
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+)
+
+// Redirect holds what is needed to redirect a visitor, so that it can be served from
+// the cache without loading the URL
+type Redirect struct {
+	// URLID identifies the URL redirects and clicks are counted for
+	URLID        string `json:"url_id"`
+	LongURL      string `json:"long_url"`
+	RedirectType int    `json:"redirect_type"`
+	ForwardQuery bool   `json:"forward_query"`
+}
+
+// CreateRedirect creates a Redirect from a URL entity
+func CreateRedirect(url *entity.URL) Redirect {
+	return Redirect{
+		URLID:        url.ID(),
+		LongURL:      url.LongURL(),
+		RedirectType: url.RedirectType(),
+		ForwardQuery: url.ForwardQuery(),
+	}
+}
+
+// Location returns the destination for a visit with the given raw query string.
+// When the query is forwarded, parameters already present in the destination take
+// precedence: incoming parameters with the same name are dropped, and the others are
+// appended in the order they arrived. The fragment of the destination is kept.
+func (r Redirect) Location(rawQuery string) string {
+	if !r.ForwardQuery || rawQuery == "" {
+		return r.LongURL
+	}
+
+	destination, err := url.Parse(r.LongURL)
+	if err != nil {
+		return r.LongURL
+	}
+	// Malformed pairs are skipped, by ParseQuery here and below for the incoming query
+	existing, _ := url.ParseQuery(destination.RawQuery)
+
+	var forwarded []string
+	for _, pair := range strings.Split(rawQuery, "&") {
+		rawKey, rawValue, hasValue := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil || key == "" {
+			continue
+		}
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			continue
+		}
+		if _, ok := existing[key]; ok {
+			continue
+		}
+
+		param := url.QueryEscape(key)
+		if hasValue {
+			param += "=" + url.QueryEscape(value)
+		}
+		forwarded = append(forwarded, param)
+	}
+	if len(forwarded) == 0 {
+		return r.LongURL
+	}
+
+	if destination.RawQuery != "" {
+		destination.RawQuery += "&"
+	}
+	destination.RawQuery += strings.Join(forwarded, "&")
+	return destination.String()
+}
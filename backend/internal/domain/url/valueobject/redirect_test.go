@@ -0,0 +1,48 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package valueobject
+
+import "testing"
+
+func TestRedirectLocation(t *testing.T) {
+	tests := []struct {
+		name         string
+		longURL      string
+		forwardQuery bool
+		rawQuery     string
+		want         string
+	}{
+		{"NotForwarded", "https://example.com/a", false, "utm_source=x", "https://example.com/a"},
+		{"EmptyQuery", "https://example.com/a?b=1", true, "", "https://example.com/a?b=1"},
+		{"Appended", "https://example.com/a", true, "utm_source=x&ref=y", "https://example.com/a?utm_source=x&ref=y"},
+		{"DestinationWins", "https://example.com/a?utm_source=print", true, "utm_source=x&utm_medium=y",
+			"https://example.com/a?utm_source=print&utm_medium=y"},
+		{"RepeatedKept", "https://example.com/a", true, "tag=a&tag=b", "https://example.com/a?tag=a&tag=b"},
+		{"FragmentKept", "https://example.com/a?b=1#top", true, "ref=y", "https://example.com/a?b=1&ref=y#top"},
+		{"Reencoded", "https://example.com/a", true, "q=a+b&x=%2F&flag", "https://example.com/a?q=a+b&x=%2F&flag"},
+		{"MalformedSkipped", "https://example.com/a", true, "=x&bad=%zz&ok=1", "https://example.com/a?ok=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redirect := Redirect{LongURL: tt.longURL, RedirectType: 302, ForwardQuery: tt.forwardQuery}
+			if got := redirect.Location(tt.rawQuery); got != tt.want {
+				t.Fatalf("Location(%q) = %q, want %q", tt.rawQuery, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// TokenRevocationCache tracks revoked access tokens and caches the token version
+// of users, which lets all tokens issued before a version bump be rejected
+type TokenRevocationCache interface {
+	RevokeToken(ctx context.Context, tokenID string, ttl time.Duration) error
+	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
+	SetTokenVersion(ctx context.Context, userID string, version int, ttl time.Duration) error
+	// GetTokenVersion reports false when the version of the user is not cached
+	GetTokenVersion(ctx context.Context, userID string) (int, bool, error)
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// API key scopes
+const (
+	ScopeURLsRead         = "urls:read"
+	ScopeURLsWrite        = "urls:write"
+	ScopeDomainsManage    = "domains:manage"
+	ScopeWorkspacesManage = "workspaces:manage"
+
+	MaxAPIKeyNameLength = 100
+)
+
+// APIKeyScopes lists every scope an API key can be granted
+var APIKeyScopes = []string{ScopeURLsRead, ScopeURLsWrite, ScopeDomainsManage, ScopeWorkspacesManage}
+
+// APIKey represents a personal access token for programmatic API use. Only a hash
+// of the key is stored; the prefix identifies the key in lookups and listings.
+type APIKey struct {
+	id         string
+	userID     string
+	name       string
+	prefix     string
+	keyHash    string
+	scopes     []string
+	expiresAt  *time.Time
+	lastUsedAt *time.Time
+	createdAt  time.Time
+}
+
+// NewAPIKey creates a new API key with validation. Without scopes the key is
+// granted every scope.
+func NewAPIKey(
+	id, userID, name, prefix, keyHash string,
+	scopes []string,
+	expiresAt *time.Time,
+) (*APIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+	if len(name) > MaxAPIKeyNameLength {
+		return nil, errors.New("name is too long")
+	}
+
+	if len(scopes) == 0 {
+		scopes = APIKeyScopes
+	}
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !slices.Contains(APIKeyScopes, scope) {
+			return nil, errors.New("unknown scope: " + scope)
+		}
+		if !slices.Contains(normalized, scope) {
+			normalized = append(normalized, scope)
+		}
+	}
+
+	now := time.Now().UTC()
+	if expiresAt != nil {
+		if !expiresAt.After(now) {
+			return nil, errors.New("expiration time must be in the future")
+		}
+		utc := expiresAt.UTC()
+		expiresAt = &utc
+	}
+
+	return &APIKey{
+		id:        id,
+		userID:    userID,
+		name:      name,
+		prefix:    prefix,
+		keyHash:   keyHash,
+		scopes:    normalized,
+		expiresAt: expiresAt,
+		createdAt: now,
+	}, nil
+}
+
+// NewAPIKeyFromRepository creates an API key from repository data (already validated)
+func NewAPIKeyFromRepository(
+	id, userID, name, prefix, keyHash string,
+	scopes []string,
+	expiresAt, lastUsedAt *time.Time,
+	createdAt time.Time,
+) *APIKey {
+	return &APIKey{
+		id:         id,
+		userID:     userID,
+		name:       name,
+		prefix:     prefix,
+		keyHash:    keyHash,
+		scopes:     scopes,
+		expiresAt:  expiresAt,
+		lastUsedAt: lastUsedAt,
+		createdAt:  createdAt,
+	}
+}
+
+// IsExpired reports whether the key can no longer be used at the given time
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.expiresAt != nil && !now.Before(*k.expiresAt)
+}
+
+// HasScope reports whether the key was granted the scope
+func (k *APIKey) HasScope(scope string) bool {
+	return slices.Contains(k.scopes, scope)
+}
+
+// Getters
+func (k *APIKey) ID() string             { return k.id }
+func (k *APIKey) UserID() string         { return k.userID }
+func (k *APIKey) Name() string           { return k.name }
+func (k *APIKey) Prefix() string         { return k.prefix }
+func (k *APIKey) KeyHash() string        { return k.keyHash }
+func (k *APIKey) Scopes() []string       { return k.scopes }
+func (k *APIKey) ExpiresAt() *time.Time  { return k.expiresAt }
+func (k *APIKey) LastUsedAt() *time.Time { return k.lastUsedAt }
+func (k *APIKey) CreatedAt() time.Time   { return k.createdAt }
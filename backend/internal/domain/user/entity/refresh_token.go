@@ -0,0 +1,81 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+import "time"
+
+// RefreshToken represents a persisted refresh token. Only a hash of the token is
+// stored; tokens issued by rotating one another share a family ID so that reuse
+// of a rotated token can revoke the whole chain.
+type RefreshToken struct {
+	id        string
+	userID    string
+	familyID  string
+	tokenHash string
+	expiresAt time.Time
+	revokedAt *time.Time
+	createdAt time.Time
+}
+
+// NewRefreshToken creates a refresh token record for a hashed token
+func NewRefreshToken(id, userID, familyID, tokenHash string, expiresAt time.Time) *RefreshToken {
+	return &RefreshToken{
+		id:        id,
+		userID:    userID,
+		familyID:  familyID,
+		tokenHash: tokenHash,
+		expiresAt: expiresAt.UTC(),
+		createdAt: time.Now().UTC(),
+	}
+}
+
+// NewRefreshTokenFromRepository creates a refresh token from repository data
+func NewRefreshTokenFromRepository(
+	id, userID, familyID, tokenHash string,
+	expiresAt time.Time,
+	revokedAt *time.Time,
+	createdAt time.Time,
+) *RefreshToken {
+	return &RefreshToken{
+		id:        id,
+		userID:    userID,
+		familyID:  familyID,
+		tokenHash: tokenHash,
+		expiresAt: expiresAt,
+		revokedAt: revokedAt,
+		createdAt: createdAt,
+	}
+}
+
+// IsExpired reports whether the token can no longer be used at the given time
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.expiresAt)
+}
+
+// IsRevoked reports whether the token has been rotated or revoked
+func (t *RefreshToken) IsRevoked() bool {
+	return t.revokedAt != nil
+}
+
+// Getters
+func (t *RefreshToken) ID() string            { return t.id }
+func (t *RefreshToken) UserID() string        { return t.userID }
+func (t *RefreshToken) FamilyID() string      { return t.familyID }
+func (t *RefreshToken) TokenHash() string     { return t.tokenHash }
+func (t *RefreshToken) ExpiresAt() time.Time  { return t.expiresAt }
+func (t *RefreshToken) RevokedAt() *time.Time { return t.revokedAt }
+func (t *RefreshToken) CreatedAt() time.Time  { return t.createdAt }
@@ -23,14 +23,26 @@ import (
 	"github.com/PraveenGongada/shortly/internal/domain/interfaces"
 )
 
+// Roles a user can hold across the whole system, unrelated to their workspace roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// UserRoles lists every role a user can hold
+var UserRoles = []string{RoleUser, RoleAdmin}
+
 // User represents a user aggregate root
 type User struct {
-	id        string
-	email     string
-	password  string
-	name      string
-	createdAt time.Time
-	updatedAt *time.Time
+	id           string
+	email        string
+	password     string
+	name         string
+	role         string
+	tokenVersion int
+	createdAt    time.Time
+	updatedAt    *time.Time
+	suspendedAt  *time.Time
 }
 
 // NewUser creates a new user with validation
@@ -55,19 +67,28 @@ func NewUser(id, email, password, name string, validator interfaces.UserValidato
 		email:     strings.ToLower(strings.TrimSpace(email)),
 		password:  hashedPassword,
 		name:      strings.TrimSpace(name),
+		role:      RoleUser,
 		createdAt: time.Now().UTC(),
 	}, nil
 }
 
 // NewUserFromRepository creates user from repository data (already validated)
-func NewUserFromRepository(id, email, password, name string, createdAt time.Time, updatedAt *time.Time) *User {
+func NewUserFromRepository(
+	id, email, password, name, role string,
+	tokenVersion int,
+	createdAt time.Time,
+	updatedAt, suspendedAt *time.Time,
+) *User {
 	return &User{
-		id:        id,
-		email:     email,
-		password:  password,
-		name:      name,
-		createdAt: createdAt,
-		updatedAt: updatedAt,
+		id:           id,
+		email:        email,
+		password:     password,
+		name:         name,
+		role:         role,
+		tokenVersion: tokenVersion,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+		suspendedAt:  suspendedAt,
 	}
 }
 
@@ -112,12 +133,26 @@ func (u *User) ChangePassword(newPassword string, validator interfaces.UserValid
 	return nil
 }
 
+// IsAdmin reports whether the user administers the whole system
+func (u *User) IsAdmin() bool {
+	return u.role == RoleAdmin
+}
+
+// IsSuspended reports whether an administrator suspended the user, which keeps them
+// from signing in and using API keys
+func (u *User) IsSuspended() bool {
+	return u.suspendedAt != nil
+}
+
 // Getters
-func (u *User) ID() string            { return u.id }
-func (u *User) Email() string         { return u.email }
-func (u *User) Name() string          { return u.name }
-func (u *User) CreatedAt() time.Time  { return u.createdAt }
-func (u *User) UpdatedAt() *time.Time { return u.updatedAt }
+func (u *User) ID() string              { return u.id }
+func (u *User) Email() string           { return u.email }
+func (u *User) Name() string            { return u.name }
+func (u *User) Role() string            { return u.role }
+func (u *User) TokenVersion() int       { return u.tokenVersion }
+func (u *User) CreatedAt() time.Time    { return u.createdAt }
+func (u *User) UpdatedAt() *time.Time   { return u.updatedAt }
+func (u *User) SuspendedAt() *time.Time { return u.suspendedAt }
 
 // HashedPassword returns the hashed password for persistence
 func (u *User) HashedPassword() string { return u.password }
@@ -18,14 +18,65 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/PraveenGongada/shortly/internal/domain/user/entity"
 )
 
+// UserFilter narrows down the users listed. Zero fields match every user.
+type UserFilter struct {
+	// Search matches part of the email or name, in any case
+	Search string
+	// Role lists only the users holding the role
+	Role string
+	// Suspended lists only the suspended users
+	Suspended bool
+}
+
+// UserCursor is the position of a user in a listing, which lists the newest users first
+type UserCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
 // UserRepository defines persistence operations for users
 type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
 	FindByID(ctx context.Context, id string) (*entity.User, error)
 	Save(ctx context.Context, user *entity.User) (*entity.User, error)
+	Update(ctx context.Context, user *entity.User) (*entity.User, error)
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	// IncrementTokenVersion invalidates all tokens issued to the user so far and
+	// returns the new version
+	IncrementTokenVersion(ctx context.Context, id string) (int, error)
+	// FindAll returns up to limit users matching the filter, newest first, starting
+	// after the cursor or with the newest when after is nil
+	FindAll(ctx context.Context, filter UserFilter, after *UserCursor, limit int) ([]*entity.User, error)
+	CountAll(ctx context.Context, filter UserFilter) (int, error)
+	// Suspend marks the user suspended and, like IncrementTokenVersion, invalidates all
+	// tokens issued to them so far. A user already suspended keeps the time they were
+	// first suspended at.
+	Suspend(ctx context.Context, id string, suspendedAt time.Time) (int, error)
+	// Unsuspend lifts the suspension of the user, if any
+	Unsuspend(ctx context.Context, id string) error
+}
+
+// RefreshTokenRepository defines persistence operations for refresh tokens
+type RefreshTokenRepository interface {
+	Save(ctx context.Context, token *entity.RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	// Revoke marks a token as used and reports whether it was still active,
+	// so that concurrent rotations of the same token can be detected
+	Revoke(ctx context.Context, id string) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeByUserID(ctx context.Context, userID string) error
+}
+
+// APIKeyRepository defines persistence operations for API keys
+type APIKeyRepository interface {
+	Save(ctx context.Context, key *entity.APIKey) error
+	FindByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error)
+	FindByUserID(ctx context.Context, userID string) ([]*entity.APIKey, error)
+	Delete(ctx context.Context, id, userID string) error
+	UpdateLastUsed(ctx context.Context, id string, lastUsedAt time.Time) error
 }
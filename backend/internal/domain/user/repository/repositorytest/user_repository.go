@@ -0,0 +1,404 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package repositorytest provides the behaviour every user repository implementation
+// must share, as a test suite to run against each of them.
+package repositorytest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/user/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/user/repository"
+)
+
+// TestUserRepository runs the user repository contract against repositories returned
+// by newRepository, which must be empty and is called once per subtest
+func TestUserRepository(t *testing.T, newRepository func(t *testing.T) repository.UserRepository) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo repository.UserRepository)
+	}{
+		{"SaveAndFind", testSaveAndFind},
+		{"SaveDuplicateEmail", testSaveDuplicateEmail},
+		{"FindMissing", testFindMissing},
+		{"Update", testUpdate},
+		{"UpdateEmailConflict", testUpdateEmailConflict},
+		{"IncrementTokenVersion", testIncrementTokenVersion},
+		{"ConcurrentTokenVersionIncrements", testConcurrentTokenVersionIncrements},
+		{"FindAll", testFindAll},
+		{"Suspend", testSuspend},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepository(t))
+		})
+	}
+}
+
+// newUser builds a user with a timestamp at the precision databases store
+func newUser(email string) *entity.User {
+	return entity.NewUserFromRepository(
+		uuid.NewString(),
+		email,
+		"hashed-password",
+		"Test User",
+		entity.RoleUser,
+		0,
+		time.Now().UTC().Truncate(time.Microsecond),
+		nil,
+		nil,
+	)
+}
+
+func save(t *testing.T, repo repository.UserRepository, user *entity.User) *entity.User {
+	t.Helper()
+	saved, err := repo.Save(context.Background(), user)
+	if err != nil {
+		t.Fatalf("Save(%s) error = %v", user.Email(), err)
+	}
+	return saved
+}
+
+func assertErrorType(t *testing.T, err error, want errors.ErrorType) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("error = nil, want %s", want)
+	}
+	if got := errors.GetErrorType(err); got != want {
+		t.Fatalf("error type = %s (%v), want %s", got, err, want)
+	}
+}
+
+func assertSameUser(t *testing.T, got, want *entity.User) {
+	t.Helper()
+	if got.ID() != want.ID() || got.Email() != want.Email() || got.Name() != want.Name() ||
+		got.HashedPassword() != want.HashedPassword() || got.Role() != want.Role() ||
+		got.TokenVersion() != want.TokenVersion() {
+		t.Fatalf("user = {%s %s %s %s %s %d}, want {%s %s %s %s %s %d}",
+			got.ID(), got.Email(), got.Name(), got.HashedPassword(), got.Role(), got.TokenVersion(),
+			want.ID(), want.Email(), want.Name(), want.HashedPassword(), want.Role(), want.TokenVersion())
+	}
+	if !got.CreatedAt().Equal(want.CreatedAt()) {
+		t.Fatalf("CreatedAt = %v, want %v", got.CreatedAt(), want.CreatedAt())
+	}
+}
+
+func testSaveAndFind(t *testing.T, repo repository.UserRepository) {
+	ctx := context.Background()
+	user := newUser("find@example.com")
+
+	saved := save(t, repo, user)
+	assertSameUser(t, saved, user)
+	if saved.UpdatedAt() != nil {
+		t.Fatalf("UpdatedAt = %v, want nil for a new user", saved.UpdatedAt())
+	}
+
+	byEmail, err := repo.FindByEmail(ctx, user.Email())
+	if err != nil {
+		t.Fatalf("FindByEmail() error = %v", err)
+	}
+	assertSameUser(t, byEmail, user)
+
+	byID, err := repo.FindByID(ctx, user.ID())
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	assertSameUser(t, byID, user)
+
+	exists, err := repo.ExistsByEmail(ctx, user.Email())
+	if err != nil || !exists {
+		t.Fatalf("ExistsByEmail(saved) = %v, %v, want true", exists, err)
+	}
+	exists, err = repo.ExistsByEmail(ctx, "absent@example.com")
+	if err != nil || exists {
+		t.Fatalf("ExistsByEmail(absent) = %v, %v, want false", exists, err)
+	}
+}
+
+func testSaveDuplicateEmail(t *testing.T, repo repository.UserRepository) {
+	save(t, repo, newUser("dup@example.com"))
+
+	_, err := repo.Save(context.Background(), newUser("dup@example.com"))
+	assertErrorType(t, err, errors.ErrorTypeConflict)
+}
+
+func testFindMissing(t *testing.T, repo repository.UserRepository) {
+	ctx := context.Background()
+
+	_, err := repo.FindByEmail(ctx, "missing@example.com")
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+
+	_, err = repo.FindByID(ctx, uuid.NewString())
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+}
+
+func testUpdate(t *testing.T, repo repository.UserRepository) {
+	ctx := context.Background()
+	saved := save(t, repo, newUser("before@example.com"))
+
+	updatedAt := time.Now().UTC().Truncate(time.Microsecond)
+	changed := entity.NewUserFromRepository(
+		saved.ID(), "after@example.com", "new-hash", "Renamed", saved.Role(), saved.TokenVersion(),
+		saved.CreatedAt(), &updatedAt, nil,
+	)
+	updated, err := repo.Update(ctx, changed)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	assertSameUser(t, updated, changed)
+	if updated.UpdatedAt() == nil || !updated.UpdatedAt().Equal(updatedAt) {
+		t.Fatalf("UpdatedAt = %v, want %v", updated.UpdatedAt(), updatedAt)
+	}
+
+	found, err := repo.FindByEmail(ctx, "after@example.com")
+	if err != nil {
+		t.Fatalf("FindByEmail(new email) error = %v", err)
+	}
+	assertSameUser(t, found, changed)
+
+	_, err = repo.FindByEmail(ctx, "before@example.com")
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+
+	_, err = repo.Update(ctx, newUser("nobody@example.com"))
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+}
+
+func testUpdateEmailConflict(t *testing.T, repo repository.UserRepository) {
+	ctx := context.Background()
+	save(t, repo, newUser("taken@example.com"))
+	user := save(t, repo, newUser("mine@example.com"))
+
+	changed := entity.NewUserFromRepository(
+		user.ID(), "taken@example.com", user.HashedPassword(), user.Name(), user.Role(), user.TokenVersion(),
+		user.CreatedAt(), nil, nil,
+	)
+	_, err := repo.Update(ctx, changed)
+	assertErrorType(t, err, errors.ErrorTypeConflict)
+
+	found, err := repo.FindByID(ctx, user.ID())
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	assertSameUser(t, found, user)
+}
+
+func testIncrementTokenVersion(t *testing.T, repo repository.UserRepository) {
+	ctx := context.Background()
+	user := save(t, repo, newUser("version@example.com"))
+
+	for want := 1; want <= 2; want++ {
+		version, err := repo.IncrementTokenVersion(ctx, user.ID())
+		if err != nil {
+			t.Fatalf("IncrementTokenVersion() error = %v", err)
+		}
+		if version != want {
+			t.Fatalf("IncrementTokenVersion() = %d, want %d", version, want)
+		}
+	}
+
+	found, err := repo.FindByID(ctx, user.ID())
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.TokenVersion() != 2 {
+		t.Fatalf("TokenVersion = %d, want 2", found.TokenVersion())
+	}
+
+	_, err = repo.IncrementTokenVersion(ctx, uuid.NewString())
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+}
+
+func testConcurrentTokenVersionIncrements(t *testing.T, repo repository.UserRepository) {
+	const workers = 16
+	user := save(t, repo, newUser("concurrent@example.com"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := repo.IncrementTokenVersion(context.Background(), user.ID()); err != nil {
+				t.Errorf("IncrementTokenVersion() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	found, err := repo.FindByID(context.Background(), user.ID())
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.TokenVersion() != workers {
+		t.Fatalf("TokenVersion = %d, want %d", found.TokenVersion(), workers)
+	}
+}
+
+func testFindAll(t *testing.T, repo repository.UserRepository) {
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Microsecond)
+
+	// Saved oldest first, with distinct creation times so the order is known
+	var users []*entity.User
+	for i, email := range []string{"ann@example.com", "bob@example.com", "carol@shortly.dev"} {
+		user := entity.NewUserFromRepository(
+			uuid.NewString(), email, "hashed-password", "User", entity.RoleUser, 0,
+			base.Add(time.Duration(i)*time.Second), nil, nil,
+		)
+		users = append(users, save(t, repo, user))
+	}
+	admin := entity.NewUserFromRepository(
+		uuid.NewString(), "root@shortly.dev", "hashed-password", "Root", entity.RoleAdmin, 0,
+		base.Add(-time.Second), nil, nil,
+	)
+	save(t, repo, admin)
+	if _, err := repo.Suspend(ctx, users[1].ID(), base); err != nil {
+		t.Fatalf("Suspend() error = %v", err)
+	}
+
+	assertEmails := func(t *testing.T, got []*entity.User, want ...string) {
+		t.Helper()
+		emails := make([]string, len(got))
+		for i, user := range got {
+			emails[i] = user.Email()
+		}
+		if len(emails) != len(want) {
+			t.Fatalf("users = %v, want %v", emails, want)
+		}
+		for i := range want {
+			if emails[i] != want[i] {
+				t.Fatalf("users = %v, want %v", emails, want)
+			}
+		}
+	}
+
+	all, err := repo.FindAll(ctx, repository.UserFilter{}, nil, 10)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	assertEmails(t, all, "carol@shortly.dev", "bob@example.com", "ann@example.com", "root@shortly.dev")
+
+	first, err := repo.FindAll(ctx, repository.UserFilter{}, nil, 2)
+	if err != nil {
+		t.Fatalf("FindAll(first page) error = %v", err)
+	}
+	assertEmails(t, first, "carol@shortly.dev", "bob@example.com")
+	last := first[len(first)-1]
+	next, err := repo.FindAll(ctx, repository.UserFilter{}, &repository.UserCursor{
+		CreatedAt: last.CreatedAt(),
+		ID:        last.ID(),
+	}, 2)
+	if err != nil {
+		t.Fatalf("FindAll(next page) error = %v", err)
+	}
+	assertEmails(t, next, "ann@example.com", "root@shortly.dev")
+
+	filters := []struct {
+		name   string
+		filter repository.UserFilter
+		want   []string
+	}{
+		{"Search", repository.UserFilter{Search: "SHORTLY"}, []string{"carol@shortly.dev", "root@shortly.dev"}},
+		{"SearchName", repository.UserFilter{Search: "roo"}, []string{"root@shortly.dev"}},
+		{"SearchWildcard", repository.UserFilter{Search: "%"}, nil},
+		{"Role", repository.UserFilter{Role: entity.RoleAdmin}, []string{"root@shortly.dev"}},
+		{"Suspended", repository.UserFilter{Suspended: true}, []string{"bob@example.com"}},
+	}
+	for _, tt := range filters {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := repo.FindAll(ctx, tt.filter, nil, 10)
+			if err != nil {
+				t.Fatalf("FindAll() error = %v", err)
+			}
+			assertEmails(t, found, tt.want...)
+
+			count, err := repo.CountAll(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("CountAll() error = %v", err)
+			}
+			if count != len(tt.want) {
+				t.Fatalf("CountAll() = %d, want %d", count, len(tt.want))
+			}
+		})
+	}
+}
+
+func testSuspend(t *testing.T, repo repository.UserRepository) {
+	ctx := context.Background()
+	user := save(t, repo, newUser("suspend@example.com"))
+	suspendedAt := time.Now().UTC().Truncate(time.Microsecond)
+
+	version, err := repo.Suspend(ctx, user.ID(), suspendedAt)
+	if err != nil {
+		t.Fatalf("Suspend() error = %v", err)
+	}
+	if version != 1 {
+		t.Fatalf("Suspend() = %d, want token version 1", version)
+	}
+
+	// Suspending again keeps the original time but still invalidates tokens
+	version, err = repo.Suspend(ctx, user.ID(), suspendedAt.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Suspend(again) error = %v", err)
+	}
+	if version != 2 {
+		t.Fatalf("Suspend(again) = %d, want token version 2", version)
+	}
+
+	found, err := repo.FindByID(ctx, user.ID())
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if !found.IsSuspended() || !found.SuspendedAt().Equal(suspendedAt) {
+		t.Fatalf("SuspendedAt = %v, want %v", found.SuspendedAt(), suspendedAt)
+	}
+
+	// Profile updates leave the suspension alone
+	updatedAt := time.Now().UTC().Truncate(time.Microsecond)
+	changed := entity.NewUserFromRepository(
+		found.ID(), found.Email(), found.HashedPassword(), "Renamed", found.Role(), found.TokenVersion(),
+		found.CreatedAt(), &updatedAt, nil,
+	)
+	updated, err := repo.Update(ctx, changed)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !updated.IsSuspended() {
+		t.Fatal("Update() lifted the suspension")
+	}
+
+	if err := repo.Unsuspend(ctx, user.ID()); err != nil {
+		t.Fatalf("Unsuspend() error = %v", err)
+	}
+	found, err = repo.FindByID(ctx, user.ID())
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.IsSuspended() {
+		t.Fatalf("SuspendedAt = %v, want nil after Unsuspend", found.SuspendedAt())
+	}
+
+	_, err = repo.Suspend(ctx, uuid.NewString(), suspendedAt)
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+	assertErrorType(t, repo.Unsuspend(ctx, uuid.NewString()), errors.ErrorTypeNotFound)
+}
@@ -25,6 +25,11 @@ import (
 // UserService defines use cases for user management
 type UserService interface {
 	Login(ctx context.Context, req *valueobject.LoginRequest) (*valueobject.TokenResponse, error)
-	Logout(ctx context.Context) error
+	Logout(ctx context.Context, req *valueobject.LogoutRequest) error
+	LogoutEverywhere(ctx context.Context, userID string) error
 	Register(ctx context.Context, req *valueobject.RegisterRequest) (*valueobject.TokenResponse, error)
+	Refresh(ctx context.Context, req *valueobject.RefreshRequest) (*valueobject.TokenResponse, error)
+	GetProfile(ctx context.Context, userID string) (*valueobject.UserResponse, error)
+	UpdateProfile(ctx context.Context, userID string, req *valueobject.UpdateProfileRequest) (*valueobject.UserResponse, error)
+	ChangePassword(ctx context.Context, userID string, req *valueobject.ChangePasswordRequest) (*valueobject.TokenResponse, error)
 }
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package valueobject
+
+import (
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/user/entity"
+)
+
+// CreateAPIKeyRequest represents a request to create an API key
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	Scopes    []string   `json:"scopes,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKeyResponse represents API key data in responses. The key itself is never included.
+type APIKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse represents a newly created API key, the only response
+// carrying the full key
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// APIKeyPrincipal represents the user and scopes an authenticated API key acts with
+type APIKeyPrincipal struct {
+	UserID string
+	Scopes []string
+}
+
+// CreateAPIKeyResponseFromEntity creates an APIKeyResponse from an APIKey entity
+func CreateAPIKeyResponseFromEntity(key *entity.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         key.ID(),
+		Name:       key.Name(),
+		Prefix:     key.Prefix(),
+		Scopes:     key.Scopes(),
+		ExpiresAt:  key.ExpiresAt(),
+		LastUsedAt: key.LastUsedAt(),
+		CreatedAt:  key.CreatedAt(),
+	}
+}
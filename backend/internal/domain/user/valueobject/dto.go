@@ -16,7 +16,11 @@
 
 package valueobject
 
-import "github.com/PraveenGongada/shortly/internal/domain/user/entity"
+import (
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/user/entity"
+)
 
 // Token represents an authentication token
 type Token struct {
@@ -37,17 +41,52 @@ type RegisterRequest struct {
 	Name     string `json:"name" validate:"required,min=1,max=100"`
 }
 
+// UpdateProfileRequest represents a partial update of the user's profile
+type UpdateProfileRequest struct {
+	Name  *string `json:"name,omitempty"  validate:"omitempty,min=1,max=100"`
+	Email *string `json:"email,omitempty" validate:"omitempty,email"`
+}
+
+// ChangePasswordRequest represents a request to change the user's password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password"     validate:"required,min=8"`
+}
+
 // UserResponse represents user data in responses
 type UserResponse struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`
 	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// RefreshRequest represents a request to rotate a refresh token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// LogoutRequest represents the tokens of the session being logged out
+type LogoutRequest struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+// AccessTokenClaims represents the claims of a verified access token
+type AccessTokenClaims struct {
+	UserID       string
+	TokenID      string
+	TokenVersion int
+	// Role is the system-wide role of the user when the token was issued
+	Role      string
+	ExpiresAt time.Time
 }
 
 // TokenResponse represents authentication response
 type TokenResponse struct {
-	Type  string `json:"type"`
-	Token string `json:"token"`
+	Type         string `json:"type"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 // CreateUserResponse creates a UserResponse from a User entity
@@ -56,5 +95,6 @@ func CreateUserResponse(user *entity.User) UserResponse {
 		ID:    user.ID(),
 		Name:  user.Name(),
 		Email: user.Email(),
+		Role:  user.Role(),
 	}
 }
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/interfaces"
+)
+
+// Invitation offers a role in a workspace to whoever registers or signs in with its
+// email address. Only a hash of the invitation token is stored; the token itself is
+// handed to the invitee.
+type Invitation struct {
+	id          string
+	workspaceID string
+	email       string
+	role        Role
+	tokenHash   string
+	// invitedBy is the member who sent the invitation
+	invitedBy string
+	expiresAt time.Time
+	createdAt time.Time
+}
+
+// NewInvitation creates an invitation with validation
+func NewInvitation(
+	id, workspaceID, email string,
+	role Role,
+	tokenHash, invitedBy string,
+	expiresAt time.Time,
+	validator interfaces.UserValidator,
+) (*Invitation, error) {
+	if err := validator.ValidateEmail(email); err != nil {
+		return nil, err
+	}
+	if !role.IsValid() {
+		return nil, errors.New("unknown role: " + string(role))
+	}
+
+	return &Invitation{
+		id:          id,
+		workspaceID: workspaceID,
+		email:       strings.ToLower(strings.TrimSpace(email)),
+		role:        role,
+		tokenHash:   tokenHash,
+		invitedBy:   invitedBy,
+		expiresAt:   expiresAt.UTC(),
+		createdAt:   time.Now().UTC(),
+	}, nil
+}
+
+// NewInvitationFromRepository creates an invitation from repository data (already validated)
+func NewInvitationFromRepository(
+	id, workspaceID, email string,
+	role Role,
+	tokenHash, invitedBy string,
+	expiresAt, createdAt time.Time,
+) *Invitation {
+	return &Invitation{
+		id:          id,
+		workspaceID: workspaceID,
+		email:       email,
+		role:        role,
+		tokenHash:   tokenHash,
+		invitedBy:   invitedBy,
+		expiresAt:   expiresAt,
+		createdAt:   createdAt,
+	}
+}
+
+// IsExpired reports whether the invitation can no longer be accepted at the given time
+func (i *Invitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.expiresAt)
+}
+
+// IsFor checks if the invitation was sent to the email address, in any case
+func (i *Invitation) IsFor(email string) bool {
+	return strings.EqualFold(i.email, strings.TrimSpace(email))
+}
+
+// Getters
+func (i *Invitation) ID() string           { return i.id }
+func (i *Invitation) WorkspaceID() string  { return i.workspaceID }
+func (i *Invitation) Email() string        { return i.email }
+func (i *Invitation) Role() Role           { return i.role }
+func (i *Invitation) TokenHash() string    { return i.tokenHash }
+func (i *Invitation) InvitedBy() string    { return i.invitedBy }
+func (i *Invitation) ExpiresAt() time.Time { return i.expiresAt }
+func (i *Invitation) CreatedAt() time.Time { return i.createdAt }
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// Member is a user taking part in a workspace with a role
+type Member struct {
+	workspaceID string
+	userID      string
+	// email is the address of the user, loaded with the member for listings
+	email     string
+	role      Role
+	createdAt time.Time
+}
+
+// NewMember creates a member with validation
+func NewMember(workspaceID, userID string, role Role) (*Member, error) {
+	if !role.IsValid() {
+		return nil, errors.New("unknown role: " + string(role))
+	}
+
+	return &Member{
+		workspaceID: workspaceID,
+		userID:      userID,
+		role:        role,
+		createdAt:   time.Now().UTC(),
+	}, nil
+}
+
+// NewMemberFromRepository creates a member from repository data (already validated)
+func NewMemberFromRepository(workspaceID, userID, email string, role Role, createdAt time.Time) *Member {
+	return &Member{
+		workspaceID: workspaceID,
+		userID:      userID,
+		email:       email,
+		role:        role,
+		createdAt:   createdAt,
+	}
+}
+
+// Can reports whether the role of the member grants at least the required one
+func (m *Member) Can(required Role) bool {
+	return m.role.Includes(required)
+}
+
+// Getters
+func (m *Member) WorkspaceID() string  { return m.workspaceID }
+func (m *Member) UserID() string       { return m.userID }
+func (m *Member) Email() string        { return m.email }
+func (m *Member) Role() Role           { return m.role }
+func (m *Member) CreatedAt() time.Time { return m.createdAt }
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entity
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	MaxWorkspaceNameLength = 100
+
+	// PersonalWorkspaceName is the name of the workspace every user gets on registration
+	PersonalWorkspaceName = "Personal"
+)
+
+// Role is what a member is allowed to do in a workspace. Each role includes the
+// permissions of the roles below it.
+type Role string
+
+const (
+	// RoleViewer lists the links of the workspace and views their analytics
+	RoleViewer Role = "viewer"
+	// RoleEditor also creates, changes and deletes links
+	RoleEditor Role = "editor"
+	// RoleAdmin also invites members and manages those who are not owners
+	RoleAdmin Role = "admin"
+	// RoleOwner also manages owners
+	RoleOwner Role = "owner"
+)
+
+// Roles lists every role, from the least to the most privileged
+var Roles = []Role{RoleViewer, RoleEditor, RoleAdmin, RoleOwner}
+
+// IsValid checks if the role is one of Roles
+func (r Role) IsValid() bool {
+	return slices.Contains(Roles, r)
+}
+
+// Includes reports whether the role grants at least the permissions of the required one
+func (r Role) Includes(required Role) bool {
+	return r.IsValid() && slices.Index(Roles, r) >= slices.Index(Roles, required)
+}
+
+// Workspace groups the links managed together by its members. Every user has a
+// personal workspace only they are a member of; other workspaces are shared by
+// inviting members.
+type Workspace struct {
+	id   string
+	name string
+	// personalUserID is the user the workspace is personal to, empty for shared workspaces
+	personalUserID string
+	createdAt      time.Time
+}
+
+// NewWorkspace creates a shared workspace with validation
+func NewWorkspace(id, name string) (*Workspace, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+	if len(name) > MaxWorkspaceNameLength {
+		return nil, errors.New("name is too long")
+	}
+
+	return &Workspace{
+		id:        id,
+		name:      name,
+		createdAt: time.Now().UTC(),
+	}, nil
+}
+
+// NewPersonalWorkspace creates the personal workspace of a user
+func NewPersonalWorkspace(id, userID string) *Workspace {
+	return &Workspace{
+		id:             id,
+		name:           PersonalWorkspaceName,
+		personalUserID: userID,
+		createdAt:      time.Now().UTC(),
+	}
+}
+
+// NewWorkspaceFromRepository creates a workspace from repository data (already validated)
+func NewWorkspaceFromRepository(id, name, personalUserID string, createdAt time.Time) *Workspace {
+	return &Workspace{
+		id:             id,
+		name:           name,
+		personalUserID: personalUserID,
+		createdAt:      createdAt,
+	}
+}
+
+// IsPersonal checks if the workspace is the personal workspace of a user, which
+// cannot be shared
+func (w *Workspace) IsPersonal() bool {
+	return w.personalUserID != ""
+}
+
+// Getters
+func (w *Workspace) ID() string             { return w.id }
+func (w *Workspace) Name() string           { return w.name }
+func (w *Workspace) PersonalUserID() string { return w.personalUserID }
+func (w *Workspace) CreatedAt() time.Time   { return w.createdAt }
+
+// Membership is a workspace along with the role of a member in it
+type Membership struct {
+	Workspace *Workspace
+	Role      Role
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"context"
+
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/entity"
+)
+
+// WorkspaceRepository defines persistence operations for workspaces, their members and
+// the invitations to join them. A user has a single personal workspace, and an email
+// address a single pending invitation per workspace.
+type WorkspaceRepository interface {
+	// Save creates a workspace together with its first member
+	Save(ctx context.Context, workspace *entity.Workspace, owner *entity.Member) error
+	FindByID(ctx context.Context, id string) (*entity.Workspace, error)
+	// FindPersonal returns the personal workspace of the user
+	FindPersonal(ctx context.Context, userID string) (*entity.Workspace, error)
+	// FindByUserID returns the workspaces the user is a member of, with their role in
+	// each, personal workspace first and then by name
+	FindByUserID(ctx context.Context, userID string) ([]entity.Membership, error)
+	FindMember(ctx context.Context, workspaceID, userID string) (*entity.Member, error)
+	// FindMembers returns the members of the workspace, by email
+	FindMembers(ctx context.Context, workspaceID string) ([]*entity.Member, error)
+	// UpdateMemberRole and RemoveMember fail with a conflict when the member is the last
+	// owner of the workspace and would lose the role, checking this atomically with
+	// the change
+	UpdateMemberRole(ctx context.Context, workspaceID, userID string, role entity.Role) error
+	RemoveMember(ctx context.Context, workspaceID, userID string) error
+	// SaveInvitation stores an invitation, replacing any earlier one to the same
+	// email address for the workspace
+	SaveInvitation(ctx context.Context, invitation *entity.Invitation) error
+	FindInvitationByHash(ctx context.Context, tokenHash string) (*entity.Invitation, error)
+	// FindInvitations returns the pending invitations to the workspace, newest first
+	FindInvitations(ctx context.Context, workspaceID string) ([]*entity.Invitation, error)
+	// DeleteInvitation removes an invitation to the workspace
+	DeleteInvitation(ctx context.Context, id, workspaceID string) error
+	// AcceptInvitation adds the member and deletes the invitation together, failing
+	// with a conflict when the user is already a member of the workspace
+	AcceptInvitation(ctx context.Context, invitationID string, member *entity.Member) error
+}
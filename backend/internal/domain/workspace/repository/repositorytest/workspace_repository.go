@@ -0,0 +1,435 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package repositorytest provides the behaviour every workspace repository implementation
+// must share, as a test suite to run against each of them.
+package repositorytest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/repository"
+)
+
+// WorkspaceRepositoryHarness creates the repository under test
+type WorkspaceRepositoryHarness struct {
+	// New returns an empty repository. It is called once per subtest.
+	New func(t *testing.T) repository.WorkspaceRepository
+	// CreateUser stores a user with the given email address, which members are
+	// listed with
+	CreateUser func(t *testing.T, userID, email string)
+}
+
+// TestWorkspaceRepository runs the workspace repository contract against the harness
+func TestWorkspaceRepository(t *testing.T, harness WorkspaceRepositoryHarness) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, h *workspaceHarness)
+	}{
+		{"SaveAndFind", testSaveAndFind},
+		{"FindPersonal", testFindPersonal},
+		{"FindByUserID", testFindByUserID},
+		{"Members", testMembers},
+		{"LastOwner", testLastOwner},
+		{"ConcurrentOwnerRemovals", testConcurrentOwnerRemovals},
+		{"Invitations", testInvitations},
+		{"AcceptInvitation", testAcceptInvitation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, &workspaceHarness{t: t, harness: harness, repo: harness.New(t)})
+		})
+	}
+}
+
+type workspaceHarness struct {
+	t       *testing.T
+	harness WorkspaceRepositoryHarness
+	repo    repository.WorkspaceRepository
+}
+
+func (h *workspaceHarness) newUser(email string) string {
+	userID := uuid.NewString()
+	h.harness.CreateUser(h.t, userID, email)
+	return userID
+}
+
+// save stores a workspace with the user as its owner, using a timestamp at the
+// precision databases store
+func (h *workspaceHarness) save(name, personalUserID, ownerID string) *entity.Workspace {
+	h.t.Helper()
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	workspace := entity.NewWorkspaceFromRepository(uuid.NewString(), name, personalUserID, createdAt)
+	owner := entity.NewMemberFromRepository(workspace.ID(), ownerID, "", entity.RoleOwner, createdAt)
+	if err := h.repo.Save(context.Background(), workspace, owner); err != nil {
+		h.t.Fatalf("Save(%s) error = %v", name, err)
+	}
+	return workspace
+}
+
+// join adds the user to the workspace through an invitation
+func (h *workspaceHarness) join(workspaceID, userID string, role entity.Role) {
+	h.t.Helper()
+	ctx := context.Background()
+	invitation := newInvitation(workspaceID, userID+"@example.com", role, time.Now())
+	if err := h.repo.SaveInvitation(ctx, invitation); err != nil {
+		h.t.Fatalf("SaveInvitation() error = %v", err)
+	}
+	member := entity.NewMemberFromRepository(workspaceID, userID, "", role, time.Now().UTC())
+	if err := h.repo.AcceptInvitation(ctx, invitation.ID(), member); err != nil {
+		h.t.Fatalf("AcceptInvitation() error = %v", err)
+	}
+}
+
+func newInvitation(workspaceID, email string, role entity.Role, createdAt time.Time) *entity.Invitation {
+	createdAt = createdAt.UTC().Truncate(time.Microsecond)
+	return entity.NewInvitationFromRepository(
+		uuid.NewString(),
+		workspaceID,
+		email,
+		role,
+		uuid.NewString(),
+		"",
+		createdAt.Add(24*time.Hour),
+		createdAt,
+	)
+}
+
+func assertErrorType(t *testing.T, err error, want errors.ErrorType) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("error = nil, want %s", want)
+	}
+	if got := errors.GetErrorType(err); got != want {
+		t.Fatalf("error type = %s (%v), want %s", got, err, want)
+	}
+}
+
+func assertSameWorkspace(t *testing.T, got, want *entity.Workspace) {
+	t.Helper()
+	if got.ID() != want.ID() || got.Name() != want.Name() || got.PersonalUserID() != want.PersonalUserID() {
+		t.Fatalf("workspace = {%s %s %q}, want {%s %s %q}",
+			got.ID(), got.Name(), got.PersonalUserID(), want.ID(), want.Name(), want.PersonalUserID())
+	}
+	if !got.CreatedAt().Equal(want.CreatedAt()) {
+		t.Fatalf("CreatedAt = %v, want %v", got.CreatedAt(), want.CreatedAt())
+	}
+}
+
+func assertSameInvitation(t *testing.T, got, want *entity.Invitation) {
+	t.Helper()
+	if got.ID() != want.ID() || got.WorkspaceID() != want.WorkspaceID() || got.Email() != want.Email() ||
+		got.Role() != want.Role() || got.TokenHash() != want.TokenHash() {
+		t.Fatalf("invitation = {%s %s %s %s %s}, want {%s %s %s %s %s}",
+			got.ID(), got.WorkspaceID(), got.Email(), got.Role(), got.TokenHash(),
+			want.ID(), want.WorkspaceID(), want.Email(), want.Role(), want.TokenHash())
+	}
+	if !got.ExpiresAt().Equal(want.ExpiresAt()) || !got.CreatedAt().Equal(want.CreatedAt()) {
+		t.Fatalf("ExpiresAt, CreatedAt = %v, %v, want %v, %v",
+			got.ExpiresAt(), got.CreatedAt(), want.ExpiresAt(), want.CreatedAt())
+	}
+}
+
+func testSaveAndFind(t *testing.T, h *workspaceHarness) {
+	ctx := context.Background()
+	ownerID := h.newUser("owner@example.com")
+	workspace := h.save("Marketing", "", ownerID)
+
+	found, err := h.repo.FindByID(ctx, workspace.ID())
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	assertSameWorkspace(t, found, workspace)
+	if found.IsPersonal() {
+		t.Fatal("IsPersonal() = true, want false for a shared workspace")
+	}
+
+	owner, err := h.repo.FindMember(ctx, workspace.ID(), ownerID)
+	if err != nil {
+		t.Fatalf("FindMember() error = %v", err)
+	}
+	if owner.Role() != entity.RoleOwner || owner.Email() != "owner@example.com" {
+		t.Fatalf("FindMember() = {%s %s}, want {%s owner@example.com}", owner.Role(), owner.Email(), entity.RoleOwner)
+	}
+
+	duplicate := entity.NewMemberFromRepository(workspace.ID(), ownerID, "", entity.RoleOwner, time.Now())
+	assertErrorType(t, h.repo.Save(ctx, workspace, duplicate), errors.ErrorTypeConflict)
+
+	_, err = h.repo.FindByID(ctx, uuid.NewString())
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+	_, err = h.repo.FindMember(ctx, workspace.ID(), uuid.NewString())
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+}
+
+func testFindPersonal(t *testing.T, h *workspaceHarness) {
+	ctx := context.Background()
+	userID := h.newUser("personal@example.com")
+	h.save("Team", "", userID)
+	personal := h.save(entity.PersonalWorkspaceName, userID, userID)
+
+	found, err := h.repo.FindPersonal(ctx, userID)
+	if err != nil {
+		t.Fatalf("FindPersonal() error = %v", err)
+	}
+	assertSameWorkspace(t, found, personal)
+
+	// A user has a single personal workspace
+	createdAt := time.Now().UTC()
+	second := entity.NewWorkspaceFromRepository(uuid.NewString(), entity.PersonalWorkspaceName, userID, createdAt)
+	owner := entity.NewMemberFromRepository(second.ID(), userID, "", entity.RoleOwner, createdAt)
+	assertErrorType(t, h.repo.Save(ctx, second, owner), errors.ErrorTypeConflict)
+	_, err = h.repo.FindByID(ctx, second.ID())
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+
+	_, err = h.repo.FindPersonal(ctx, h.newUser("none@example.com"))
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+}
+
+func testFindByUserID(t *testing.T, h *workspaceHarness) {
+	ctx := context.Background()
+	userID := h.newUser("member@example.com")
+	otherID := h.newUser("other@example.com")
+
+	beta := h.save("Beta", "", userID)
+	alpha := h.save("Alpha", "", otherID)
+	h.join(alpha.ID(), userID, entity.RoleViewer)
+	personal := h.save(entity.PersonalWorkspaceName, userID, userID)
+	h.save("Hidden", "", otherID)
+
+	memberships, err := h.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		t.Fatalf("FindByUserID() error = %v", err)
+	}
+	want := []struct {
+		workspace *entity.Workspace
+		role      entity.Role
+	}{
+		{personal, entity.RoleOwner},
+		{alpha, entity.RoleViewer},
+		{beta, entity.RoleOwner},
+	}
+	if len(memberships) != len(want) {
+		t.Fatalf("FindByUserID() returned %d workspaces, want %d", len(memberships), len(want))
+	}
+	for i, membership := range memberships {
+		assertSameWorkspace(t, membership.Workspace, want[i].workspace)
+		if membership.Role != want[i].role {
+			t.Errorf("role in %s = %s, want %s", membership.Workspace.Name(), membership.Role, want[i].role)
+		}
+	}
+
+	none, err := h.repo.FindByUserID(ctx, uuid.NewString())
+	if err != nil || len(none) != 0 {
+		t.Fatalf("FindByUserID(unknown user) = %v, %v, want none", none, err)
+	}
+}
+
+func testMembers(t *testing.T, h *workspaceHarness) {
+	ctx := context.Background()
+	ownerID := h.newUser("zoe@example.com")
+	editorID := h.newUser("adam@example.com")
+	workspace := h.save("Team", "", ownerID)
+	h.join(workspace.ID(), editorID, entity.RoleEditor)
+
+	members, err := h.repo.FindMembers(ctx, workspace.ID())
+	if err != nil {
+		t.Fatalf("FindMembers() error = %v", err)
+	}
+	if got := fmt.Sprint(memberSummary(members)); got != "[adam@example.com:editor zoe@example.com:owner]" {
+		t.Fatalf("FindMembers() = %s, want adam as editor and zoe as owner", got)
+	}
+
+	if err := h.repo.UpdateMemberRole(ctx, workspace.ID(), editorID, entity.RoleAdmin); err != nil {
+		t.Fatalf("UpdateMemberRole() error = %v", err)
+	}
+	member, err := h.repo.FindMember(ctx, workspace.ID(), editorID)
+	if err != nil || member.Role() != entity.RoleAdmin || member.Email() != "adam@example.com" {
+		t.Fatalf("FindMember() after update = %v, %v, want adam as admin", member, err)
+	}
+	err = h.repo.UpdateMemberRole(ctx, workspace.ID(), uuid.NewString(), entity.RoleAdmin)
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+
+	if err := h.repo.RemoveMember(ctx, workspace.ID(), editorID); err != nil {
+		t.Fatalf("RemoveMember() error = %v", err)
+	}
+	_, err = h.repo.FindMember(ctx, workspace.ID(), editorID)
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+	assertErrorType(t, h.repo.RemoveMember(ctx, workspace.ID(), editorID), errors.ErrorTypeNotFound)
+
+	members, err = h.repo.FindMembers(ctx, workspace.ID())
+	if err != nil || len(members) != 1 || members[0].UserID() != ownerID {
+		t.Fatalf("FindMembers() after removal = %v, %v, want only the owner", members, err)
+	}
+}
+
+func testLastOwner(t *testing.T, h *workspaceHarness) {
+	ctx := context.Background()
+	ownerID := h.newUser("zoe@example.com")
+	otherID := h.newUser("adam@example.com")
+	workspace := h.save("Team", "", ownerID)
+
+	assertErrorType(t, h.repo.UpdateMemberRole(ctx, workspace.ID(), ownerID, entity.RoleAdmin), errors.ErrorTypeConflict)
+	assertErrorType(t, h.repo.RemoveMember(ctx, workspace.ID(), ownerID), errors.ErrorTypeConflict)
+	if err := h.repo.UpdateMemberRole(ctx, workspace.ID(), ownerID, entity.RoleOwner); err != nil {
+		t.Fatalf("UpdateMemberRole(owner to owner) error = %v", err)
+	}
+
+	h.join(workspace.ID(), otherID, entity.RoleOwner)
+	if err := h.repo.UpdateMemberRole(ctx, workspace.ID(), ownerID, entity.RoleAdmin); err != nil {
+		t.Fatalf("UpdateMemberRole() with another owner error = %v", err)
+	}
+	assertErrorType(t, h.repo.RemoveMember(ctx, workspace.ID(), otherID), errors.ErrorTypeConflict)
+	if err := h.repo.RemoveMember(ctx, workspace.ID(), ownerID); err != nil {
+		t.Fatalf("RemoveMember(admin) error = %v", err)
+	}
+}
+
+// testConcurrentOwnerRemovals has two owners remove each other at the same time, of
+// which only one may succeed
+func testConcurrentOwnerRemovals(t *testing.T, h *workspaceHarness) {
+	ctx := context.Background()
+	const rounds = 10
+	for round := range rounds {
+		first := h.newUser(fmt.Sprintf("first-%d@example.com", round))
+		second := h.newUser(fmt.Sprintf("second-%d@example.com", round))
+		workspace := h.save("Team", "", first)
+		h.join(workspace.ID(), second, entity.RoleOwner)
+
+		errs := make([]error, 2)
+		var wg sync.WaitGroup
+		for i, memberID := range []string{first, second} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if i == 0 {
+					errs[i] = h.repo.RemoveMember(ctx, workspace.ID(), memberID)
+				} else {
+					errs[i] = h.repo.UpdateMemberRole(ctx, workspace.ID(), memberID, entity.RoleViewer)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if (errs[0] == nil) == (errs[1] == nil) {
+			t.Fatalf("round %d: errors = %v, want exactly one change to fail", round, errs)
+		}
+		for _, err := range errs {
+			if err != nil && errors.GetErrorType(err) != errors.ErrorTypeConflict {
+				t.Fatalf("round %d: error = %v, want conflict", round, err)
+			}
+		}
+	}
+}
+
+func memberSummary(members []*entity.Member) []string {
+	summary := make([]string, len(members))
+	for i, member := range members {
+		summary[i] = member.Email() + ":" + string(member.Role())
+	}
+	return summary
+}
+
+func testInvitations(t *testing.T, h *workspaceHarness) {
+	ctx := context.Background()
+	workspace := h.save("Team", "", h.newUser("owner@example.com"))
+	other := h.save("Other", "", h.newUser("other@example.com"))
+	now := time.Now()
+
+	older := newInvitation(workspace.ID(), "first@example.com", entity.RoleViewer, now.Add(-time.Hour))
+	replaced := newInvitation(workspace.ID(), "second@example.com", entity.RoleViewer, now.Add(-30*time.Minute))
+	elsewhere := newInvitation(other.ID(), "second@example.com", entity.RoleAdmin, now)
+	for _, invitation := range []*entity.Invitation{older, replaced, elsewhere} {
+		if err := h.repo.SaveInvitation(ctx, invitation); err != nil {
+			t.Fatalf("SaveInvitation(%s) error = %v", invitation.Email(), err)
+		}
+	}
+
+	// Inviting the same email address again replaces the pending invitation
+	newer := newInvitation(workspace.ID(), "second@example.com", entity.RoleEditor, now)
+	if err := h.repo.SaveInvitation(ctx, newer); err != nil {
+		t.Fatalf("SaveInvitation(again) error = %v", err)
+	}
+	_, err := h.repo.FindInvitationByHash(ctx, replaced.TokenHash())
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+
+	found, err := h.repo.FindInvitationByHash(ctx, newer.TokenHash())
+	if err != nil {
+		t.Fatalf("FindInvitationByHash() error = %v", err)
+	}
+	assertSameInvitation(t, found, newer)
+
+	invitations, err := h.repo.FindInvitations(ctx, workspace.ID())
+	if err != nil {
+		t.Fatalf("FindInvitations() error = %v", err)
+	}
+	if len(invitations) != 2 {
+		t.Fatalf("FindInvitations() returned %d invitations, want 2", len(invitations))
+	}
+	assertSameInvitation(t, invitations[0], newer)
+	assertSameInvitation(t, invitations[1], older)
+
+	assertErrorType(t, h.repo.DeleteInvitation(ctx, older.ID(), other.ID()), errors.ErrorTypeNotFound)
+	if err := h.repo.DeleteInvitation(ctx, older.ID(), workspace.ID()); err != nil {
+		t.Fatalf("DeleteInvitation() error = %v", err)
+	}
+	assertErrorType(t, h.repo.DeleteInvitation(ctx, older.ID(), workspace.ID()), errors.ErrorTypeNotFound)
+
+	missing := newInvitation(uuid.NewString(), "missing@example.com", entity.RoleViewer, now)
+	assertErrorType(t, h.repo.SaveInvitation(ctx, missing), errors.ErrorTypeNotFound)
+}
+
+func testAcceptInvitation(t *testing.T, h *workspaceHarness) {
+	ctx := context.Background()
+	ownerID := h.newUser("owner@example.com")
+	userID := h.newUser("invitee@example.com")
+	workspace := h.save("Team", "", ownerID)
+
+	invitation := newInvitation(workspace.ID(), "invitee@example.com", entity.RoleEditor, time.Now())
+	if err := h.repo.SaveInvitation(ctx, invitation); err != nil {
+		t.Fatalf("SaveInvitation() error = %v", err)
+	}
+	member := entity.NewMemberFromRepository(workspace.ID(), userID, "", entity.RoleEditor, time.Now().UTC())
+	if err := h.repo.AcceptInvitation(ctx, invitation.ID(), member); err != nil {
+		t.Fatalf("AcceptInvitation() error = %v", err)
+	}
+
+	found, err := h.repo.FindMember(ctx, workspace.ID(), userID)
+	if err != nil || found.Role() != entity.RoleEditor {
+		t.Fatalf("FindMember() after accepting = %v, %v, want an editor", found, err)
+	}
+	_, err = h.repo.FindInvitationByHash(ctx, invitation.TokenHash())
+	assertErrorType(t, err, errors.ErrorTypeNotFound)
+	assertErrorType(t, h.repo.AcceptInvitation(ctx, invitation.ID(), member), errors.ErrorTypeNotFound)
+
+	// Members cannot join again, and their invitation is kept
+	again := newInvitation(workspace.ID(), "invitee@example.com", entity.RoleAdmin, time.Now())
+	if err := h.repo.SaveInvitation(ctx, again); err != nil {
+		t.Fatalf("SaveInvitation() error = %v", err)
+	}
+	assertErrorType(t, h.repo.AcceptInvitation(ctx, again.ID(), member), errors.ErrorTypeConflict)
+	if _, err := h.repo.FindInvitationByHash(ctx, again.TokenHash()); err != nil {
+		t.Fatalf("FindInvitationByHash() after conflict error = %v", err)
+	}
+}
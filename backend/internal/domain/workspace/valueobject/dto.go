@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package valueobject
+
+import (
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/entity"
+)
+
+// CreateWorkspaceRequest represents a shared workspace to create
+type CreateWorkspaceRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// WorkspaceResponse represents a workspace and the role of the requesting user in it
+type WorkspaceResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Personal  bool      `json:"personal"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MemberResponse represents a member of a workspace in responses
+type MemberResponse struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UpdateMemberRequest represents a change to the role of a member
+type UpdateMemberRequest struct {
+	Role string `json:"role" validate:"required"`
+}
+
+// CreateInvitationRequest represents an invitation to join a workspace
+type CreateInvitationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required"`
+}
+
+// InvitationResponse represents a pending invitation in responses. The token itself is
+// never included.
+type InvitationResponse struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	InvitedBy string    `json:"invited_by"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateInvitationResponse represents a newly created invitation, the only response
+// carrying the token the invitee accepts it with
+type CreateInvitationResponse struct {
+	InvitationResponse
+	Token string `json:"token"`
+}
+
+// AcceptInvitationRequest represents the acceptance of an invitation by its token
+type AcceptInvitationRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// CreateWorkspaceResponse creates a WorkspaceResponse for a member with the given role
+func CreateWorkspaceResponse(workspace *entity.Workspace, role entity.Role) WorkspaceResponse {
+	return WorkspaceResponse{
+		ID:        workspace.ID(),
+		Name:      workspace.Name(),
+		Personal:  workspace.IsPersonal(),
+		Role:      string(role),
+		CreatedAt: workspace.CreatedAt(),
+	}
+}
+
+// CreateMemberResponse creates a MemberResponse from a Member entity
+func CreateMemberResponse(member *entity.Member) MemberResponse {
+	return MemberResponse{
+		UserID:    member.UserID(),
+		Email:     member.Email(),
+		Role:      string(member.Role()),
+		CreatedAt: member.CreatedAt(),
+	}
+}
+
+// CreateInvitationResponseFromEntity creates an InvitationResponse from an Invitation entity
+func CreateInvitationResponseFromEntity(invitation *entity.Invitation) InvitationResponse {
+	return InvitationResponse{
+		ID:        invitation.ID(),
+		Email:     invitation.Email(),
+		Role:      string(invitation.Role()),
+		InvitedBy: invitation.InvitedBy(),
+		ExpiresAt: invitation.ExpiresAt(),
+		CreatedAt: invitation.CreatedAt(),
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// apiKeyIdentifier marks API keys so they are recognisable, e.g. by secret scanners
+	apiKeyIdentifier = "shortly_"
+
+	apiKeyPrefixBytes = 6
+	apiKeySecretBytes = 32
+)
+
+// APIKeyGenerator defines interface for API key generation
+type APIKeyGenerator interface {
+	GenerateAPIKey() (string, string, error) // returns key, prefix, error
+	APIKeyPrefix(key string) (string, bool)
+}
+
+// RandomAPIKeyGenerator generates API keys of the form shortly_<prefix>_<secret>
+type RandomAPIKeyGenerator struct{}
+
+// NewAPIKeyGenerator creates a new API key generator
+func NewAPIKeyGenerator() APIKeyGenerator {
+	return &RandomAPIKeyGenerator{}
+}
+
+func (g *RandomAPIKeyGenerator) GenerateAPIKey() (string, string, error) {
+	prefixBytes := make([]byte, apiKeyPrefixBytes)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return "", "", err
+	}
+	secretBytes := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", "", err
+	}
+
+	prefix := hex.EncodeToString(prefixBytes)
+	key := apiKeyIdentifier + prefix + "_" + base64.RawURLEncoding.EncodeToString(secretBytes)
+	return key, prefix, nil
+}
+
+// APIKeyPrefix extracts the lookup prefix from a key, reporting false for
+// strings that are not shaped like a generated key
+func (g *RandomAPIKeyGenerator) APIKeyPrefix(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, apiKeyIdentifier)
+	if !ok {
+		return "", false
+	}
+
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != hex.EncodedLen(apiKeyPrefixBytes) || secret == "" {
+		return "", false
+	}
+
+	return prefix, true
+}
@@ -18,25 +18,41 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 
 	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
 	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/user/valueobject"
+	"github.com/PraveenGongada/shortly/internal/shared/utils"
+)
+
+const (
+	// refreshTokenBytes is the amount of randomness in an opaque refresh token
+	refreshTokenBytes = 32
+
+	// accessTokenType marks access tokens apart from other tokens signed with the same key
+	accessTokenType = "access_token"
 )
 
 // TokenGenerator defines interface for JWT token generation
 type TokenGenerator interface {
-	GenerateToken(userID string) (string, string, error) // returns type, token, error
+	GenerateToken(userID, role string, tokenVersion int) (string, string, error) // returns type, token, error
+	GenerateRefreshToken() (string, time.Time, error)                            // returns token, expiry, error
+	ParseToken(token string) (*valueobject.AccessTokenClaims, error)
 }
 
 // JwtTokenGenerator implements token generation using RSA
 type JwtTokenGenerator struct {
-	tokenExpiration time.Duration
-	logger          logger.Logger
-	authConfig      config.AuthConfig
+	tokenExpiration   time.Duration
+	refreshExpiration time.Duration
+	logger            logger.Logger
+	authConfig        config.AuthConfig
 }
 
 // NewJwtTokenGenerator creates a new JWT token generator
@@ -46,14 +62,20 @@ func NewJwtTokenGenerator(log logger.Logger, authConfig config.AuthConfig) Token
 		log.Error(context.Background(), "Invalid JWT token expiration config", logger.Error(err))
 		jwtTokenDuration = 24 * time.Hour // default to 24 hours
 	}
+	refreshTokenDuration, err := time.ParseDuration(authConfig.JWTRefreshExpiry())
+	if err != nil {
+		log.Error(context.Background(), "Invalid refresh token expiration config", logger.Error(err))
+		refreshTokenDuration = 7 * 24 * time.Hour // default to 7 days
+	}
 	return &JwtTokenGenerator{
-		tokenExpiration: jwtTokenDuration,
-		authConfig:      authConfig,
-		logger:          log,
+		tokenExpiration:   jwtTokenDuration,
+		refreshExpiration: refreshTokenDuration,
+		authConfig:        authConfig,
+		logger:            log,
 	}
 }
 
-func (g *JwtTokenGenerator) GenerateToken(userID string) (string, string, error) {
+func (g *JwtTokenGenerator) GenerateToken(userID, role string, tokenVersion int) (string, string, error) {
 	if userID == "" {
 		return "", "", errors.New("user ID cannot be empty")
 	}
@@ -66,7 +88,10 @@ func (g *JwtTokenGenerator) GenerateToken(userID string) (string, string, error)
 		"id":         userID,
 		"exp":        tokenExpired,
 		"iat":        timeNow.Unix(),
-		"token_type": "access_token",
+		"jti":        utils.GenerateRandomUUID(),
+		"ver":        tokenVersion,
+		"role":       role,
+		"token_type": accessTokenType,
 	}
 
 	token.Claims = claims
@@ -88,3 +113,75 @@ func (g *JwtTokenGenerator) GenerateToken(userID string) (string, string, error)
 
 	return g.authConfig.JWTTokenType(), tokenString, nil
 }
+
+// GenerateRefreshToken returns an opaque random refresh token. Refresh tokens are not
+// JWTs: they are only meaningful together with their stored hash.
+func (g *JwtTokenGenerator) GenerateRefreshToken() (string, time.Time, error) {
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		g.logger.Error(context.Background(), "Error generating refresh token", logger.Error(err))
+		return "", time.Time{}, err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), time.Now().Add(g.refreshExpiration).UTC(), nil
+}
+
+// ParseToken verifies the signature and expiry of an access token and returns its claims
+func (g *JwtTokenGenerator) ParseToken(tokenString string) (*valueobject.AccessTokenClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		publicRsa := g.authConfig.GetRSAPublicKey()
+		if publicRsa == nil {
+			return nil, errors.New("public RSA key not configured")
+		}
+		return publicRsa, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("invalid token claims")
+	}
+
+	return accessTokenClaims(claims)
+}
+
+// accessTokenClaims extracts the claims of a verified access token. Tokens without a
+// token ID could not be revoked on logout and are rejected. Tokens issued before roles
+// were introduced yield an empty role and act as tokens of regular users.
+func accessTokenClaims(claims jwt.MapClaims) (*valueobject.AccessTokenClaims, error) {
+	if tokenType, _ := claims["token_type"].(string); tokenType != accessTokenType {
+		return nil, errors.New("not an access token")
+	}
+
+	id, ok := claims["id"].(string)
+	if !ok || id == "" {
+		return nil, errors.New("user ID not found in token")
+	}
+
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return nil, errors.New("expiration time missing or invalid")
+	}
+
+	tokenID, ok := claims["jti"].(string)
+	if !ok || tokenID == "" {
+		return nil, errors.New("token ID not found in token")
+	}
+
+	tokenVersion, _ := claims["ver"].(float64)
+	role, _ := claims["role"].(string)
+
+	return &valueobject.AccessTokenClaims{
+		UserID:       id,
+		TokenID:      tokenID,
+		TokenVersion: int(tokenVersion),
+		Role:         role,
+		ExpiresAt:    exp.Time,
+	}, nil
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+)
+
+const (
+	// linkUnlockTokenType marks unlock tokens so they can never pass as access tokens
+	linkUnlockTokenType = "link_unlock"
+
+	// passwordFingerprintBytes is how much of the password hash digest an unlock token carries
+	passwordFingerprintBytes = 16
+)
+
+// LinkUnlockTokenGenerator defines interface for tokens granting access to password protected links
+type LinkUnlockTokenGenerator interface {
+	GenerateUnlockToken(shortCode, passwordHash string) (string, time.Time, error) // returns token, expiry, error
+	VerifyUnlockToken(token, shortCode, passwordHash string) bool
+}
+
+// JwtLinkUnlockTokenGenerator signs unlock tokens with the RSA key used for access tokens.
+// Tokens are bound to the short code and to the current password hash, so changing or
+// removing the password invalidates every token issued before.
+type JwtLinkUnlockTokenGenerator struct {
+	expiration time.Duration
+	logger     logger.Logger
+	authConfig config.AuthConfig
+}
+
+// NewLinkUnlockTokenGenerator creates a new link unlock token generator
+func NewLinkUnlockTokenGenerator(log logger.Logger, authConfig config.AuthConfig) LinkUnlockTokenGenerator {
+	expiration, err := time.ParseDuration(authConfig.JWTLinkUnlockExpiry())
+	if err != nil {
+		log.Error(context.Background(), "Invalid link unlock token expiration config", logger.Error(err))
+		expiration = time.Hour // default to 1 hour
+	}
+	return &JwtLinkUnlockTokenGenerator{
+		expiration: expiration,
+		logger:     log,
+		authConfig: authConfig,
+	}
+}
+
+func (g *JwtLinkUnlockTokenGenerator) GenerateUnlockToken(shortCode, passwordHash string) (string, time.Time, error) {
+	if shortCode == "" || passwordHash == "" {
+		return "", time.Time{}, errors.New("short code and password hash are required")
+	}
+
+	timeNow := time.Now()
+	expiresAt := timeNow.Add(g.expiration)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub":        shortCode,
+		"pwd":        passwordFingerprint(passwordHash),
+		"exp":        expiresAt.Unix(),
+		"iat":        timeNow.Unix(),
+		"token_type": linkUnlockTokenType,
+	})
+
+	privateRsa := g.authConfig.GetRSAPrivateKey()
+	if privateRsa == nil {
+		g.logger.Error(context.Background(), "Private RSA key not available",
+			logger.String("shortCode", shortCode))
+		return "", time.Time{}, errors.New("private RSA key not configured")
+	}
+
+	tokenString, err := token.SignedString(privateRsa)
+	if err != nil {
+		g.logger.Error(context.Background(), "Error signing link unlock token",
+			logger.String("shortCode", shortCode),
+			logger.Error(err))
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expiresAt.UTC(), nil
+}
+
+// VerifyUnlockToken reports whether the token is valid, unexpired and was issued for the
+// short code while it was protected by the given password hash
+func (g *JwtLinkUnlockTokenGenerator) VerifyUnlockToken(tokenString, shortCode, passwordHash string) bool {
+	if tokenString == "" {
+		return false
+	}
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		publicRsa := g.authConfig.GetRSAPublicKey()
+		if publicRsa == nil {
+			return nil, errors.New("public RSA key not configured")
+		}
+		return publicRsa, nil
+	}, jwt.WithExpirationRequired())
+	if err != nil {
+		return false
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return false
+	}
+
+	tokenType, _ := claims["token_type"].(string)
+	subject, _ := claims["sub"].(string)
+	fingerprint, _ := claims["pwd"].(string)
+
+	return tokenType == linkUnlockTokenType &&
+		subject == shortCode &&
+		subtle.ConstantTimeCompare([]byte(fingerprint), []byte(passwordFingerprint(passwordHash))) == 1
+}
+
+// passwordFingerprint identifies a password hash without revealing it in the token
+func passwordFingerprint(passwordHash string) string {
+	sum := sha256.Sum256([]byte(passwordHash))
+	return base64.RawURLEncoding.EncodeToString(sum[:passwordFingerprintBytes])
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package memory implements the caches on top of in-process maps, for running the
+// service without Redis. State is local to the process and lost on restart.
+package memory
+
+import (
+	"sync"
+	"time"
+)
+
+// minSweepSize is the number of entries below which expired entries are only
+// removed when they are read
+const minSweepSize = 1024
+
+type expiringEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// expiringMap is a concurrency-safe map whose entries disappear after their TTL.
+// Expired entries are dropped when read, and swept whenever the map has doubled in
+// size since the last sweep, so keys that are never read again do not accumulate.
+type expiringMap[V any] struct {
+	mu        sync.Mutex
+	entries   map[string]expiringEntry[V]
+	sweepSize int
+}
+
+func newExpiringMap[V any]() *expiringMap[V] {
+	return &expiringMap[V]{
+		entries:   make(map[string]expiringEntry[V]),
+		sweepSize: minSweepSize,
+	}
+}
+
+func (m *expiringMap[V]) set(key string, value V, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.entries[key] = expiringEntry[V]{value: value, expiresAt: now.Add(ttl)}
+
+	if len(m.entries) >= m.sweepSize {
+		for key, entry := range m.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(m.entries, key)
+			}
+		}
+		m.sweepSize = max(minSweepSize, 2*len(m.entries))
+	}
+}
+
+func (m *expiringMap[V]) get(key string) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if !time.Now().Before(entry.expiresAt) {
+		delete(m.entries, key)
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func (m *expiringMap[V]) delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+}
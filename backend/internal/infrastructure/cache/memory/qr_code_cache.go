@@ -0,0 +1,47 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/url/cache"
+)
+
+type qrCodeCache struct {
+	images *expiringMap[[]byte]
+}
+
+// NewQRCodeCache creates an in-process QR code cache
+func NewQRCodeCache() cache.QRCodeCache {
+	return &qrCodeCache{images: newExpiringMap[[]byte]()}
+}
+
+func (qc *qrCodeCache) SetQRCode(ctx context.Context, key string, image []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	qc.images.set(key, image, ttl)
+	return nil
+}
+
+func (qc *qrCodeCache) GetQRCode(ctx context.Context, key string) ([]byte, error) {
+	image, _ := qc.images.get(key)
+	return image, nil
+}
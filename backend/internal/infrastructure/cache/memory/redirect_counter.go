@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/PraveenGongada/shortly/internal/domain/url/cache"
+)
+
+type redirectCounter struct {
+	mu      sync.Mutex
+	pending map[string]int
+}
+
+// NewRedirectCounter creates an in-process redirect counter
+func NewRedirectCounter() cache.RedirectCounter {
+	return &redirectCounter{pending: make(map[string]int)}
+}
+
+func (rc *redirectCounter) Increment(ctx context.Context, urlID string) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.pending[urlID]++
+	return nil
+}
+
+func (rc *redirectCounter) Pending(ctx context.Context, urlIDs ...string) (map[string]int, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	counts := make(map[string]int, len(urlIDs))
+	for _, urlID := range urlIDs {
+		if count, ok := rc.pending[urlID]; ok {
+			counts[urlID] = count
+		}
+	}
+	return counts, nil
+}
+
+func (rc *redirectCounter) Drain(ctx context.Context) (map[string]int, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	counts := rc.pending
+	rc.pending = make(map[string]int)
+	return counts, nil
+}
+
+func (rc *redirectCounter) Restore(ctx context.Context, counts map[string]int) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for urlID, count := range counts {
+		rc.pending[urlID] += count
+	}
+	return nil
+}
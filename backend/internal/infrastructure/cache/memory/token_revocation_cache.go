@@ -0,0 +1,61 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/user/cache"
+)
+
+type tokenRevocationCache struct {
+	revoked  *expiringMap[struct{}]
+	versions *expiringMap[int]
+}
+
+// NewTokenRevocationCache creates an in-process token revocation cache
+func NewTokenRevocationCache() cache.TokenRevocationCache {
+	return &tokenRevocationCache{
+		revoked:  newExpiringMap[struct{}](),
+		versions: newExpiringMap[int](),
+	}
+}
+
+func (c *tokenRevocationCache) RevokeToken(ctx context.Context, tokenID string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	c.revoked.set(tokenID, struct{}{}, ttl)
+	return nil
+}
+
+func (c *tokenRevocationCache) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	_, revoked := c.revoked.get(tokenID)
+	return revoked, nil
+}
+
+func (c *tokenRevocationCache) SetTokenVersion(ctx context.Context, userID string, version int, ttl time.Duration) error {
+	c.versions.set(userID, version, ttl)
+	return nil
+}
+
+func (c *tokenRevocationCache) GetTokenVersion(ctx context.Context, userID string) (int, bool, error) {
+	version, ok := c.versions.get(userID)
+	return version, ok, nil
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/url/cache"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+)
+
+type urlCache struct {
+	// mu makes checking for an invalidation and setting an entry atomic
+	mu          sync.Mutex
+	entries     *expiringMap[valueobject.Redirect]
+	invalidated *expiringMap[struct{}]
+}
+
+// NewURLCache creates an in-process URL cache
+func NewURLCache() cache.URLCache {
+	return &urlCache{
+		entries:     newExpiringMap[valueobject.Redirect](),
+		invalidated: newExpiringMap[struct{}](),
+	}
+}
+
+func (uc *urlCache) SetShortURL(
+	ctx context.Context,
+	domain, shortCode string,
+	redirect valueobject.Redirect,
+	ttl time.Duration,
+) error {
+	// Matches the Redis cache, which never stores an entry that could outlive the link
+	if ttl <= 0 {
+		return nil
+	}
+
+	key := cache.ShortURLKey(domain, shortCode)
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if _, ok := uc.invalidated.get(key); ok {
+		return nil
+	}
+	uc.entries.set(key, redirect, ttl)
+	return nil
+}
+
+func (uc *urlCache) GetRedirect(ctx context.Context, domain, shortCode string) (*valueobject.Redirect, error) {
+	redirect, ok := uc.entries.get(cache.ShortURLKey(domain, shortCode))
+	if !ok {
+		return nil, nil
+	}
+	return &redirect, nil
+}
+
+func (uc *urlCache) InvalidateShortURL(ctx context.Context, domain, shortCode string) error {
+	key := cache.ShortURLKey(domain, shortCode)
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	uc.entries.delete(key)
+	uc.invalidated.set(key, struct{}{}, cache.InvalidationWindow)
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/url/cache"
+)
+
+const qrCodeKeyPrefix = "qr:"
+
+type qrCodeCache struct {
+	client Client
+	logger logger.Logger
+}
+
+func NewQRCodeCache(client Client, logger logger.Logger) cache.QRCodeCache {
+	return &qrCodeCache{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (qc *qrCodeCache) SetQRCode(ctx context.Context, key string, image []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+
+	err := qc.client.Client().Set(ctx, qrCodeKeyPrefix+key, image, ttl).Err()
+	if err != nil {
+		qc.logger.Error(ctx, "Error setting QR code in cache",
+			logger.String("key", key),
+			logger.String("operation", "SetQRCode"),
+			logger.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (qc *qrCodeCache) GetQRCode(ctx context.Context, key string) ([]byte, error) {
+	image, err := qc.client.Client().Get(ctx, qrCodeKeyPrefix+key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		qc.logger.Error(ctx, "Error getting QR code from cache",
+			logger.String("key", key),
+			logger.String("operation", "GetQRCode"),
+			logger.Error(err),
+		)
+		return nil, err
+	}
+
+	return image, nil
+}
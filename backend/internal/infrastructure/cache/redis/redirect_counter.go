@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/url/cache"
+)
+
+// pendingRedirectsKey is a hash of URL ID to redirects not yet persisted
+const pendingRedirectsKey = "redirects:pending"
+
+// drainScript reads and deletes the pending counts atomically, so increments
+// arriving during a drain are kept for the next one
+var drainScript = redis.NewScript(`
+local counts = redis.call('HGETALL', KEYS[1])
+redis.call('DEL', KEYS[1])
+return counts
+`)
+
+type redirectCounter struct {
+	client Client
+	logger logger.Logger
+}
+
+func NewRedirectCounter(client Client, logger logger.Logger) cache.RedirectCounter {
+	return &redirectCounter{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (rc *redirectCounter) Increment(ctx context.Context, urlID string) error {
+	err := rc.client.Client().HIncrBy(ctx, pendingRedirectsKey, urlID, 1).Err()
+	if err != nil {
+		rc.logger.Error(ctx, "Error incrementing pending redirects",
+			logger.String("urlId", urlID),
+			logger.String("operation", "Increment"),
+			logger.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (rc *redirectCounter) Pending(ctx context.Context, urlIDs ...string) (map[string]int, error) {
+	counts := make(map[string]int, len(urlIDs))
+	if len(urlIDs) == 0 {
+		return counts, nil
+	}
+
+	values, err := rc.client.Client().HMGet(ctx, pendingRedirectsKey, urlIDs...).Result()
+	if err != nil {
+		rc.logger.Error(ctx, "Error getting pending redirects",
+			logger.Int("count", len(urlIDs)),
+			logger.String("operation", "Pending"),
+			logger.Error(err),
+		)
+		return nil, err
+	}
+
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		count, err := strconv.Atoi(raw)
+		if err != nil {
+			continue
+		}
+		counts[urlIDs[i]] = count
+	}
+
+	return counts, nil
+}
+
+func (rc *redirectCounter) Drain(ctx context.Context) (map[string]int, error) {
+	values, err := drainScript.Run(ctx, rc.client.Client(), []string{pendingRedirectsKey}).StringSlice()
+	if err != nil && err != redis.Nil {
+		rc.logger.Error(ctx, "Error draining pending redirects",
+			logger.String("operation", "Drain"),
+			logger.Error(err),
+		)
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		count, err := strconv.Atoi(values[i+1])
+		if err != nil {
+			continue
+		}
+		counts[values[i]] = count
+	}
+
+	return counts, nil
+}
+
+func (rc *redirectCounter) Restore(ctx context.Context, counts map[string]int) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	pipe := rc.client.Client().Pipeline()
+	for urlID, count := range counts {
+		pipe.HIncrBy(ctx, pendingRedirectsKey, urlID, int64(count))
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		rc.logger.Error(ctx, "Error restoring pending redirects",
+			logger.Int("count", len(counts)),
+			logger.String("operation", "Restore"),
+			logger.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
@@ -18,14 +18,33 @@ package redis
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 
 	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
 	"github.com/PraveenGongada/shortly/internal/domain/url/cache"
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
 )
 
+// invalidatedValue replaces the entry of an invalidated short code for the
+// InvalidationWindow. Keeping the marker under the entry key lets setScript check for it
+// atomically, also on Redis Cluster. Cached values are JSON or, from older versions, URLs,
+// so they never equal it.
+const invalidatedValue = "!invalidated"
+
+// setScript sets an entry unless its key holds the invalidation marker
+var setScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[2] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[3])
+return 1
+`)
+
 type urlCache struct {
 	client Client
 	logger logger.Logger
@@ -40,12 +59,25 @@ func NewURLCache(client Client, logger logger.Logger) cache.URLCache {
 
 func (uc *urlCache) SetShortURL(
 	ctx context.Context,
-	shortCode, originalURL string,
+	domain, shortCode string,
+	redirect valueobject.Redirect,
 	ttl time.Duration,
 ) error {
-	err := uc.client.Client().Set(ctx, shortCode, originalURL, ttl).Err()
+	// Redis treats a zero TTL as "never expire", which would let the entry outlive the link
+	if ttl <= 0 {
+		return nil
+	}
+
+	value, err := json.Marshal(redirect)
+	if err != nil {
+		return err
+	}
+
+	err = setScript.Run(ctx, uc.client.Client(), []string{cache.ShortURLKey(domain, shortCode)},
+		value, invalidatedValue, max(ttl.Milliseconds(), 1)).Err()
 	if err != redis.Nil && err != nil {
 		uc.logger.Error(ctx, "Error setting shortURL in cache",
+			logger.String("domain", domain),
 			logger.String("shortCode", shortCode),
 			logger.String("operation", "SetShortURL"),
 			logger.Error(err),
@@ -56,24 +88,45 @@ func (uc *urlCache) SetShortURL(
 	return nil
 }
 
-func (uc *urlCache) GetOriginalURL(ctx context.Context, shortCode string) (string, error) {
-	url, err := uc.client.Client().Get(ctx, shortCode).Result()
-	if err != redis.Nil && err != nil {
+func (uc *urlCache) GetRedirect(ctx context.Context, domain, shortCode string) (*valueobject.Redirect, error) {
+	value, err := uc.client.Client().Get(ctx, cache.ShortURLKey(domain, shortCode)).Result()
+	if err == redis.Nil || value == invalidatedValue {
+		return nil, nil
+	}
+	if err != nil {
 		uc.logger.Error(ctx, "Error getting shortURL in cache",
+			logger.String("domain", domain),
+			logger.String("shortCode", shortCode),
+			logger.String("operation", "GetRedirect"),
+			logger.Error(err),
+		)
+		return nil, err
+	}
+
+	// Entries written before redirect settings were cached hold just the destination
+	if !strings.HasPrefix(value, "{") {
+		return &valueobject.Redirect{LongURL: value, RedirectType: entity.DefaultRedirectType}, nil
+	}
+
+	var redirect valueobject.Redirect
+	if err := json.Unmarshal([]byte(value), &redirect); err != nil {
+		uc.logger.Error(ctx, "Error decoding cached shortURL",
+			logger.String("domain", domain),
 			logger.String("shortCode", shortCode),
-			logger.String("operation", "GetOriginalURL"),
+			logger.String("operation", "GetRedirect"),
 			logger.Error(err),
 		)
-		return "", err
+		return nil, err
 	}
 
-	return url, nil
+	return &redirect, nil
 }
 
-func (uc *urlCache) InvalidateShortURL(ctx context.Context, shortCode string) error {
-	err := uc.client.Client().Del(ctx, shortCode).Err()
+func (uc *urlCache) InvalidateShortURL(ctx context.Context, domain, shortCode string) error {
+	err := uc.client.Client().Set(ctx, cache.ShortURLKey(domain, shortCode), invalidatedValue, cache.InvalidationWindow).Err()
 	if err != redis.Nil && err != nil {
 		uc.logger.Error(ctx, "Error invalidating shortURL in cache",
+			logger.String("domain", domain),
 			logger.String("shortCode", shortCode),
 			logger.String("operation", "InvalidateShortURL"),
 			logger.Error(err),
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	zl "github.com/rs/zerolog"
+
+	"github.com/PraveenGongada/shortly/internal/domain/url/cache"
+	"github.com/PraveenGongada/shortly/internal/domain/url/cache/cachetest"
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/logging/zerolog"
+)
+
+// newTestClient starts an in-process Redis server that is shut down with the test
+func newTestClient(t *testing.T) (Client, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return &client{rdb: rdb}, server
+}
+
+func TestURLCache(t *testing.T) {
+	var server *miniredis.Miniredis
+	cachetest.TestURLCache(t, cachetest.URLCacheHarness{
+		New: func(t *testing.T) cache.URLCache {
+			var client Client
+			client, server = newTestClient(t)
+			return NewURLCache(client, zerolog.NewWithLogger(zl.Nop()))
+		},
+		// miniredis only expires keys when its clock is advanced
+		Wait: func(d time.Duration) { server.FastForward(d) },
+	})
+}
+
+// Short codes can be cached again once the invalidation window has passed
+func TestURLCacheInvalidationWindow(t *testing.T) {
+	ctx := context.Background()
+	client, server := newTestClient(t)
+	c := NewURLCache(client, zerolog.NewWithLogger(zl.Nop()))
+	redirect := valueobject.Redirect{LongURL: "https://example.com/a", RedirectType: entity.DefaultRedirectType}
+
+	if err := c.InvalidateShortURL(ctx, "", "abc1234"); err != nil {
+		t.Fatalf("InvalidateShortURL() error = %v", err)
+	}
+	server.FastForward(cache.InvalidationWindow)
+
+	if err := c.SetShortURL(ctx, "", "abc1234", redirect, time.Hour); err != nil {
+		t.Fatalf("SetShortURL() error = %v", err)
+	}
+	if got, err := c.GetRedirect(ctx, "", "abc1234"); err != nil || got == nil || *got != redirect {
+		t.Fatalf("GetRedirect() = %+v, %v, want %+v", got, err, redirect)
+	}
+}
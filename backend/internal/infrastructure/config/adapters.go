@@ -18,6 +18,10 @@ package config
 
 import (
 	"crypto/rsa"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	domainConfig "github.com/PraveenGongada/shortly/internal/domain/shared/config"
@@ -105,6 +109,37 @@ func (s *SecurityConfigAdapter) RequestTimeout() time.Duration {
 	return s.config.Security.RequestTimeout
 }
 
+func (s *SecurityConfigAdapter) RateLimitEnabled() bool { return s.config.Security.RateLimit.Enabled }
+
+func (s *SecurityConfigAdapter) RateLimitPolicy(name string) (domainConfig.RateLimitPolicy, bool) {
+	policy, ok := s.config.Security.RateLimit.Policies[name]
+	if !ok {
+		return domainConfig.RateLimitPolicy{}, false
+	}
+	return domainConfig.RateLimitPolicy{Requests: policy.Requests, Window: policy.Window}, true
+}
+
+// TrustedProxies reads single addresses as networks holding only that address
+func (s *SecurityConfigAdapter) TrustedProxies() []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(s.config.Security.TrustedProxies))
+	for _, proxy := range s.config.Security.TrustedProxies {
+		if prefix, err := netip.ParsePrefix(proxy); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(proxy); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+		}
+	}
+	return prefixes
+}
+
+// Storage returns the storage backend, defaulting to PostgreSQL with Redis
+func (d *DatabaseConfigAdapter) Storage() string {
+	if d.config.Database.Storage == "" {
+		return StoragePostgres
+	}
+	return d.config.Database.Storage
+}
+
 func (d *DatabaseConfigAdapter) Host() string { return d.config.Database.Postgres.Host }
 
 func (d *DatabaseConfigAdapter) Port() int { return d.config.Database.Postgres.Port }
@@ -145,6 +180,10 @@ func (d *DatabaseConfigAdapter) ConnectTimeout() time.Duration {
 	return d.config.Database.Postgres.ConnectTimeout
 }
 
+func (d *DatabaseConfigAdapter) MigrateOnStart() bool {
+	return d.config.Database.Postgres.MigrateOnStart
+}
+
 type AuthConfigAdapter struct {
 	config  *Config
 	secrets SecretProvider
@@ -160,6 +199,10 @@ func (a *AuthConfigAdapter) JWTTokenExpiry() string { return a.config.Auth.JwtTo
 
 func (a *AuthConfigAdapter) JWTRefreshExpiry() string { return a.config.Auth.JwtToken.RefreshExpired }
 
+func (a *AuthConfigAdapter) JWTLinkUnlockExpiry() string {
+	return a.config.Auth.JwtToken.LinkUnlockExpired
+}
+
 func (a *AuthConfigAdapter) GetRSAPublicKey() *rsa.PublicKey { return a.secrets.GetRSAPublicKey() }
 
 func (a *AuthConfigAdapter) GetRSAPrivateKey() *rsa.PrivateKey { return a.secrets.GetRSAPrivateKey() }
@@ -178,6 +221,111 @@ func (u *URLConfigAdapter) MaxCollisionRetries() int {
 	return int(u.config.Application.MaxCollisionRetries)
 }
 
+func (u *URLConfigAdapter) MaxBulkURLs() int { return u.config.Application.MaxBulkURLs }
+
+func (u *URLConfigAdapter) MaxPageSize() int { return u.config.Application.MaxPageSize }
+
+func (u *URLConfigAdapter) PublicURL() string {
+	return strings.TrimRight(u.config.Application.PublicURL, "/")
+}
+
+type AnalyticsConfigAdapter struct {
+	config *Config
+}
+
+func NewAnalyticsConfigAdapter(cfg *Config) domainConfig.AnalyticsConfig {
+	return &AnalyticsConfigAdapter{config: cfg}
+}
+
+func (a *AnalyticsConfigAdapter) ClickBufferSize() int {
+	return a.config.Application.Analytics.BufferSize
+}
+
+func (a *AnalyticsConfigAdapter) ClickBatchSize() int {
+	return a.config.Application.Analytics.BatchSize
+}
+
+func (a *AnalyticsConfigAdapter) ClickFlushInterval() time.Duration {
+	return a.config.Application.Analytics.FlushInterval
+}
+
+func (a *AnalyticsConfigAdapter) RedirectFlushInterval() time.Duration {
+	return a.config.Application.Analytics.RedirectFlushInterval
+}
+
+type TrashConfigAdapter struct {
+	config *Config
+}
+
+func NewTrashConfigAdapter(cfg *Config) domainConfig.TrashConfig {
+	return &TrashConfigAdapter{config: cfg}
+}
+
+func (t *TrashConfigAdapter) TrashRetention() time.Duration {
+	return t.config.Application.Trash.Retention
+}
+
+func (t *TrashConfigAdapter) TrashPurgeInterval() time.Duration {
+	return t.config.Application.Trash.PurgeInterval
+}
+
+type URLSafetyConfigAdapter struct {
+	config *Config
+}
+
+func NewURLSafetyConfigAdapter(cfg *Config) domainConfig.URLSafetyConfig {
+	return &URLSafetyConfigAdapter{config: cfg}
+}
+
+func (u *URLSafetyConfigAdapter) Blocklists() []domainConfig.Blocklist {
+	blocklists := make([]domainConfig.Blocklist, len(u.config.Security.URLSafety.Blocklists))
+	for i, list := range u.config.Security.URLSafety.Blocklists {
+		blocklists[i] = domainConfig.Blocklist{Name: list.Name, Type: list.Type, Path: list.Path}
+	}
+	return blocklists
+}
+
+func (u *URLSafetyConfigAdapter) BlocklistReloadInterval() time.Duration {
+	return u.config.Security.URLSafety.ReloadInterval
+}
+
+func (u *URLSafetyConfigAdapter) SafetyRescanInterval() time.Duration {
+	return u.config.Security.URLSafety.RescanInterval
+}
+
+type DestinationConfigAdapter struct {
+	config *Config
+}
+
+func NewDestinationConfigAdapter(cfg *Config) domainConfig.DestinationConfig {
+	return &DestinationConfigAdapter{config: cfg}
+}
+
+// OwnHosts includes the host of the public URL along with the configured hostnames
+func (d *DestinationConfigAdapter) OwnHosts() []string {
+	hosts := slices.Clone(d.config.Security.Destinations.OwnHosts)
+	if publicURL, err := url.Parse(d.config.Application.PublicURL); err == nil && publicURL.Hostname() != "" {
+		hosts = append(hosts, publicURL.Hostname())
+	}
+	return hosts
+}
+
+func (d *DestinationConfigAdapter) ShortenerHosts() []string {
+	return d.config.Security.Destinations.ShortenerHosts
+}
+
+func (d *DestinationConfigAdapter) ShortenerPolicy() string {
+	return d.config.Security.Destinations.ShortenerPolicy
+}
+
+func (d *DestinationConfigAdapter) MaxShortenerHops() int {
+	return d.config.Security.Destinations.MaxShortenerHops
+}
+
+func (d *DestinationConfigAdapter) ShortenerTimeout() time.Duration {
+	return d.config.Security.Destinations.ShortenerTimeout
+}
+
 type ServerConfigAdapter struct {
 	config *Config
 }
@@ -48,7 +48,7 @@ func (m *Manager) LoadConfig() (*Config, error) {
 func (m *Manager) LoadSecrets() (SecretProvider, error) {
 	if m.secrets == nil {
 		var err error
-		m.secrets, err = NewEnvSecretProvider()
+		m.secrets, err = NewEnvSecretProvider(true)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create secret provider: %w", err)
 		}
@@ -72,7 +72,10 @@ func LoadGlobalSecrets() (SecretProvider, error) {
 	secretsOnce.Do(func() {
 		_ = godotenv.Load()
 
-		globalSecrets, err = NewEnvSecretProvider()
+		// Without a loadable config the storage is unknown, so credentials stay required
+		config, configErr := LoadGlobalConfig()
+		requireDatabaseCredentials := configErr != nil || config.Database.Storage != StorageMemory
+		globalSecrets, err = NewEnvSecretProvider(requireDatabaseCredentials)
 	})
 	return globalSecrets, err
 }
@@ -22,6 +22,12 @@ const (
 	KeysPath    = "keys/"
 )
 
+// Storage backends selected by database.storage
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
 // RSA key file paths
 const (
 	RSAPublicKeyPath  = KeysPath + "public.pem"
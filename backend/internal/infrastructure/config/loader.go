@@ -38,19 +38,37 @@ type GracefulConfig struct {
 	MaxSecond time.Duration `yaml:"max_second" mapstructure:"MAX_SECOND" validate:"required,min=1s"`
 }
 
+type AnalyticsConfig struct {
+	BufferSize            int           `yaml:"buffer_size"             mapstructure:"BUFFER_SIZE"             validate:"required,min=1"`
+	BatchSize             int           `yaml:"batch_size"              mapstructure:"BATCH_SIZE"              validate:"required,min=1"`
+	FlushInterval         time.Duration `yaml:"flush_interval"          mapstructure:"FLUSH_INTERVAL"          validate:"required"`
+	RedirectFlushInterval time.Duration `yaml:"redirect_flush_interval" mapstructure:"REDIRECT_FLUSH_INTERVAL" validate:"required"`
+}
+
+type TrashConfig struct {
+	Retention     time.Duration `yaml:"retention"      mapstructure:"RETENTION"      validate:"required,min=1s"`
+	PurgeInterval time.Duration `yaml:"purge_interval" mapstructure:"PURGE_INTERVAL" validate:"required,min=1s"`
+}
+
 type ApplicationConfig struct {
-	Port                int            `yaml:"port"                  mapstructure:"PORT"                  validate:"required,min=1,max=65535"`
-	AdminPort           int            `yaml:"admin_port"            mapstructure:"ADMIN_PORT"            validate:"required,min=1,max=65535"`
-	ShortUrlLength      int8           `yaml:"short_url_length"      mapstructure:"SHORT_URL_LENGTH"      validate:"required,min=4,max=20"`
-	MaxCollisionRetries int8           `yaml:"max_collision_retries" mapstructure:"MAX_COLLISION_RETRIES" validate:"required,min=1,max=10"`
-	Environment         string         `yaml:"environment"           mapstructure:"ENVIRONMENT"           validate:"required,oneof=DEVELOPMENT STAGING PRODUCTION"`
-	Graceful            GracefulConfig `yaml:"graceful"              mapstructure:"GRACEFUL"`
+	Port                int             `yaml:"port"                  mapstructure:"PORT"                  validate:"required,min=1,max=65535"`
+	AdminPort           int             `yaml:"admin_port"            mapstructure:"ADMIN_PORT"            validate:"required,min=1,max=65535"`
+	ShortUrlLength      int8            `yaml:"short_url_length"      mapstructure:"SHORT_URL_LENGTH"      validate:"required,min=4,max=20"`
+	MaxCollisionRetries int8            `yaml:"max_collision_retries" mapstructure:"MAX_COLLISION_RETRIES" validate:"required,min=1,max=10"`
+	MaxBulkURLs         int             `yaml:"max_bulk_urls"         mapstructure:"MAX_BULK_URLS"         validate:"required,min=1,max=50000"`
+	MaxPageSize         int             `yaml:"max_page_size"         mapstructure:"MAX_PAGE_SIZE"         validate:"required,min=1,max=1000"`
+	PublicURL           string          `yaml:"public_url"            mapstructure:"PUBLIC_URL"            validate:"omitempty,url"`
+	Environment         string          `yaml:"environment"           mapstructure:"ENVIRONMENT"           validate:"required,oneof=DEVELOPMENT STAGING PRODUCTION"`
+	Graceful            GracefulConfig  `yaml:"graceful"              mapstructure:"GRACEFUL"`
+	Analytics           AnalyticsConfig `yaml:"analytics"             mapstructure:"ANALYTICS"`
+	Trash               TrashConfig     `yaml:"trash"                 mapstructure:"TRASH"`
 }
 
 type JwtTokenConfig struct {
-	Type           string `yaml:"type"            mapstructure:"TYPE"            validate:"required"`
-	Expired        string `yaml:"expired"         mapstructure:"EXPIRED"         validate:"required"`
-	RefreshExpired string `yaml:"refresh_expired" mapstructure:"REFRESH_EXPIRED" validate:"required"`
+	Type              string `yaml:"type"                mapstructure:"TYPE"                validate:"required"`
+	Expired           string `yaml:"expired"             mapstructure:"EXPIRED"             validate:"required"`
+	RefreshExpired    string `yaml:"refresh_expired"     mapstructure:"REFRESH_EXPIRED"     validate:"required"`
+	LinkUnlockExpired string `yaml:"link_unlock_expired" mapstructure:"LINK_UNLOCK_EXPIRED" validate:"required"`
 }
 
 type AuthConfig struct {
@@ -66,13 +84,14 @@ type PostgresPoolConfig struct {
 }
 
 type PostgresConfig struct {
-	Host           string             `yaml:"host"            mapstructure:"HOST"            validate:"required"`
-	Port           int                `yaml:"port"            mapstructure:"PORT"            validate:"required,min=1,max=65535"`
-	Name           string             `yaml:"name"            mapstructure:"NAME"            validate:"required"`
-	SSLMode        string             `yaml:"ssl_mode"        mapstructure:"SSL_MODE"        validate:"required,oneof=disable require verify-ca verify-full"`
-	Pool           PostgresPoolConfig `yaml:"pool"            mapstructure:"POOL"`
-	QueryTimeout   time.Duration      `yaml:"query_timeout"   mapstructure:"QUERY_TIMEOUT"   validate:"required"`
-	ConnectTimeout time.Duration      `yaml:"connect_timeout" mapstructure:"CONNECT_TIMEOUT" validate:"required"`
+	Host           string             `yaml:"host"             mapstructure:"HOST"             validate:"required"`
+	Port           int                `yaml:"port"             mapstructure:"PORT"             validate:"required,min=1,max=65535"`
+	Name           string             `yaml:"name"             mapstructure:"NAME"             validate:"required"`
+	SSLMode        string             `yaml:"ssl_mode"         mapstructure:"SSL_MODE"         validate:"required,oneof=disable require verify-ca verify-full"`
+	Pool           PostgresPoolConfig `yaml:"pool"             mapstructure:"POOL"`
+	QueryTimeout   time.Duration      `yaml:"query_timeout"    mapstructure:"QUERY_TIMEOUT"    validate:"required"`
+	ConnectTimeout time.Duration      `yaml:"connect_timeout"  mapstructure:"CONNECT_TIMEOUT"  validate:"required"`
+	MigrateOnStart bool               `yaml:"migrate_on_start" mapstructure:"MIGRATE_ON_START"`
 }
 
 type RedisPoolConfig struct {
@@ -97,6 +116,7 @@ type RedisConfig struct {
 }
 
 type DatabaseConfig struct {
+	Storage  string         `yaml:"storage"  mapstructure:"STORAGE"  validate:"omitempty,oneof=postgres memory"`
 	Postgres PostgresConfig `yaml:"postgres" mapstructure:"POSTGRES"`
 	Redis    RedisConfig    `yaml:"redis"    mapstructure:"REDIS"`
 }
@@ -109,9 +129,43 @@ type CORSConfig struct {
 	MaxAge           int      `yaml:"max_age"           mapstructure:"MAX_AGE"           validate:"min=0"`
 }
 
+type RateLimitPolicyConfig struct {
+	Requests int           `yaml:"requests" mapstructure:"REQUESTS" validate:"required,min=1"`
+	Window   time.Duration `yaml:"window"   mapstructure:"WINDOW"   validate:"required,min=1s"`
+}
+
+type RateLimitConfig struct {
+	Enabled  bool                             `yaml:"enabled"  mapstructure:"ENABLED"`
+	Policies map[string]RateLimitPolicyConfig `yaml:"policies" mapstructure:"POLICIES" validate:"dive"`
+}
+
+type BlocklistConfig struct {
+	Name string `yaml:"name" mapstructure:"NAME" validate:"required"`
+	Type string `yaml:"type" mapstructure:"TYPE" validate:"required,oneof=domains regex hash_prefix"`
+	Path string `yaml:"path" mapstructure:"PATH" validate:"required"`
+}
+
+type URLSafetyConfig struct {
+	ReloadInterval time.Duration     `yaml:"reload_interval" mapstructure:"RELOAD_INTERVAL" validate:"required,min=1s"`
+	RescanInterval time.Duration     `yaml:"rescan_interval" mapstructure:"RESCAN_INTERVAL" validate:"omitempty,min=1m"`
+	Blocklists     []BlocklistConfig `yaml:"blocklists"      mapstructure:"BLOCKLISTS"      validate:"dive"`
+}
+
+type DestinationsConfig struct {
+	OwnHosts         []string      `yaml:"own_hosts"          mapstructure:"OWN_HOSTS"`
+	ShortenerHosts   []string      `yaml:"shortener_hosts"    mapstructure:"SHORTENER_HOSTS"`
+	ShortenerPolicy  string        `yaml:"shortener_policy"   mapstructure:"SHORTENER_POLICY"   validate:"required,oneof=allow reject resolve"`
+	MaxShortenerHops int           `yaml:"max_shortener_hops" mapstructure:"MAX_SHORTENER_HOPS" validate:"min=1"`
+	ShortenerTimeout time.Duration `yaml:"shortener_timeout"  mapstructure:"SHORTENER_TIMEOUT"  validate:"required,min=100ms"`
+}
+
 type SecurityConfig struct {
-	CORS           CORSConfig    `yaml:"cors"            mapstructure:"CORS"`
-	RequestTimeout time.Duration `yaml:"request_timeout" mapstructure:"REQUEST_TIMEOUT" validate:"required"`
+	CORS           CORSConfig         `yaml:"cors"            mapstructure:"CORS"`
+	RequestTimeout time.Duration      `yaml:"request_timeout" mapstructure:"REQUEST_TIMEOUT" validate:"required"`
+	TrustedProxies []string           `yaml:"trusted_proxies" mapstructure:"TRUSTED_PROXIES" validate:"dive,ip|cidr"`
+	RateLimit      RateLimitConfig    `yaml:"rate_limit"      mapstructure:"RATE_LIMIT"`
+	URLSafety      URLSafetyConfig    `yaml:"url_safety"      mapstructure:"URL_SAFETY"`
+	Destinations   DestinationsConfig `yaml:"destinations"    mapstructure:"DESTINATIONS"`
 }
 
 type Config struct {
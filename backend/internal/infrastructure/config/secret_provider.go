@@ -37,14 +37,16 @@ type EnvSecretProvider struct {
 	rsaPrivateKey *rsa.PrivateKey
 }
 
-func NewEnvSecretProvider() (*EnvSecretProvider, error) {
+// NewEnvSecretProvider loads secrets from the environment. Database credentials are
+// only required when the service connects to PostgreSQL.
+func NewEnvSecretProvider(requireDatabaseCredentials bool) (*EnvSecretProvider, error) {
 	provider := &EnvSecretProvider{}
 
 	// Validate required database credentials
-	if os.Getenv("POSTGRES_USER") == "" {
+	if requireDatabaseCredentials && os.Getenv("POSTGRES_USER") == "" {
 		return nil, fmt.Errorf("POSTGRES_USER environment variable is required")
 	}
-	if os.Getenv("POSTGRES_PASSWORD") == "" {
+	if requireDatabaseCredentials && os.Getenv("POSTGRES_PASSWORD") == "" {
 		return nil, fmt.Errorf("POSTGRES_PASSWORD environment variable is required")
 	}
 
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+)
+
+// lookupTimeout bounds a single TXT record lookup
+const lookupTimeout = 10 * time.Second
+
+// TXTVerifier checks DNS TXT records, proving control over custom domains
+type TXTVerifier struct {
+	resolver *net.Resolver
+}
+
+// NewTXTVerifier creates a verifier looking records up with resolver
+func NewTXTVerifier(resolver *net.Resolver) *TXTVerifier {
+	return &TXTVerifier{resolver: resolver}
+}
+
+// HasTXTRecord checks if one of the TXT records at name is value. A name without TXT
+// records is not an error.
+func (v *TXTVerifier) HasTXTRecord(ctx context.Context, name, value string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+
+	records, err := v.resolver.LookupTXT(ctx, name)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, record := range records {
+		if strings.TrimSpace(record) == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
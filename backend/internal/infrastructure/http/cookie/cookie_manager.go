@@ -18,6 +18,7 @@ package cookie
 
 import (
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
@@ -27,15 +28,30 @@ import (
 type Manager interface {
 	SetAuthCookie(w http.ResponseWriter, token string) error
 	InvalidateAuthCookie(w http.ResponseWriter)
+	SetRefreshCookie(w http.ResponseWriter, token string) error
+	InvalidateRefreshCookie(w http.ResponseWriter)
+	SetLinkUnlockCookie(w http.ResponseWriter, shortCode string, token string, expiresAt time.Time) error
 }
 
+const (
+	// RefreshCookieName is the cookie carrying the refresh token
+	RefreshCookieName = "refresh_token"
+
+	// refreshCookiePath limits the refresh token cookie to the user endpoints that consume it
+	refreshCookiePath = "/api/user"
+
+	// LinkUnlockCookieName is the cookie carrying the unlock token of a password protected link
+	LinkUnlockCookieName = "link_unlock"
+)
+
 // cookieManager implements cookie management
 type cookieManager struct {
-	domain     string
-	secure     bool
-	httpOnly   bool
-	sameSite   http.SameSite
-	expiration time.Duration
+	domain            string
+	secure            bool
+	httpOnly          bool
+	sameSite          http.SameSite
+	expiration        time.Duration
+	refreshExpiration time.Duration
 }
 
 // NewCookieManager creates a new cookie manager
@@ -45,12 +61,18 @@ func NewCookieManager(authConfig config.AuthConfig) Manager {
 		expiration = 24 * time.Hour // default to 24 hours
 	}
 
+	refreshExpiration, err := time.ParseDuration(authConfig.JWTRefreshExpiry())
+	if err != nil {
+		refreshExpiration = 7 * 24 * time.Hour // default to 7 days
+	}
+
 	return &cookieManager{
-		domain:     "", // Set from config if needed
-		secure:     true,
-		httpOnly:   true,
-		sameSite:   http.SameSiteNoneMode,
-		expiration: expiration,
+		domain:            "", // Set from config if needed
+		secure:            true,
+		httpOnly:          true,
+		sameSite:          http.SameSiteNoneMode,
+		expiration:        expiration,
+		refreshExpiration: refreshExpiration,
 	}
 }
 
@@ -80,3 +102,53 @@ func (cm *cookieManager) InvalidateAuthCookie(w http.ResponseWriter) {
 	}
 	http.SetCookie(w, cookie)
 }
+
+func (cm *cookieManager) SetRefreshCookie(w http.ResponseWriter, token string) error {
+	cookie := &http.Cookie{
+		Name:     RefreshCookieName,
+		Value:    token,
+		Expires:  time.Now().Add(cm.refreshExpiration),
+		HttpOnly: cm.httpOnly,
+		Secure:   cm.secure,
+		SameSite: cm.sameSite,
+		Path:     refreshCookiePath,
+		Domain:   cm.domain,
+	}
+	http.SetCookie(w, cookie)
+	return nil
+}
+
+func (cm *cookieManager) InvalidateRefreshCookie(w http.ResponseWriter) {
+	cookie := &http.Cookie{
+		Name:    RefreshCookieName,
+		Value:   "",
+		Path:    refreshCookiePath,
+		Domain:  cm.domain,
+		Expires: time.Now().Add(-1 * time.Hour),
+		MaxAge:  -1,
+	}
+	http.SetCookie(w, cookie)
+}
+
+// SetLinkUnlockCookie stores the unlock token under the path of its short link, so every
+// protected link keeps its own cookie. Lax same-site mode lets the cookie accompany visitors
+// following the link from other sites.
+func (cm *cookieManager) SetLinkUnlockCookie(
+	w http.ResponseWriter,
+	shortCode string,
+	token string,
+	expiresAt time.Time,
+) error {
+	cookie := &http.Cookie{
+		Name:     LinkUnlockCookieName,
+		Value:    token,
+		Expires:  expiresAt,
+		HttpOnly: true,
+		Secure:   cm.secure,
+		SameSite: http.SameSiteLaxMode,
+		Path:     "/" + url.PathEscape(shortCode),
+		Domain:   cm.domain,
+	}
+	http.SetCookie(w, cookie)
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/PraveenGongada/shortly/internal/domain/admin/valueobject"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/http/response"
+)
+
+// ListAdminUsers godoc
+// @Summary List users
+// @Description Get a page of all users, newest first, optionally filtered by search text, role and suspension. Pass the next_cursor of a page as cursor to get the following page. Requires the admin role.
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token"
+// @Param limit query int false "Page size, capped by the server"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param q query string false "Part of the email or name"
+// @Param role query string false "Only users holding the role" Enums(user, admin)
+// @Param suspended query bool false "Only suspended users"
+// @Success 200 {object} response.Response{data=valueobject.UserListResponse} "Users page"
+// @Failure 400 {object} response.Response "Bad request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Admin role required"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /admin/users [get]
+func (h *Handler) ListAdminUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var (
+		listReq valueobject.UserListRequest
+		err     error
+	)
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if listReq.Limit, err = strconv.Atoi(limitStr); err != nil {
+			response.Err(w, errors.ValidationError("error parsing limit"))
+			return
+		}
+	}
+	if suspendedStr := query.Get("suspended"); suspendedStr != "" {
+		if listReq.Suspended, err = strconv.ParseBool(suspendedStr); err != nil {
+			response.Err(w, errors.ValidationError("error parsing suspended"))
+			return
+		}
+	}
+	listReq.Cursor = query.Get("cursor")
+	listReq.Search = query.Get("q")
+	listReq.Role = query.Get("role")
+
+	page, err := h.adminService.ListUsers(r.Context(), &listReq)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Json(w, http.StatusOK, "success!", page)
+}
+
+// SuspendUser godoc
+// @Summary Suspend user
+// @Description Keep a user from signing in and from using their API keys, and end all of their sessions at once. Their links keep redirecting. Requires the admin role.
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token"
+// @Param userId path string true "User ID"
+// @Success 200 {object} response.Response{data=valueobject.AdminUserResponse} "User suspended"
+// @Failure 400 {object} response.Response "Administrators cannot suspend themselves"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Admin role required"
+// @Failure 404 {object} response.Response "User not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /admin/users/{userId}/suspend [post]
+func (h *Handler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Header.Get("id")
+
+	user, err := h.adminService.SuspendUser(r.Context(), adminID, chi.URLParam(r, "userId"))
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Json(w, http.StatusOK, "User suspended successfully!", user)
+}
+
+// UnsuspendUser godoc
+// @Summary Unsuspend user
+// @Description Lift the suspension of a user, who can sign in and use their API keys again. Requires the admin role.
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token"
+// @Param userId path string true "User ID"
+// @Success 200 {object} response.Response{data=valueobject.AdminUserResponse} "User unsuspended"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Admin role required"
+// @Failure 404 {object} response.Response "User not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /admin/users/{userId}/unsuspend [post]
+func (h *Handler) UnsuspendUser(w http.ResponseWriter, r *http.Request) {
+	user, err := h.adminService.UnsuspendUser(r.Context(), chi.URLParam(r, "userId"))
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Json(w, http.StatusOK, "User unsuspended successfully!", user)
+}
+
+// GetAdminStats godoc
+// @Summary Get system statistics
+// @Description Get counts of the users and links of the whole system. Requires the admin role.
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token"
+// @Success 200 {object} response.Response{data=valueobject.StatsResponse} "System statistics"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Admin role required"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /admin/stats [get]
+func (h *Handler) GetAdminStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.adminService.GetStats(r.Context())
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Json(w, http.StatusOK, "success!", stats)
+}
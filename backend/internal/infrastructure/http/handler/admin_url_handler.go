@@ -0,0 +1,103 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/http/response"
+)
+
+// ListAdminUrls godoc
+// @Summary List URLs of all workspaces
+// @Description Get a page of the URLs of every workspace, with the same paging and filters as the URL list, optionally narrowed to a workspace or to the URLs created by a user. Requires the admin role.
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token"
+// @Param limit query int false "Page size, capped by the server"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "Order of the URLs" Enums(created, updated, redirects, alphabetical)
+// @Param tag query []string false "Only URLs carrying every given tag" collectionFormat(multi)
+// @Param q query string false "Part of the short code, destination or title"
+// @Param from query string false "Created at or after (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "Created before (RFC 3339 or YYYY-MM-DD)"
+// @Param workspace_id query string false "Only URLs of the workspace"
+// @Param user_id query string false "Only URLs created by the user"
+// @Param trash query bool false "List URLs in the trash instead of active ones"
+// @Success 200 {object} response.Response{data=valueobject.URLListResponse} "URLs page"
+// @Failure 400 {object} response.Response "Bad request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Admin role required"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /admin/urls [get]
+func (h *Handler) ListAdminURLs(w http.ResponseWriter, r *http.Request) {
+	listReq, err := parseURLListRequest(r)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	listReq.UserID = r.URL.Query().Get("user_id")
+	if trashStr := r.URL.Query().Get("trash"); trashStr != "" {
+		if listReq.Trash, err = strconv.ParseBool(trashStr); err != nil {
+			response.Err(w, errors.ValidationError("error parsing trash"))
+			return
+		}
+	}
+
+	page, err := h.urlService.ListAllURLs(r.Context(), listReq)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Json(w, http.StatusOK, "success!", page)
+}
+
+// DisableUrl godoc
+// @Summary Disable URL
+// @Description Take down a URL for the given reason. It stops redirecting at once, answering 410 Gone, and stays disabled. Requires the admin role.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token"
+// @Param urlId path string true "URL ID"
+// @Param request body valueobject.DisableURLRequest true "Why the URL is disabled"
+// @Success 200 {object} response.Response "URL disabled"
+// @Failure 400 {object} response.Response "Missing or too long reason"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Admin role required"
+// @Failure 404 {object} response.Response "URL not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /admin/urls/{urlId}/disable [post]
+func (h *Handler) DisableURL(w http.ResponseWriter, r *http.Request) {
+	var disableReq valueobject.DisableURLRequest
+	if err := json.NewDecoder(r.Body).Decode(&disableReq); err != nil {
+		response.Err(w, errors.ValidationError("Invalid request format"))
+		return
+	}
+
+	if err := h.urlService.DisableURL(r.Context(), chi.URLParam(r, "urlId"), &disableReq); err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Json(w, http.StatusOK, "URL disabled successfully!", nil)
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/user/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/http/response"
+)
+
+// CreateAPIKey godoc
+// @Summary Create an API key
+// @Description Create a personal API key for programmatic access. Scopes default to all scopes (urls:read, urls:write, domains:manage, workspaces:manage). The key is only returned by this request; send it as "Authorization: ApiKey <key>".
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token"
+// @Param request body valueobject.CreateAPIKeyRequest true "API key information"
+// @Success 201 {object} response.Response{data=valueobject.CreateAPIKeyResponse} "API key created successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /user/keys [post]
+func (h *Handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req valueobject.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn(r.Context(), "Invalid request payload",
+			logger.String("handler", "CreateAPIKey"),
+			logger.Error(err))
+		response.Err(w, errors.ValidationError("Invalid request format"))
+		return
+	}
+
+	userID := r.Header.Get("id")
+
+	keyResponse, err := h.apiKeyService.CreateAPIKey(r.Context(), userID, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusCreated, "API key created successfully", keyResponse)
+}
+
+// ListAPIKeys godoc
+// @Summary List API keys
+// @Description List the API keys of the current user. Keys are identified by their prefix; the full key is never returned.
+// @Tags user
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token"
+// @Success 200 {object} response.Response{data=[]valueobject.APIKeyResponse} "List of API keys"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /user/keys [get]
+func (h *Handler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("id")
+
+	keys, err := h.apiKeyService.ListAPIKeys(r.Context(), userID)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "success!", keys)
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key
+// @Description Permanently revoke an API key of the current user
+// @Tags user
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token"
+// @Param keyId path string true "API key ID"
+// @Success 200 {object} response.Response "API key revoked successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "API key not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /user/keys/{keyId} [delete]
+func (h *Handler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	keyID := chi.URLParam(r, "keyId")
+	userID := r.Header.Get("id")
+
+	if err := h.apiKeyService.RevokeAPIKey(r.Context(), userID, keyID); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "API key revoked successfully!", nil)
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/http/response"
+)
+
+// AddDomain godoc
+// @Summary Add a custom domain
+// @Description Add a custom domain to serve short URLs from. The domain can be used once verified: publish the returned verification_record as a DNS TXT record, then call POST /domains/{domainId}/verify. The domain's DNS also has to point at this service.
+// @Tags domain
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param request body valueobject.AddDomainRequest true "Domain information"
+// @Success 201 {object} response.Response{data=valueobject.DomainResponse} "Domain added successfully"
+// @Failure 400 {object} response.Response "Invalid hostname"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 409 {object} response.Response "Domain already added"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /domains [post]
+func (h *Handler) AddDomain(w http.ResponseWriter, r *http.Request) {
+	var req valueobject.AddDomainRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn(r.Context(), "Invalid request payload",
+			logger.String("handler", "AddDomain"),
+			logger.Error(err))
+		response.Err(w, errors.ValidationError("Invalid request format"))
+		return
+	}
+
+	userID := r.Header.Get("id")
+
+	domain, err := h.domainService.AddDomain(r.Context(), userID, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusCreated, "Domain added successfully", domain)
+}
+
+// ListDomains godoc
+// @Summary List custom domains
+// @Description List the custom domains of the current user, by hostname
+// @Tags domain
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Success 200 {object} response.Response{data=[]valueobject.DomainResponse} "List of domains"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /domains [get]
+func (h *Handler) ListDomains(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("id")
+
+	domains, err := h.domainService.ListDomains(r.Context(), userID)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "success!", domains)
+}
+
+// VerifyDomain godoc
+// @Summary Verify a custom domain
+// @Description Look up the verification TXT record of a domain and mark the domain as verified when it is found. A hostname can only be verified by one user.
+// @Tags domain
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param domainId path string true "Domain ID"
+// @Success 200 {object} response.Response{data=valueobject.DomainResponse} "Domain verified"
+// @Failure 400 {object} response.Response "Verification record not found"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Domain not found"
+// @Failure 409 {object} response.Response "Domain already verified by another user"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /domains/{domainId}/verify [post]
+func (h *Handler) VerifyDomain(w http.ResponseWriter, r *http.Request) {
+	domainID := chi.URLParam(r, "domainId")
+	userID := r.Header.Get("id")
+
+	domain, err := h.domainService.VerifyDomain(r.Context(), userID, domainID)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "Domain verified successfully", domain)
+}
+
+// DeleteDomain godoc
+// @Summary Delete a custom domain
+// @Description Remove a custom domain of the current user. Verified domains cannot be removed while short URLs, including those in the trash, are served from them.
+// @Tags domain
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param domainId path string true "Domain ID"
+// @Success 200 {object} response.Response "Domain deleted successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Domain not found"
+// @Failure 409 {object} response.Response "Domain still has short URLs"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /domains/{domainId} [delete]
+func (h *Handler) DeleteDomain(w http.ResponseWriter, r *http.Request) {
+	domainID := chi.URLParam(r, "domainId")
+	userID := r.Header.Get("id")
+
+	if err := h.domainService.DeleteDomain(r.Context(), userID, domainID); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "Domain deleted successfully!", nil)
+}
@@ -17,59 +17,141 @@
 package handler
 
 import (
+	"net/http"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
 	"github.com/PraveenGongada/shortly/internal/application/service"
 	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
 	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/user/entity"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/http/cookie"
 	httpmiddleware "github.com/PraveenGongada/shortly/internal/infrastructure/http/middleware"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/ratelimit"
 )
 
 type Handler struct {
-	userService   service.UserService
-	urlService    service.URLService
-	cookieManager cookie.Manager
-	logger        logger.Logger
-	authConfig    config.AuthConfig
+	userService      service.UserService
+	urlService       service.URLService
+	qrCodeService    service.QRCodeService
+	apiKeyService    service.APIKeyService
+	domainService    service.DomainService
+	workspaceService service.WorkspaceService
+	adminService     service.AdminService
+	cookieManager    cookie.Manager
+	limiter          ratelimit.Limiter
+	logger           logger.Logger
+	tokenParser      httpmiddleware.TokenParser
+	securityConfig   config.SecurityConfig
 }
 
 func New(
 	userService service.UserService,
 	urlService service.URLService,
+	qrCodeService service.QRCodeService,
+	apiKeyService service.APIKeyService,
+	domainService service.DomainService,
+	workspaceService service.WorkspaceService,
+	adminService service.AdminService,
 	cookieManager cookie.Manager,
+	limiter ratelimit.Limiter,
 	logger logger.Logger,
-	authConfig config.AuthConfig,
+	tokenParser httpmiddleware.TokenParser,
+	securityConfig config.SecurityConfig,
 ) *Handler {
 	return &Handler{
-		userService:   userService,
-		urlService:    urlService,
-		cookieManager: cookieManager,
-		logger:        logger,
-		authConfig:    authConfig,
+		userService:      userService,
+		urlService:       urlService,
+		qrCodeService:    qrCodeService,
+		apiKeyService:    apiKeyService,
+		domainService:    domainService,
+		workspaceService: workspaceService,
+		adminService:     adminService,
+		cookieManager:    cookieManager,
+		limiter:          limiter,
+		logger:           logger,
+		tokenParser:      tokenParser,
+		securityConfig:   securityConfig,
 	}
 }
 
 func (h *Handler) Router(r chi.Router) {
 	r.Use(middleware.StripSlashes)
+	r.Use(httpmiddleware.ResolveClientIP(h.securityConfig.TrustedProxies()))
 	r.Use(httpmiddleware.RequestLogger(h.logger))
 	r.Use(httpmiddleware.Metrics())
 
 	r.Route("/api", func(r chi.Router) {
 		r.Route("/user", func(r chi.Router) {
-			r.Post("/login", h.UserLogin)
-			r.Post("/register", h.UserRegister)
-			r.Get("/logout", h.UserLogout)
+			r.With(h.rateLimit("login")).Post("/login", h.UserLogin)
+			r.With(h.rateLimit("register")).Post("/register", h.UserRegister)
+			r.Post("/logout", h.UserLogout)
+			r.With(h.rateLimit("refresh")).Post("/refresh", h.UserRefresh)
+			r.Group(func(r chi.Router) {
+				r.Use(httpmiddleware.JwtAuth(h.logger, h.tokenParser, h.userService))
+				r.Use(h.rateLimit("api"))
+				r.Post("/logout/all", h.UserLogoutEverywhere)
+				r.Get("/me", h.GetProfile)
+				r.Patch("/me", h.UpdateProfile)
+				r.With(h.rateLimit("password")).Post("/password", h.ChangePassword)
+				r.Post("/keys", h.CreateAPIKey)
+				r.Get("/keys", h.ListAPIKeys)
+				r.Delete("/keys/{keyId}", h.RevokeAPIKey)
+			})
+		})
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(httpmiddleware.JwtAuth(h.logger, h.tokenParser, h.userService))
+			r.Use(httpmiddleware.RequireRole(entity.RoleAdmin))
+			r.Use(h.rateLimit("api"))
+			r.Get("/users", h.ListAdminUsers)
+			r.Post("/users/{userId}/suspend", h.SuspendUser)
+			r.Post("/users/{userId}/unsuspend", h.UnsuspendUser)
+			r.Get("/urls", h.ListAdminURLs)
+			r.Post("/urls/{urlId}/disable", h.DisableURL)
+			r.Get("/stats", h.GetAdminStats)
 		})
 		r.Group(func(r chi.Router) {
-			r.Use(httpmiddleware.JwtAuth(h.logger, h.authConfig))
-			r.Get("/urls", h.GetPaginatedURLs)
+			r.Use(httpmiddleware.Authenticate(h.logger, h.tokenParser, h.userService, h.apiKeyService))
+			r.Use(h.rateLimit("api"))
+			read := httpmiddleware.RequireScope(entity.ScopeURLsRead)
+			write := httpmiddleware.RequireScope(entity.ScopeURLsWrite)
+			manageDomains := httpmiddleware.RequireScope(entity.ScopeDomainsManage)
+			manageWorkspaces := httpmiddleware.RequireScope(entity.ScopeWorkspacesManage)
+
+			r.With(read).Get("/urls", h.GetPaginatedURLs)
+			r.With(read).Get("/urls/export", h.ExportURLs)
+			r.With(read).Get("/urls/tags", h.ListTags)
+			r.With(read).Get("/urls/trash", h.GetTrashedURLs)
+			r.With(write, h.rateLimit("url_bulk")).Post("/urls/import", h.ImportURLs)
+			r.Route("/domains", func(r chi.Router) {
+				r.With(manageDomains).Post("/", h.AddDomain)
+				r.With(read).Get("/", h.ListDomains)
+				r.With(manageDomains).Post("/{domainId}/verify", h.VerifyDomain)
+				r.With(manageDomains).Delete("/{domainId}", h.DeleteDomain)
+			})
+			r.Route("/workspaces", func(r chi.Router) {
+				r.With(manageWorkspaces).Post("/", h.CreateWorkspace)
+				r.With(read).Get("/", h.ListWorkspaces)
+				r.With(manageWorkspaces).Post("/invitations/accept", h.AcceptWorkspaceInvitation)
+				r.With(read).Get("/{workspaceId}/members", h.ListWorkspaceMembers)
+				r.With(manageWorkspaces).Patch("/{workspaceId}/members/{userId}", h.UpdateWorkspaceMember)
+				r.With(manageWorkspaces).Delete("/{workspaceId}/members/{userId}", h.RemoveWorkspaceMember)
+				r.With(manageWorkspaces).Post("/{workspaceId}/invitations", h.CreateWorkspaceInvitation)
+				r.With(read).Get("/{workspaceId}/invitations", h.ListWorkspaceInvitations)
+				r.With(manageWorkspaces).Delete("/{workspaceId}/invitations/{invitationId}", h.RevokeWorkspaceInvitation)
+			})
 			r.Route("/url", func(r chi.Router) {
-				r.Post("/create", h.CreateShortURL)
-				r.Patch("/update", h.UpdateURL)
-				r.Delete("/{urlId}", h.DeleteURL)
-				r.Get("/analytics/{shortUrl}", h.GetAnalytics)
+				r.With(write, h.rateLimit("url_create")).Post("/create", h.CreateShortURL)
+				r.With(write, h.rateLimit("url_bulk")).Post("/bulk", h.CreateShortURLs)
+				r.With(write).Patch("/update", h.UpdateURL)
+				r.With(write).Delete("/{urlId}", h.DeleteURL)
+				r.With(write).Post("/{urlId}/restore", h.RestoreURL)
+				r.With(read).Get("/{urlId}/history", h.GetURLHistory)
+				r.With(write).Post("/{urlId}/tags", h.AddURLTags)
+				r.With(write).Delete("/{urlId}/tags/{tag}", h.RemoveURLTag)
+				r.With(read).Get("/analytics/{shortUrl}", h.GetAnalytics)
+				r.With(read).Get("/{shortUrl}/qr", h.GetQRCode)
 			})
 		})
 		r.Get("/{shortUrl}", h.GetLongURL)
@@ -77,5 +159,11 @@ func (h *Handler) Router(r chi.Router) {
 
 	r.Group(func(r chi.Router) {
 		r.Get("/{shortUrl}", h.RedirectUser)
+		r.With(h.rateLimit("unlock")).Post("/{shortUrl}", h.UnlockURL)
 	})
 }
+
+// rateLimit limits requests under the named policy from the security configuration
+func (h *Handler) rateLimit(policy string) func(http.Handler) http.Handler {
+	return httpmiddleware.RateLimit(h.logger, h.securityConfig, h.limiter, policy)
+}
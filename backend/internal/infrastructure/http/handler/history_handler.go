@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/http/response"
+)
+
+// GetUrlHistory godoc
+// @Summary Get URL history
+// @Description Get a page of the destination changes of a URL, newest first. Pass a revision ID as revert_to to the update endpoint to restore the destination it replaced.
+// @Tags url
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param urlId path string true "URL ID"
+// @Param limit query int false "Page size, capped by the server"
+// @Param before query int false "next_before of the previous page"
+// @Success 200 {object} response.Response{data=valueobject.URLHistoryResponse} "URL history page"
+// @Failure 400 {object} response.Response "Bad request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
+// @Failure 404 {object} response.Response "URL not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /url/{urlId}/history [get]
+func (h *Handler) GetURLHistory(w http.ResponseWriter, r *http.Request) {
+	urlID := chi.URLParam(r, "urlId")
+	userID := r.Header.Get("id")
+	query := r.URL.Query()
+
+	var (
+		historyReq valueobject.URLHistoryRequest
+		err        error
+	)
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if historyReq.Limit, err = strconv.Atoi(limitStr); err != nil {
+			response.Err(w, errors.ValidationError("error parsing limit"))
+			return
+		}
+	}
+	if beforeStr := query.Get("before"); beforeStr != "" {
+		if historyReq.Before, err = strconv.ParseInt(beforeStr, 10, 64); err != nil {
+			response.Err(w, errors.ValidationError("error parsing before"))
+			return
+		}
+	}
+
+	history, err := h.urlService.GetURLHistory(r.Context(), userID, urlID, &historyReq)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Json(w, http.StatusOK, "success!", history)
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/http/response"
+)
+
+// GetQRCode godoc
+// @Summary Get URL QR code
+// @Description Render the full short URL of a link as a PNG or SVG QR code. The short URL uses application.public_url when configured, and the host of the request otherwise; links on a custom domain use that domain with the same scheme.
+// @Tags url
+// @Produce png
+// @Produce image/svg+xml
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param shortUrl path string true "Short URL code"
+// @Param domain query string false "Custom domain the short URL is served from, omitted for the default domain"
+// @Param format query string false "Image format: png or svg" default(png)
+// @Param size query int false "Width and height in pixels, 64 to 2048" default(256)
+// @Param level query string false "Error correction level: L, M, Q or H" default(M)
+// @Param margin query int false "Quiet zone around the code in modules, 0 to 16" default(4)
+// @Param fg query string false "Foreground colour as RRGGBB or RRGGBBAA hex" default(000000)
+// @Param bg query string false "Background colour as RRGGBB or RRGGBBAA hex" default(ffffff)
+// @Success 200 {file} binary "QR code image"
+// @Failure 400 {object} response.Response "Bad request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
+// @Failure 404 {object} response.Response "URL not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /url/{shortUrl}/qr [get]
+func (h *Handler) GetQRCode(w http.ResponseWriter, r *http.Request) {
+	shortCode := chi.URLParam(r, "shortUrl")
+	userID := r.Header.Get("id")
+
+	h.logger.Info(r.Context(), "Processing QR code request",
+		logger.String("handler", "GetQRCode"),
+		logger.String("shortCode", shortCode),
+		logger.String("userID", userID))
+
+	query := r.URL.Query()
+	qrReq := valueobject.QRCodeRequest{
+		Domain:     query.Get("domain"),
+		Format:     query.Get("format"),
+		Level:      query.Get("level"),
+		Foreground: query.Get("fg"),
+		Background: query.Get("bg"),
+		BaseURL:    requestBaseURL(r),
+	}
+
+	var err error
+	if sizeStr := query.Get("size"); sizeStr != "" {
+		if qrReq.Size, err = strconv.Atoi(sizeStr); err != nil {
+			response.Err(w, errors.ValidationError("error parsing size"))
+			return
+		}
+	}
+	if marginStr := query.Get("margin"); marginStr != "" {
+		margin, err := strconv.Atoi(marginStr)
+		if err != nil {
+			response.Err(w, errors.ValidationError("error parsing margin"))
+			return
+		}
+		qrReq.Margin = &margin
+	}
+
+	qrCode, err := h.qrCodeService.GetQRCode(r.Context(), shortCode, userID, &qrReq)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", qrCode.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(qrCode.Image)))
+	w.Header().Set("Cache-Control", "private, max-age=86400")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(qrCode.Image)
+}
+
+// requestBaseURL returns the scheme and host the request was addressed to, as seen
+// by the client when the API runs behind a reverse proxy
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+
+	host := r.Host
+	if forwardedHost := r.Header.Get("X-Forwarded-Host"); forwardedHost != "" {
+		first, _, _ := strings.Cut(forwardedHost, ",")
+		if first = strings.TrimSpace(first); first != "" {
+			host = first
+		}
+	}
+
+	return scheme + "://" + host
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/http/response"
+)
+
+// ListTags godoc
+// @Summary List tags
+// @Description List the tags used on the user's URLs with the number of URLs carrying each
+// @Tags url
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Success 200 {object} response.Response{data=[]valueobject.TagResponse} "Tags list"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /urls/tags [get]
+func (h *Handler) ListTags(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("id")
+
+	tags, err := h.urlService.ListTags(r.Context(), userID, r.URL.Query().Get("workspace_id"))
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "success!", tags)
+}
+
+// AddUrlTags godoc
+// @Summary Add URL tags
+// @Description Add tags to a URL, keeping the tags it already carries
+// @Tags url
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param urlId path string true "URL ID"
+// @Param request body valueobject.URLTagsRequest true "Tags to add"
+// @Success 200 {object} response.Response{data=valueobject.URLTagsResponse} "Tags of the URL"
+// @Failure 400 {object} response.Response "Bad request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
+// @Failure 404 {object} response.Response "URL not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /url/{urlId}/tags [post]
+func (h *Handler) AddURLTags(w http.ResponseWriter, r *http.Request) {
+	var tagsRequest valueobject.URLTagsRequest
+	urlID := chi.URLParam(r, "urlId")
+	userID := r.Header.Get("id")
+
+	if err := json.NewDecoder(r.Body).Decode(&tagsRequest); err != nil {
+		response.Err(w, errors.ValidationError("Cannot parse tags request"))
+		return
+	}
+
+	tags, err := h.urlService.AddURLTags(r.Context(), userID, urlID, tagsRequest.Tags)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "success!", tags)
+}
+
+// RemoveUrlTag godoc
+// @Summary Remove URL tag
+// @Description Remove a tag from a URL
+// @Tags url
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param urlId path string true "URL ID"
+// @Param tag path string true "Tag"
+// @Success 200 {object} response.Response{data=valueobject.URLTagsResponse} "Tags of the URL"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
+// @Failure 404 {object} response.Response "URL or tag not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /url/{urlId}/tags/{tag} [delete]
+func (h *Handler) RemoveURLTag(w http.ResponseWriter, r *http.Request) {
+	urlID := chi.URLParam(r, "urlId")
+	tag := chi.URLParam(r, "tag")
+	userID := r.Header.Get("id")
+
+	tags, err := h.urlService.RemoveURLTag(r.Context(), userID, urlID, tag)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "success!", tags)
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/PraveenGongada/shortly/internal/infrastructure/http/response"
+)
+
+// GetTrashedUrls godoc
+// @Summary Get trashed URLs
+// @Description Get a page of the URLs of a workspace in the trash, with the same paging and filters as the URL list
+// @Tags url
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param limit query int false "Page size, capped by the server"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "Order of the URLs" Enums(created, updated, redirects, alphabetical)
+// @Param tag query []string false "Only URLs carrying every given tag" collectionFormat(multi)
+// @Param q query string false "Part of the short code, destination or title"
+// @Param from query string false "Created at or after (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "Created before (RFC 3339 or YYYY-MM-DD)"
+// @Param workspace_id query string false "Workspace ID, defaults to the personal workspace of the user"
+// @Success 200 {object} response.Response{data=valueobject.URLListResponse} "Trashed URLs page"
+// @Failure 400 {object} response.Response "Bad request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /urls/trash [get]
+func (h *Handler) GetTrashedURLs(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("id")
+
+	listReq, err := parseURLListRequest(r)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	listReq.Trash = true
+
+	page, err := h.urlService.GetPaginatedURLs(r.Context(), userID, listReq)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+	response.Json(w, http.StatusOK, "success!", page)
+}
+
+// RestoreUrl godoc
+// @Summary Restore URL
+// @Description Move a URL out of the trash, so it redirects again under its short code
+// @Tags url
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param urlId path string true "URL ID"
+// @Success 200 {object} response.Response "URL restored"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
+// @Failure 404 {object} response.Response "URL not found in trash"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /url/{urlId}/restore [post]
+func (h *Handler) RestoreURL(w http.ResponseWriter, r *http.Request) {
+	urlID := chi.URLParam(r, "urlId")
+	userID := r.Header.Get("id")
+
+	if err := h.urlService.RestoreURL(r.Context(), urlID, userID); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "URL restored successfully!", nil)
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"net/http"
+)
+
+//go:embed templates/unlock.html
+var templatesFS embed.FS
+
+var unlockTemplate = template.Must(template.ParseFS(templatesFS, "templates/unlock.html"))
+
+// unlockPage holds the data rendered into the unlock form
+type unlockPage struct {
+	ShortCode string
+	Error     string
+}
+
+// renderUnlockPage serves the form asking for the password of a protected link.
+// The form posts back to the short link itself.
+func renderUnlockPage(w http.ResponseWriter, status int, page unlockPage) {
+	var body bytes.Buffer
+	if err := unlockTemplate.Execute(&body, page); err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "text/html; charset=utf-8")
+	header.Set("Cache-Control", "no-store")
+	header.Set("Referrer-Policy", "no-referrer")
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Content-Security-Policy",
+		"default-src 'none'; style-src 'unsafe-inline'; form-action 'self'; frame-ancestors 'none'")
+	w.WriteHeader(status)
+	_, _ = w.Write(body.Bytes())
+}
@@ -0,0 +1,309 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/http/response"
+)
+
+const (
+	exportFormatCSV  = "csv"
+	exportFormatJSON = "json"
+
+	// exportBufferSize is how much of an export is buffered before it is sent
+	exportBufferSize = 32 << 10
+)
+
+// urlRecordColumns are the CSV columns of exported URLs, in order
+var urlRecordColumns = []string{"short_code", "long_url", "redirects", "created_at", "updated_at", "domain"}
+
+// ExportURLs godoc
+// @Summary Export URLs
+// @Description Download all URLs of a workspace as CSV or as a JSON array, oldest first. The export is streamed, and can be imported again with POST /urls/import.
+// @Tags url
+// @Produce text/csv
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param format query string false "Export format: csv or json" default(csv)
+// @Param workspace_id query string false "Workspace ID, defaults to the personal workspace of the user"
+// @Success 200 {array} valueobject.URLRecord "Exported URLs"
+// @Failure 400 {object} response.Response "Invalid format"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /urls/export [get]
+func (h *Handler) ExportURLs(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = exportFormatCSV
+	}
+	if format != exportFormatCSV && format != exportFormatJSON {
+		response.Err(w, errors.ValidationError("format must be csv or json"))
+		return
+	}
+
+	userID := r.Header.Get("id")
+	workspaceID := r.URL.Query().Get("workspace_id")
+
+	h.logger.Info(r.Context(), "Processing export URLs request",
+		logger.String("handler", "ExportURLs"),
+		logger.String("userID", userID),
+		logger.String("workspaceID", workspaceID),
+		logger.String("format", format))
+
+	contentType := "text/csv; charset=utf-8"
+	if format == exportFormatJSON {
+		contentType = "application/json"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="shortly-urls-%s.%s"`, time.Now().UTC().Format("2006-01-02"), format))
+
+	// Nothing is sent until the buffer fills up, so early failures still get an error response
+	sent := &sentTracker{w: w}
+	out := bufio.NewWriterSize(sent, exportBufferSize)
+
+	var err error
+	if format == exportFormatCSV {
+		err = h.exportURLsCSV(r, out, userID, workspaceID)
+	} else {
+		err = h.exportURLsJSON(r, out, userID, workspaceID)
+	}
+	if err == nil {
+		err = out.Flush()
+	}
+	if err != nil {
+		h.logger.Error(r.Context(), "Failed to export URLs",
+			logger.String("handler", "ExportURLs"),
+			logger.String("userID", userID),
+			logger.Error(err))
+		if !sent.sent {
+			w.Header().Del("Content-Disposition")
+			response.Err(w, err)
+		}
+		return
+	}
+
+	h.logger.Info(r.Context(), "Export successful",
+		logger.String("handler", "ExportURLs"),
+		logger.String("userID", userID))
+}
+
+func (h *Handler) exportURLsCSV(r *http.Request, out io.Writer, userID, workspaceID string) error {
+	writer := csv.NewWriter(out)
+	if err := writer.Write(urlRecordColumns); err != nil {
+		return err
+	}
+
+	err := h.urlService.ExportURLs(r.Context(), userID, workspaceID, func(record valueobject.URLRecord) error {
+		updatedAt := ""
+		if record.UpdatedAt != nil {
+			updatedAt = record.UpdatedAt.UTC().Format(time.RFC3339Nano)
+		}
+		return writer.Write([]string{
+			record.ShortCode,
+			record.LongURL,
+			strconv.Itoa(record.Redirects),
+			record.CreatedAt.UTC().Format(time.RFC3339Nano),
+			updatedAt,
+			record.Domain,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func (h *Handler) exportURLsJSON(r *http.Request, out io.Writer, userID, workspaceID string) error {
+	encoder := json.NewEncoder(out)
+	if _, err := io.WriteString(out, "["); err != nil {
+		return err
+	}
+
+	first := true
+	err := h.urlService.ExportURLs(r.Context(), userID, workspaceID, func(record valueobject.URLRecord) error {
+		if !first {
+			if _, err := io.WriteString(out, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		return encoder.Encode(record)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(out, "]\n")
+	return err
+}
+
+// ImportURLs godoc
+// @Summary Import URLs
+// @Description Create URLs from a CSV file or JSON array in the export format. Short codes are kept where they are free on the domain of the row, which has to be a verified domain of the user; rows whose short code is taken are reported as conflicts, and rows without one get a generated code. Redirect counts and creation times are carried over.
+// @Tags url
+// @Accept text/csv
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param format query string false "Import format: csv or json, defaults to csv for text/csv bodies and json otherwise"
+// @Param workspace_id query string false "Workspace ID, defaults to the personal workspace of the user"
+// @Param request body []valueobject.URLRecord true "URLs to import"
+// @Success 200 {object} response.Response{data=valueobject.BulkCreateURLResponse} "Per-row results"
+// @Failure 400 {object} response.Response "Invalid file or too many URLs"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
+// @Failure 429 {object} response.Response "Too many requests"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /urls/import [post]
+func (h *Handler) ImportURLs(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = exportFormatJSON
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "text/csv" {
+			format = exportFormatCSV
+		}
+	}
+	if format != exportFormatCSV && format != exportFormatJSON {
+		response.Err(w, errors.ValidationError("format must be csv or json"))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBulkRequestBytes)
+
+	var records []valueobject.URLRecord
+	var err error
+	if format == exportFormatCSV {
+		records, err = decodeURLRecordsCSV(r.Body)
+	} else {
+		err = json.NewDecoder(r.Body).Decode(&records)
+	}
+	if err != nil {
+		h.logger.Warn(r.Context(), "Invalid import file",
+			logger.String("handler", "ImportURLs"),
+			logger.String("format", format),
+			logger.Error(err))
+		response.Err(w, errors.ValidationError("Invalid import file: "+err.Error()))
+		return
+	}
+
+	userID := r.Header.Get("id")
+	workspaceID := r.URL.Query().Get("workspace_id")
+
+	h.logger.Info(r.Context(), "Processing import URLs request",
+		logger.String("handler", "ImportURLs"),
+		logger.String("userID", userID),
+		logger.String("workspaceID", workspaceID),
+		logger.String("format", format),
+		logger.Int("count", len(records)))
+
+	importResponse, err := h.urlService.ImportURLs(r.Context(), userID, workspaceID, records)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "Import processed", importResponse)
+}
+
+// decodeURLRecordsCSV reads URLs from CSV with a header row naming the columns. Only
+// long_url is required; unknown columns and updated_at are ignored.
+func decodeURLRecordsCSV(body io.Reader) ([]valueobject.URLRecord, error) {
+	reader := csv.NewReader(body)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("missing header row")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Spreadsheet applications may prefix the file with a byte order mark
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["long_url"]; !ok {
+		return nil, fmt.Errorf("missing long_url column")
+	}
+
+	var records []valueobject.URLRecord
+	for row := 1; ; row++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+
+		record := valueobject.URLRecord{
+			ShortCode: field("short_code"),
+			LongURL:   field("long_url"),
+			Domain:    field("domain"),
+		}
+		if redirects := field("redirects"); redirects != "" {
+			if record.Redirects, err = strconv.Atoi(redirects); err != nil {
+				return nil, fmt.Errorf("row %d: invalid redirects %q", row, redirects)
+			}
+		}
+		if createdAt := field("created_at"); createdAt != "" {
+			if record.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
+				return nil, fmt.Errorf("row %d: invalid created_at %q", row, createdAt)
+			}
+		}
+		records = append(records, record)
+	}
+}
+
+// sentTracker records whether anything has been written to the response
+type sentTracker struct {
+	w    io.Writer
+	sent bool
+}
+
+func (t *sentTracker) Write(p []byte) (int, error) {
+	t.sent = true
+	return t.w.Write(p)
+}
@@ -18,28 +18,36 @@ package handler
 
 import (
 	"encoding/json"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
 	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/http/cookie"
+	httpmiddleware "github.com/PraveenGongada/shortly/internal/infrastructure/http/middleware"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/http/response"
 	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
 )
 
 // CreateShortUrl godoc
 // @Summary Create a short URL
-// @Description Create a short URL from a long URL
+// @Description Create a short URL from a long URL, optionally using a custom alias as the short code
 // @Tags url
 // @Accept json
 // @Produce json
-// @Param Authorization header string true "Bearer JWT token"
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
 // @Param request body valueobject.CreateURLRequest true "URL information"
 // @Success 201 {object} response.Response{data=valueobject.CreateURLResponse} "URL created successfully"
 // @Failure 400 {object} response.Response "Invalid request"
 // @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
+// @Failure 409 {object} response.Response "Alias already in use"
+// @Failure 429 {object} response.Response "Too many requests"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /url/create [post]
 func (h *Handler) CreateShortURL(w http.ResponseWriter, r *http.Request) {
@@ -68,14 +76,95 @@ func (h *Handler) CreateShortURL(w http.ResponseWriter, r *http.Request) {
 	response.Json(w, http.StatusCreated, "Short URL created successfully", urlResponse)
 }
 
+// maxBulkRequestBytes bounds the size of a bulk creation request body; the number of
+// links it may contain is limited by the URL service
+const maxBulkRequestBytes = 32 << 20
+
+// CreateShortURLs godoc
+// @Summary Create short URLs in bulk
+// @Description Create many short URLs in one request from a JSON array, or from NDJSON with one create request per line. Each request is processed independently and reported in the results by its position; password protected URLs cannot be created in bulk.
+// @Tags url
+// @Accept json
+// @Accept application/x-ndjson
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param request body []valueobject.CreateURLRequest true "URLs to create"
+// @Success 200 {object} response.Response{data=valueobject.BulkCreateURLResponse} "Per-request results"
+// @Failure 400 {object} response.Response "Invalid request or too many URLs"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 429 {object} response.Response "Too many requests"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /url/bulk [post]
+func (h *Handler) CreateShortURLs(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBulkRequestBytes)
+
+	reqs, err := decodeBulkCreateRequests(r)
+	if err != nil {
+		h.logger.Warn(r.Context(), "Invalid request payload",
+			logger.String("handler", "CreateShortURLs"),
+			logger.Error(err))
+		response.Err(w, errors.ValidationError("Invalid request format"))
+		return
+	}
+
+	userID := r.Header.Get("id")
+
+	h.logger.Info(r.Context(), "Processing bulk create short URL request",
+		logger.String("handler", "CreateShortURLs"),
+		logger.String("userID", userID),
+		logger.Int("count", len(reqs)))
+
+	bulkResponse, err := h.urlService.CreateShortURLs(r.Context(), userID, reqs)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "Bulk creation processed", bulkResponse)
+}
+
+// decodeBulkCreateRequests reads create requests from a JSON array, or from NDJSON when
+// the content type says so
+func decodeBulkCreateRequests(r *http.Request) ([]valueobject.CreateURLRequest, error) {
+	decoder := json.NewDecoder(r.Body)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/x-ndjson" && mediaType != "application/jsonl" {
+		var reqs []valueobject.CreateURLRequest
+		if err := decoder.Decode(&reqs); err != nil {
+			return nil, err
+		}
+		return reqs, nil
+	}
+
+	var reqs []valueobject.CreateURLRequest
+	for {
+		var req valueobject.CreateURLRequest
+		if err := decoder.Decode(&req); err == io.EOF {
+			return reqs, nil
+		} else if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, req)
+	}
+}
+
+// maxUnlockFormBytes bounds the size of a submitted unlock form
+const maxUnlockFormBytes = 4096
+
 // RedirectUser godoc
 // @Summary Redirect to long URL
-// @Description Redirects to the original long URL from a short URL
+// @Description Redirects to the original long URL from a short URL, with the redirect status code of the link. The short code is looked up on the domain of the Host header when it is a verified custom domain, and on the default domain otherwise. Links with forward_query pass the query string of the visit on to the destination. Password protected URLs serve an unlock form instead until unlocked.
 // @Tags url
 // @Produce html
 // @Param shortUrl path string true "Short URL code"
+// @Success 301 {string} string "Redirect to long URL, for links with redirect type 301"
 // @Success 302 {string} string "Redirect to long URL"
+// @Success 307 {string} string "Redirect to long URL, for links with redirect type 307"
+// @Success 308 {string} string "Redirect to long URL, for links with redirect type 308"
+// @Failure 401 {string} string "Unlock form for a password protected URL"
 // @Failure 404 {object} response.Response "URL not found"
+// @Failure 410 {object} response.Response "URL has expired"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /{shortUrl} [get]
 func (h *Handler) RedirectUser(w http.ResponseWriter, r *http.Request) {
@@ -89,26 +178,107 @@ func (h *Handler) RedirectUser(w http.ResponseWriter, r *http.Request) {
 		logger.String("handler", "RedirectUser"),
 		logger.String("shortCode", shortCode))
 
-	longURL, err := h.urlService.GetOriginalURL(r.Context(), shortCode)
+	var unlockToken string
+	if unlockCookie, err := r.Cookie(cookie.LinkUnlockCookieName); err == nil {
+		unlockToken = unlockCookie.Value
+	}
+
+	redirect, err := h.urlService.GetOriginalURL(r.Context(), r.Host, shortCode, unlockToken)
 	if err != nil {
+		if errors.GetErrorType(err) == errors.ErrorTypeForbidden {
+			renderUnlockPage(w, http.StatusUnauthorized, unlockPage{ShortCode: shortCode})
+			return
+		}
 		response.Err(w, err)
 		return
 	}
 
+	h.urlService.RecordClick(r.Context(), redirect.URLID, &valueobject.ClickRequest{
+		Referrer:       r.Referer(),
+		UserAgent:      r.UserAgent(),
+		IPAddress:      httpmiddleware.ClientIP(r),
+		AcceptLanguage: r.Header.Get("Accept-Language"),
+	})
+
 	h.logger.Info(r.Context(), "Redirect successful",
 		logger.String("handler", "RedirectUser"),
 		logger.String("shortCode", shortCode))
-	http.Redirect(w, r, longURL, http.StatusFound)
+	http.Redirect(w, r, redirect.Location(r.URL.RawQuery), redirect.RedirectType)
+}
+
+// UnlockURL godoc
+// @Summary Unlock a password protected URL
+// @Description Verifies the password submitted through the unlock form, then sets a short-lived cookie for the short URL and redirects back to it
+// @Tags url
+// @Accept x-www-form-urlencoded
+// @Produce html
+// @Param shortUrl path string true "Short URL code"
+// @Param password formData string true "Link password"
+// @Success 303 {string} string "Redirect back to the short URL"
+// @Failure 401 {string} string "Unlock form with an error for an incorrect password"
+// @Failure 404 {object} response.Response "URL not found"
+// @Failure 410 {object} response.Response "URL has expired"
+// @Failure 429 {object} response.Response "Too many requests"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /{shortUrl} [post]
+func (h *Handler) UnlockURL(w http.ResponseWriter, r *http.Request) {
+	shortCode := chi.URLParam(r, "shortUrl")
+
+	h.logger.Info(r.Context(), "Processing unlock request",
+		logger.String("handler", "UnlockURL"),
+		logger.String("shortCode", shortCode))
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUnlockFormBytes)
+	if err := r.ParseForm(); err != nil {
+		h.logger.Warn(r.Context(), "Invalid unlock form",
+			logger.String("handler", "UnlockURL"),
+			logger.Error(err))
+		response.Err(w, errors.ValidationError("Invalid request format"))
+		return
+	}
+
+	unlock, err := h.urlService.UnlockURL(r.Context(), r.Host, shortCode, r.PostForm.Get("password"))
+	if err != nil {
+		switch errors.GetErrorType(err) {
+		case errors.ErrorTypeUnauthorized:
+			renderUnlockPage(w, http.StatusUnauthorized, unlockPage{
+				ShortCode: shortCode,
+				Error:     "Incorrect password, please try again.",
+			})
+		case errors.ErrorTypeValidation:
+			// The URL is not protected (anymore), so there is nothing to unlock
+			http.Redirect(w, r, r.URL.RequestURI(), http.StatusSeeOther)
+		default:
+			response.Err(w, err)
+		}
+		return
+	}
+
+	if err := h.cookieManager.SetLinkUnlockCookie(w, shortCode, unlock.Token, unlock.ExpiresAt); err != nil {
+		h.logger.Error(r.Context(), "Failed to set unlock cookie",
+			logger.String("handler", "UnlockURL"),
+			logger.Error(err))
+		response.Err(w, errors.InternalError("failed to set cookie"))
+		return
+	}
+
+	h.logger.Info(r.Context(), "Unlock successful",
+		logger.String("handler", "UnlockURL"),
+		logger.String("shortCode", shortCode))
+	// The form is posted to the URL of the visit, so its query string is kept for forwarding
+	http.Redirect(w, r, r.URL.RequestURI(), http.StatusSeeOther)
 }
 
 // GetLongUrl godoc
 // @Summary Get long URL
-// @Description Get the original long URL from a short URL without redirecting
+// @Description Get the original long URL from a short URL without redirecting. The short code is looked up on the domain of the Host header like for redirects.
 // @Tags url
 // @Produce json
 // @Param shortUrl path string true "Short URL code"
 // @Success 200 {object} response.Response{data=string} "Long URL"
+// @Failure 403 {object} response.Response "URL is password protected"
 // @Failure 404 {object} response.Response "URL not found"
+// @Failure 410 {object} response.Response "URL has expired"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /{shortUrl} [get]
 func (h *Handler) GetLongURL(w http.ResponseWriter, r *http.Request) {
@@ -118,24 +288,32 @@ func (h *Handler) GetLongURL(w http.ResponseWriter, r *http.Request) {
 		logger.String("handler", "GetLongURL"),
 		logger.String("shortCode", shortCode))
 
-	longURL, err := h.urlService.GetOriginalURL(r.Context(), shortCode)
+	// Password protected URLs are never unlocked here, so their destination is not revealed
+	redirect, err := h.urlService.GetOriginalURL(r.Context(), r.Host, shortCode, "")
 	if err != nil {
 		response.Err(w, err)
 		return
 	}
 
-	response.Json(w, http.StatusOK, "success!", longURL)
+	response.Json(w, http.StatusOK, "success!", redirect.LongURL)
 }
 
 // GetAnalytics godoc
 // @Summary Get URL analytics
-// @Description Get analytics for a specific short URL
+// @Description Get click analytics for a specific short URL: time-bucketed counts, top referrers and top user agents
 // @Tags url
 // @Produce json
-// @Param Authorization header string true "Bearer JWT token"
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
 // @Param shortUrl path string true "Short URL code"
-// @Success 200 {object} response.Response{data=int} "Redirect count"
+// @Param domain query string false "Custom domain the short URL is served from, omitted for the default domain"
+// @Param from query string false "Start of the range (RFC 3339 or YYYY-MM-DD), defaults to 30 days before to"
+// @Param to query string false "End of the range, exclusive (RFC 3339 or YYYY-MM-DD), defaults to now"
+// @Param interval query string false "Bucket width: hour, day, week or month" default(day)
+// @Param top query int false "Number of top referrers and user agents to return" default(10)
+// @Success 200 {object} response.Response{data=valueobject.AnalyticsResponse} "URL analytics"
+// @Failure 400 {object} response.Response "Bad request"
 // @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
 // @Failure 404 {object} response.Response "URL not found"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /url/analytics/{shortUrl} [get]
@@ -148,69 +326,130 @@ func (h *Handler) GetAnalytics(w http.ResponseWriter, r *http.Request) {
 		logger.String("shortCode", shortCode),
 		logger.String("userID", userID))
 
-	count, err := h.urlService.GetAnalytics(r.Context(), shortCode, userID)
+	query := r.URL.Query()
+	analyticsReq := valueobject.AnalyticsRequest{
+		Domain:   query.Get("domain"),
+		Interval: query.Get("interval"),
+	}
+
+	var err error
+	if analyticsReq.From, err = parseTimeParam(query.Get("from")); err != nil {
+		response.Err(w, errors.ValidationError("error parsing from"))
+		return
+	}
+	if analyticsReq.To, err = parseTimeParam(query.Get("to")); err != nil {
+		response.Err(w, errors.ValidationError("error parsing to"))
+		return
+	}
+	if topStr := query.Get("top"); topStr != "" {
+		if analyticsReq.Top, err = strconv.Atoi(topStr); err != nil {
+			response.Err(w, errors.ValidationError("error parsing top"))
+			return
+		}
+	}
+
+	analytics, err := h.urlService.GetAnalytics(r.Context(), shortCode, userID, &analyticsReq)
 	if err != nil {
 		response.Err(w, err)
 		return
 	}
 
-	response.Json(w, http.StatusOK, "success!", count)
+	response.Json(w, http.StatusOK, "success!", analytics)
+}
+
+// parseTimeParam parses an optional RFC 3339 timestamp or YYYY-MM-DD date query parameter
+func parseTimeParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
 }
 
 // GetPaginatedUrls godoc
 // @Summary Get paginated URLs
-// @Description Get a paginated list of URLs created by the user
+// @Description Get a page of the URLs of a workspace, optionally filtered by tags, search text and creation date. Pass the next_cursor of a page as cursor to get the following page.
 // @Tags url
 // @Produce json
-// @Param Authorization header string true "Bearer JWT token"
-// @Param limit query int true "Limit"
-// @Param offset query int true "Offset"
-// @Success 200 {object} response.Response{data=[]valueobject.URLResponse} "URLs list"
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param limit query int false "Page size, capped by the server"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "Order of the URLs" Enums(created, updated, redirects, alphabetical)
+// @Param tag query []string false "Only URLs carrying every given tag" collectionFormat(multi)
+// @Param q query string false "Part of the short code, destination or title"
+// @Param from query string false "Created at or after (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "Created before (RFC 3339 or YYYY-MM-DD)"
+// @Param workspace_id query string false "Workspace ID, defaults to the personal workspace of the user"
+// @Success 200 {object} response.Response{data=valueobject.URLListResponse} "URLs page"
 // @Failure 400 {object} response.Response "Bad request"
 // @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /urls [get]
 func (h *Handler) GetPaginatedURLs(w http.ResponseWriter, r *http.Request) {
 	userID := r.Header.Get("id")
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
 
-	if offsetStr == "" || limitStr == "" {
-		response.Err(w, errors.ValidationError("limit & offset are required"))
+	listReq, err := parseURLListRequest(r)
+	if err != nil {
+		response.Err(w, err)
 		return
 	}
 
-	offset, err := strconv.Atoi(offsetStr)
+	page, err := h.urlService.GetPaginatedURLs(r.Context(), userID, listReq)
 	if err != nil {
-		response.Err(w, errors.ValidationError("error parsing offset"))
+		response.Err(w, err)
 		return
 	}
+	response.Json(w, http.StatusOK, "success!", page)
+}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil {
-		response.Err(w, errors.ValidationError("error parsing limit"))
-		return
+// parseURLListRequest reads the page and filters of a URL listing from the query string
+func parseURLListRequest(r *http.Request) (*valueobject.URLListRequest, error) {
+	query := r.URL.Query()
+
+	var (
+		listReq valueobject.URLListRequest
+		err     error
+	)
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if listReq.Limit, err = strconv.Atoi(limitStr); err != nil {
+			return nil, errors.ValidationError("error parsing limit")
+		}
 	}
 
-	urls, err := h.urlService.GetPaginatedURLs(r.Context(), userID, limit, offset)
-	if err != nil {
-		response.Err(w, err)
-		return
+	listReq.Cursor = query.Get("cursor")
+	listReq.Sort = query.Get("sort")
+	listReq.Tags = query["tag"]
+	listReq.Search = query.Get("q")
+	listReq.WorkspaceID = query.Get("workspace_id")
+	if listReq.From, err = parseTimeParam(query.Get("from")); err != nil {
+		return nil, errors.ValidationError("error parsing from")
 	}
-	response.Json(w, http.StatusOK, "success!", urls)
+	if listReq.To, err = parseTimeParam(query.Get("to")); err != nil {
+		return nil, errors.ValidationError("error parsing to")
+	}
+
+	return &listReq, nil
 }
 
 // UpdateUrl godoc
 // @Summary Update URL
-// @Description Update a long URL
+// @Description Update the long URL, expiration limits, redirect behaviour, title, description or tags of a URL. Destination changes are kept in the URL history, and revert_to restores the destination replaced by a revision.
 // @Tags url
 // @Accept json
 // @Produce json
-// @Param Authorization header string true "Bearer JWT token"
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
 // @Param request body valueobject.URLUpdateRequest true "URL update information"
 // @Success 200 {object} response.Response "URL updated successfully"
 // @Failure 400 {object} response.Response "Bad request"
 // @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /url/update [patch]
 func (h *Handler) UpdateURL(w http.ResponseWriter, r *http.Request) {
@@ -222,7 +461,7 @@ func (h *Handler) UpdateURL(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.urlService.UpdateURL(r.Context(), updateRequest.ID, userID, updateRequest.NewURL)
+	err := h.urlService.UpdateURL(r.Context(), userID, &updateRequest)
 	if err != nil {
 		response.Err(w, err)
 		return
@@ -233,13 +472,14 @@ func (h *Handler) UpdateURL(w http.ResponseWriter, r *http.Request) {
 
 // DeleteUrl godoc
 // @Summary Delete URL
-// @Description Delete a URL
+// @Description Move a URL to the trash. It stops redirecting at once and can be restored until it is purged.
 // @Tags url
 // @Produce json
-// @Param Authorization header string true "Bearer JWT token"
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
 // @Param urlId path string true "URL ID"
-// @Success 200 {object} response.Response "URL deleted successfully"
+// @Success 200 {object} response.Response "URL moved to trash"
 // @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /url/{urlId} [delete]
 func (h *Handler) DeleteURL(w http.ResponseWriter, r *http.Request) {
@@ -252,5 +492,5 @@ func (h *Handler) DeleteURL(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.Json(w, http.StatusOK, "URL deleted successfully!", nil)
+	response.Json(w, http.StatusOK, "URL moved to trash!", nil)
 }
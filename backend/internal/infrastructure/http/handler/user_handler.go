@@ -18,17 +18,20 @@ package handler
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
 	"github.com/PraveenGongada/shortly/internal/domain/user/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/http/cookie"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/http/response"
 	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
 )
 
 // UserLogin godoc
 // @Summary User login
-// @Description Authenticate a user and return a JWT token
+// @Description Authenticate a user and return a JWT access token and a refresh token
 // @Tags user
 // @Accept json
 // @Produce json
@@ -36,6 +39,7 @@ import (
 // @Success 200 {object} response.Response{data=valueobject.TokenResponse} "Login successful"
 // @Failure 400 {object} response.Response "Invalid request"
 // @Failure 401 {object} response.Response "Invalid credentials"
+// @Failure 429 {object} response.Response "Too many requests"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /user/login [post]
 func (h *Handler) UserLogin(w http.ResponseWriter, r *http.Request) {
@@ -51,35 +55,66 @@ func (h *Handler) UserLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Set authentication cookie
-	if err := h.cookieManager.SetAuthCookie(w, tokenResp.Token); err != nil {
-		h.logger.Warn(r.Context(), "Error setting login cookie", logger.Error(err))
-	}
+	// Set authentication cookies
+	h.setTokenCookies(w, r, tokenResp)
 
 	response.Json(w, http.StatusOK, "Login successful!", tokenResp)
 }
 
 // UserLogout godoc
 // @Summary User logout
-// @Description Logout the current user by invalidating their cookie
+// @Description Logout the current user by revoking their access and refresh tokens and invalidating their cookies
 // @Tags user
 // @Produce json
 // @Success 200 {object} response.Response "Logout successful"
-// @Router /user/logout [get]
+// @Router /user/logout [post]
 func (h *Handler) UserLogout(w http.ResponseWriter, r *http.Request) {
-	// Call service logout (for potential token blacklisting)
-	if err := h.userService.Logout(r.Context()); err != nil {
+	logoutReq := valueobject.LogoutRequest{}
+	if authHeader := r.Header.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+		logoutReq.AccessToken = strings.TrimPrefix(authHeader, "Bearer ")
+	} else if tokenCookie, err := r.Cookie("token"); err == nil {
+		logoutReq.AccessToken = tokenCookie.Value
+	}
+	if refreshCookie, err := r.Cookie(cookie.RefreshCookieName); err == nil {
+		logoutReq.RefreshToken = refreshCookie.Value
+	}
+
+	// Revoke the session server-side; cookies are cleared regardless
+	if err := h.userService.Logout(r.Context(), &logoutReq); err != nil {
 		h.logger.Warn(r.Context(), "Error during logout", logger.Error(err))
 	}
 
-	// Invalidate cookie
+	// Invalidate cookies
 	h.cookieManager.InvalidateAuthCookie(w)
+	h.cookieManager.InvalidateRefreshCookie(w)
 	response.Json(w, http.StatusOK, "Logout successful!", nil)
 }
 
+// UserLogoutEverywhere godoc
+// @Summary Logout from all sessions
+// @Description Revoke every access and refresh token issued to the current user and invalidate their cookies
+// @Tags user
+// @Produce json
+// @Success 200 {object} response.Response "Logged out of all sessions"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /user/logout/all [post]
+func (h *Handler) UserLogoutEverywhere(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("id")
+
+	if err := h.userService.LogoutEverywhere(r.Context(), userID); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	h.cookieManager.InvalidateAuthCookie(w)
+	h.cookieManager.InvalidateRefreshCookie(w)
+	response.Json(w, http.StatusOK, "Logged out of all sessions!", nil)
+}
+
 // UserRegsiter godoc
 // @Summary User registration
-// @Description Register a new user and return a JWT token
+// @Description Register a new user and return a JWT access token and a refresh token
 // @Tags user
 // @Accept json
 // @Produce json
@@ -87,6 +122,7 @@ func (h *Handler) UserLogout(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} response.Response{data=valueobject.TokenResponse} "Registration successful"
 // @Failure 400 {object} response.Response "Invalid request"
 // @Failure 401 {object} response.Response "Email already registered"
+// @Failure 429 {object} response.Response "Too many requests"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /user/register [post]
 func (h *Handler) UserRegister(w http.ResponseWriter, r *http.Request) {
@@ -102,10 +138,146 @@ func (h *Handler) UserRegister(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Set authentication cookie
+	// Set authentication cookies
+	h.setTokenCookies(w, r, tokenResp)
+
+	response.Json(w, http.StatusCreated, "Registration successful!", tokenResp)
+}
+
+// UserRefresh godoc
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token and refresh token. The refresh token is read from the request body or the refresh_token cookie and can only be used once; reusing a rotated token revokes every token issued from the same login.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body valueobject.RefreshRequest false "Refresh token (optional when sent as a cookie)"
+// @Success 200 {object} response.Response{data=valueobject.TokenResponse} "Token refreshed"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Invalid, expired or reused refresh token"
+// @Failure 429 {object} response.Response "Too many requests"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /user/refresh [post]
+func (h *Handler) UserRefresh(w http.ResponseWriter, r *http.Request) {
+	var refreshReq valueobject.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&refreshReq); err != nil && err != io.EOF {
+		response.Err(w, errors.ValidationError("Invalid request format"))
+		return
+	}
+
+	if refreshReq.RefreshToken == "" {
+		if refreshCookie, err := r.Cookie(cookie.RefreshCookieName); err == nil {
+			refreshReq.RefreshToken = refreshCookie.Value
+		}
+	}
+
+	tokenResp, err := h.userService.Refresh(r.Context(), &refreshReq)
+	if err != nil {
+		if errors.GetErrorType(err) == errors.ErrorTypeUnauthorized {
+			h.cookieManager.InvalidateRefreshCookie(w)
+		}
+		response.Err(w, err)
+		return
+	}
+
+	h.setTokenCookies(w, r, tokenResp)
+
+	response.Json(w, http.StatusOK, "Token refreshed!", tokenResp)
+}
+
+// setTokenCookies stores the access and refresh tokens of a response in cookies
+func (h *Handler) setTokenCookies(w http.ResponseWriter, r *http.Request, tokenResp *valueobject.TokenResponse) {
 	if err := h.cookieManager.SetAuthCookie(w, tokenResp.Token); err != nil {
-		h.logger.Warn(r.Context(), "Error setting registration cookie", logger.Error(err))
+		h.logger.Warn(r.Context(), "Error setting authentication cookie", logger.Error(err))
+	}
+	if err := h.cookieManager.SetRefreshCookie(w, tokenResp.RefreshToken); err != nil {
+		h.logger.Warn(r.Context(), "Error setting refresh cookie", logger.Error(err))
 	}
+}
 
-	response.Json(w, http.StatusCreated, "Registration successful!", tokenResp)
+// GetProfile godoc
+// @Summary Get current user
+// @Description Get the profile of the current user
+// @Tags user
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token"
+// @Success 200 {object} response.Response{data=valueobject.UserResponse} "User profile"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /user/me [get]
+func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("id")
+
+	userResp, err := h.userService.GetProfile(r.Context(), userID)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "success!", userResp)
+}
+
+// UpdateProfile godoc
+// @Summary Update current user
+// @Description Update the name and/or email of the current user
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token"
+// @Param request body valueobject.UpdateProfileRequest true "Profile fields to update"
+// @Success 200 {object} response.Response{data=valueobject.UserResponse} "Profile updated successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 409 {object} response.Response "Email already registered"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /user/me [patch]
+func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	var updateReq valueobject.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&updateReq); err != nil {
+		response.Err(w, errors.ValidationError("Invalid request format"))
+		return
+	}
+
+	userID := r.Header.Get("id")
+
+	userResp, err := h.userService.UpdateProfile(r.Context(), userID, &updateReq)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "Profile updated successfully!", userResp)
+}
+
+// ChangePassword godoc
+// @Summary Change password
+// @Description Change the password of the current user. All existing sessions are logged out and new tokens are returned for the current one.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token"
+// @Param request body valueobject.ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} response.Response{data=valueobject.TokenResponse} "Password changed successfully"
+// @Failure 400 {object} response.Response "Invalid request or incorrect current password"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 429 {object} response.Response "Too many requests"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /user/password [post]
+func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var passwordReq valueobject.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&passwordReq); err != nil {
+		response.Err(w, errors.ValidationError("Invalid request format"))
+		return
+	}
+
+	userID := r.Header.Get("id")
+
+	tokenResp, err := h.userService.ChangePassword(r.Context(), userID, &passwordReq)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	h.setTokenCookies(w, r, tokenResp)
+
+	response.Json(w, http.StatusOK, "Password changed successfully!", tokenResp)
 }
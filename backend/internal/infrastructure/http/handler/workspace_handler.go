@@ -0,0 +1,306 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/http/response"
+)
+
+// CreateWorkspace godoc
+// @Summary Create a workspace
+// @Description Create a shared workspace, with the current user as its owner. Links created in a workspace can be managed by all of its members according to their role: viewers see links and analytics, editors also create, update and delete links, admins also manage members and invitations, and owners also manage other owners.
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param request body valueobject.CreateWorkspaceRequest true "Workspace information"
+// @Success 201 {object} response.Response{data=valueobject.WorkspaceResponse} "Workspace created successfully"
+// @Failure 400 {object} response.Response "Invalid name"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /workspaces [post]
+func (h *Handler) CreateWorkspace(w http.ResponseWriter, r *http.Request) {
+	var req valueobject.CreateWorkspaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn(r.Context(), "Invalid request payload",
+			logger.String("handler", "CreateWorkspace"),
+			logger.Error(err))
+		response.Err(w, errors.ValidationError("Invalid request format"))
+		return
+	}
+
+	userID := r.Header.Get("id")
+
+	workspace, err := h.workspaceService.CreateWorkspace(r.Context(), userID, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusCreated, "Workspace created successfully", workspace)
+}
+
+// ListWorkspaces godoc
+// @Summary List workspaces
+// @Description List the workspaces the current user is a member of with their role in each, personal workspace first and then by name
+// @Tags workspace
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Success 200 {object} response.Response{data=[]valueobject.WorkspaceResponse} "List of workspaces"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /workspaces [get]
+func (h *Handler) ListWorkspaces(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("id")
+
+	workspaces, err := h.workspaceService.ListWorkspaces(r.Context(), userID)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "success!", workspaces)
+}
+
+// ListWorkspaceMembers godoc
+// @Summary List workspace members
+// @Description List the members of a workspace and their roles, by email
+// @Tags workspace
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {object} response.Response{data=[]valueobject.MemberResponse} "List of members"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Not a member of the workspace"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /workspaces/{workspaceId}/members [get]
+func (h *Handler) ListWorkspaceMembers(w http.ResponseWriter, r *http.Request) {
+	workspaceID := chi.URLParam(r, "workspaceId")
+	userID := r.Header.Get("id")
+
+	members, err := h.workspaceService.ListMembers(r.Context(), userID, workspaceID)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "success!", members)
+}
+
+// UpdateWorkspaceMember godoc
+// @Summary Change a member's role
+// @Description Change the role of a workspace member. Admins manage the members who are not owners; only owners grant or revoke the owner role, and the last owner cannot step down.
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param workspaceId path string true "Workspace ID"
+// @Param userId path string true "User ID of the member"
+// @Param request body valueobject.UpdateMemberRequest true "New role"
+// @Success 200 {object} response.Response{data=valueobject.MemberResponse} "Member updated successfully"
+// @Failure 400 {object} response.Response "Invalid role"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
+// @Failure 404 {object} response.Response "Member not found"
+// @Failure 409 {object} response.Response "Last owner of the workspace"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /workspaces/{workspaceId}/members/{userId} [patch]
+func (h *Handler) UpdateWorkspaceMember(w http.ResponseWriter, r *http.Request) {
+	var req valueobject.UpdateMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn(r.Context(), "Invalid request payload",
+			logger.String("handler", "UpdateWorkspaceMember"),
+			logger.Error(err))
+		response.Err(w, errors.ValidationError("Invalid request format"))
+		return
+	}
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	memberID := chi.URLParam(r, "userId")
+	userID := r.Header.Get("id")
+
+	member, err := h.workspaceService.UpdateMember(r.Context(), userID, workspaceID, memberID, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "Member updated successfully", member)
+}
+
+// RemoveWorkspaceMember godoc
+// @Summary Remove a member
+// @Description Remove a member from a workspace, under the same rules as changing their role. Members can always leave a workspace by removing themselves, unless they are its last owner. The links they created stay in the workspace.
+// @Tags workspace
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param workspaceId path string true "Workspace ID"
+// @Param userId path string true "User ID of the member"
+// @Success 200 {object} response.Response "Member removed successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
+// @Failure 404 {object} response.Response "Member not found"
+// @Failure 409 {object} response.Response "Last owner of the workspace"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /workspaces/{workspaceId}/members/{userId} [delete]
+func (h *Handler) RemoveWorkspaceMember(w http.ResponseWriter, r *http.Request) {
+	workspaceID := chi.URLParam(r, "workspaceId")
+	memberID := chi.URLParam(r, "userId")
+	userID := r.Header.Get("id")
+
+	if err := h.workspaceService.RemoveMember(r.Context(), userID, workspaceID, memberID); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "Member removed successfully!", nil)
+}
+
+// CreateWorkspaceInvitation godoc
+// @Summary Invite a member
+// @Description Invite an email address to join a shared workspace with a role. The returned token is only shown once and has to be passed on to the invitee, who accepts it with POST /workspaces/invitations/accept within 7 days. Inviting the same address again replaces the earlier invitation.
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param workspaceId path string true "Workspace ID"
+// @Param request body valueobject.CreateInvitationRequest true "Invitation information"
+// @Success 201 {object} response.Response{data=valueobject.CreateInvitationResponse} "Invitation created successfully"
+// @Failure 400 {object} response.Response "Invalid email or role, or a personal workspace"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /workspaces/{workspaceId}/invitations [post]
+func (h *Handler) CreateWorkspaceInvitation(w http.ResponseWriter, r *http.Request) {
+	var req valueobject.CreateInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn(r.Context(), "Invalid request payload",
+			logger.String("handler", "CreateWorkspaceInvitation"),
+			logger.Error(err))
+		response.Err(w, errors.ValidationError("Invalid request format"))
+		return
+	}
+
+	workspaceID := chi.URLParam(r, "workspaceId")
+	userID := r.Header.Get("id")
+
+	invitation, err := h.workspaceService.CreateInvitation(r.Context(), userID, workspaceID, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusCreated, "Invitation created successfully", invitation)
+}
+
+// ListWorkspaceInvitations godoc
+// @Summary List pending invitations
+// @Description List the pending invitations to a workspace, newest first
+// @Tags workspace
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param workspaceId path string true "Workspace ID"
+// @Success 200 {object} response.Response{data=[]valueobject.InvitationResponse} "List of invitations"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /workspaces/{workspaceId}/invitations [get]
+func (h *Handler) ListWorkspaceInvitations(w http.ResponseWriter, r *http.Request) {
+	workspaceID := chi.URLParam(r, "workspaceId")
+	userID := r.Header.Get("id")
+
+	invitations, err := h.workspaceService.ListInvitations(r.Context(), userID, workspaceID)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "success!", invitations)
+}
+
+// RevokeWorkspaceInvitation godoc
+// @Summary Revoke an invitation
+// @Description Revoke a pending invitation, so its token can no longer be accepted
+// @Tags workspace
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param workspaceId path string true "Workspace ID"
+// @Param invitationId path string true "Invitation ID"
+// @Success 200 {object} response.Response "Invitation revoked successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Workspace role does not allow this"
+// @Failure 404 {object} response.Response "Invitation not found"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /workspaces/{workspaceId}/invitations/{invitationId} [delete]
+func (h *Handler) RevokeWorkspaceInvitation(w http.ResponseWriter, r *http.Request) {
+	workspaceID := chi.URLParam(r, "workspaceId")
+	invitationID := chi.URLParam(r, "invitationId")
+	userID := r.Header.Get("id")
+
+	if err := h.workspaceService.RevokeInvitation(r.Context(), userID, workspaceID, invitationID); err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "Invitation revoked successfully!", nil)
+}
+
+// AcceptWorkspaceInvitation godoc
+// @Summary Accept an invitation
+// @Description Join the workspace an invitation token was issued for, with the role of the invitation. The invitation has to be addressed to the email of the current user.
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer JWT token or ApiKey API key"
+// @Param request body valueobject.AcceptInvitationRequest true "Invitation token"
+// @Success 200 {object} response.Response{data=valueobject.WorkspaceResponse} "Invitation accepted"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 403 {object} response.Response "Invitation sent to another email address"
+// @Failure 404 {object} response.Response "Invitation not found"
+// @Failure 409 {object} response.Response "Already a member"
+// @Failure 410 {object} response.Response "Invitation has expired"
+// @Failure 500 {object} response.Response "Internal server error"
+// @Router /workspaces/invitations/accept [post]
+func (h *Handler) AcceptWorkspaceInvitation(w http.ResponseWriter, r *http.Request) {
+	var req valueobject.AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn(r.Context(), "Invalid request payload",
+			logger.String("handler", "AcceptWorkspaceInvitation"),
+			logger.Error(err))
+		response.Err(w, errors.ValidationError("Invalid request format"))
+		return
+	}
+
+	userID := r.Header.Get("id")
+
+	workspace, err := h.workspaceService.AcceptInvitation(r.Context(), userID, &req)
+	if err != nil {
+		response.Err(w, err)
+		return
+	}
+
+	response.Json(w, http.StatusOK, "Invitation accepted", workspace)
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httpmiddleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/user/valueobject"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/http/response"
+)
+
+// APIKeyAuthenticator resolves API keys to the user and scopes they act with
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*valueobject.APIKeyPrincipal, error)
+}
+
+type scopesContextKey struct{}
+
+// Authenticate accepts an API key sent as "Authorization: ApiKey <key>" and falls
+// back to JWT authentication for any other request
+func Authenticate(
+	log logger.Logger,
+	tokens TokenParser,
+	sessions SessionValidator,
+	apiKeys APIKeyAuthenticator,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		jwtAuth := JwtAuth(log, tokens, sessions)(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "ApiKey ")
+			if !ok {
+				jwtAuth.ServeHTTP(w, r)
+				return
+			}
+
+			principal, err := apiKeys.AuthenticateAPIKey(r.Context(), strings.TrimSpace(key))
+			if err != nil {
+				log.Warn(r.Context(), "API key authentication failed",
+					logger.String("middleware", "Authenticate"),
+					logger.Error(err))
+				response.Err(w, err)
+				return
+			}
+
+			r.Header.Set("id", principal.UserID)
+			log.Info(r.Context(), "API key validated successfully",
+				logger.String("middleware", "Authenticate"),
+				logger.String("userId", principal.UserID))
+
+			ctx := context.WithValue(r.Context(), userIDContextKey{}, principal.UserID)
+			ctx = context.WithValue(ctx, scopesContextKey{}, principal.Scopes)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireScope rejects requests authenticated with an API key that was not granted
+// the scope. Requests authenticated with a JWT are not restricted by scopes.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scopes, restricted := r.Context().Value(scopesContextKey{}).([]string)
+			if restricted && !slices.Contains(scopes, scope) {
+				response.Err(w, errors.ForbiddenError("API key is missing the "+scope+" scope"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
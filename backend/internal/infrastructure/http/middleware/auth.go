@@ -17,20 +17,40 @@
 package httpmiddleware
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 	"strings"
-	"time"
 
-	"github.com/golang-jwt/jwt/v5"
-
-	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
 	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
 	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/user/valueobject"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/http/response"
 )
 
-func JwtAuth(log logger.Logger, authConfig config.AuthConfig) func(http.Handler) http.Handler {
+// TokenParser verifies the signature, expiry and type of an access token and returns
+// its claims
+type TokenParser interface {
+	ParseToken(token string) (*valueobject.AccessTokenClaims, error)
+}
+
+// SessionValidator checks that a token with a valid signature has not been revoked
+type SessionValidator interface {
+	ValidateSession(ctx context.Context, claims *valueobject.AccessTokenClaims) error
+}
+
+type (
+	userIDContextKey struct{}
+	roleContextKey   struct{}
+)
+
+// authenticatedUserID returns the ID of the user an authentication middleware
+// authenticated the request for, or an empty string when none did
+func authenticatedUserID(ctx context.Context) string {
+	userID, _ := ctx.Value(userIDContextKey{}).(string)
+	return userID
+}
+
+func JwtAuth(log logger.Logger, tokens TokenParser, sessions SessionValidator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			log.Debug(r.Context(), "Verifying JWT token",
@@ -59,24 +79,8 @@ func JwtAuth(log logger.Logger, authConfig config.AuthConfig) func(http.Handler)
 					logger.String("middleware", "JwtVerifyToken"))
 			}
 
-			token, err := jwt.Parse(JwtToken, func(token *jwt.Token) (any, error) {
-				if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-					log.Warn(r.Context(), "Unexpected signing method",
-						logger.String("middleware", "JwtVerifyToken"),
-						logger.String("method", token.Header["alg"].(string)))
-					return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-				}
-
-				publicRsa := authConfig.GetRSAPublicKey()
-				if publicRsa == nil {
-					log.Error(r.Context(), "RSA public key is not configured",
-						logger.String("middleware", "JwtVerifyToken"))
-					return nil, errors.InternalError("RSA key configuration error")
-				}
-				return publicRsa, nil
-			})
-
-			if err != nil || !token.Valid {
+			claims, err := tokens.ParseToken(JwtToken)
+			if err != nil {
 				log.Warn(r.Context(), "Token is not valid",
 					logger.String("middleware", "JwtVerifyToken"),
 					logger.Error(err))
@@ -84,36 +88,13 @@ func JwtAuth(log logger.Logger, authConfig config.AuthConfig) func(http.Handler)
 				return
 			}
 
-			claims, ok := token.Claims.(jwt.MapClaims)
-			if !ok {
-				log.Warn(r.Context(), "Invalid token claims format",
-					logger.String("middleware", "JwtVerifyToken"))
-				response.Json(w, http.StatusUnauthorized, "Invalid token claims", nil)
-				return
-			}
-
-			id, ok := claims["id"].(string)
-			if !ok || id == "" {
-				log.Warn(r.Context(), "User ID not found in token",
-					logger.String("middleware", "JwtVerifyToken"))
-				response.Json(w, http.StatusUnauthorized, "ID not found", nil)
-				return
-			}
-
-			rawExp, ok := claims["exp"].(float64)
-			if !ok {
-				log.Warn(r.Context(), "Expiration time missing or invalid",
-					logger.String("middleware", "JwtVerifyToken"))
-				response.Json(w, http.StatusUnauthorized, "Expiration time missing or invalid", nil)
-				return
-			}
-			exp := int64(rawExp)
-			if exp < time.Now().Unix() {
-				log.Warn(r.Context(), "Token has expired",
+			id := claims.UserID
+			if err := sessions.ValidateSession(r.Context(), claims); err != nil {
+				log.Warn(r.Context(), "Token session is no longer valid",
 					logger.String("middleware", "JwtVerifyToken"),
-					logger.Any("expired", time.Unix(exp, 0)),
-					logger.Any("now", time.Now()))
-				response.Json(w, http.StatusUnauthorized, "Token has expired", nil)
+					logger.String("userId", id),
+					logger.Error(err))
+				response.Err(w, err)
 				return
 			}
 
@@ -122,6 +103,23 @@ func JwtAuth(log logger.Logger, authConfig config.AuthConfig) func(http.Handler)
 				logger.String("middleware", "JwtVerifyToken"),
 				logger.String("userId", id))
 
+			ctx := context.WithValue(r.Context(), userIDContextKey{}, id)
+			ctx = context.WithValue(ctx, roleContextKey{}, claims.Role)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireRole rejects requests unless they were authenticated with a JWT issued to a
+// user holding the role. Requests authenticated with an API key carry no role.
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if granted, _ := r.Context().Value(roleContextKey{}).(string); granted != role {
+				response.Err(w, errors.ForbiddenError("requires the "+role+" role"))
+				return
+			}
+
 			next.ServeHTTP(w, r)
 		})
 	}
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httpmiddleware
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+type clientIPContextKey struct{}
+
+// ResolveClientIP determines the address of the client of every request for ClientIP.
+// Forwarding headers are only honoured on connections from the trusted proxies, as any
+// other client can set them to whatever it likes.
+func ResolveClientIP(trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), clientIPContextKey{}, resolveClientIP(r, trustedProxies))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ClientIP returns the address of the client that made the request, as determined by
+// ResolveClientIP, or the address of the connection peer when it did not run.
+func ClientIP(r *http.Request) string {
+	if clientIP, ok := r.Context().Value(clientIPContextKey{}).(string); ok {
+		return clientIP
+	}
+	if peer, ok := peerAddr(r); ok {
+		return peer.String()
+	}
+	return r.RemoteAddr
+}
+
+func resolveClientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	peer, ok := peerAddr(r)
+	if !ok {
+		return r.RemoteAddr
+	}
+	if !isTrustedProxy(peer, trustedProxies) {
+		return peer.String()
+	}
+
+	// Every proxy appends the address it received the request from, so the hops are
+	// read from the right: the first one not added for a trusted proxy is the client.
+	// Hops further left were sent by the client and cannot be relied on.
+	hops := forwardedHops(r.Header.Values("X-Forwarded-For"))
+	if len(hops) == 0 {
+		if realIP, ok := parseAddr(r.Header.Get("X-Real-IP")); ok {
+			return realIP.String()
+		}
+		return peer.String()
+	}
+
+	client := peer
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, ok := parseAddr(hops[i])
+		if !ok {
+			break
+		}
+		client = hop
+		if !isTrustedProxy(client, trustedProxies) {
+			break
+		}
+	}
+	return client.String()
+}
+
+// forwardedHops splits X-Forwarded-For headers into their hops, in the order they were added
+func forwardedHops(headers []string) []string {
+	var hops []string
+	for _, header := range headers {
+		for hop := range strings.SplitSeq(header, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	return hops
+}
+
+func peerAddr(r *http.Request) (netip.Addr, bool) {
+	return parseAddr(r.RemoteAddr)
+}
+
+// parseAddr reads an address with or without a port
+func parseAddr(value string) (netip.Addr, bool) {
+	value = strings.TrimSpace(value)
+	if addr, err := netip.ParseAddr(value); err == nil {
+		return addr.Unmap(), true
+	}
+	if addrPort, err := netip.ParseAddrPort(value); err == nil {
+		return addrPort.Addr().Unmap(), true
+	}
+	return netip.Addr{}, false
+}
+
+func isTrustedProxy(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	for _, proxy := range trustedProxies {
+		if proxy.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
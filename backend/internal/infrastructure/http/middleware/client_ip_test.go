@@ -0,0 +1,83 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httpmiddleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestResolveClientIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("fd00::/8")}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		realIP       string
+		want         string
+	}{
+		{"Direct", "203.0.113.7:5000", nil, "", "203.0.113.7"},
+		{"UntrustedPeerForwardedFor", "203.0.113.7:5000", []string{"198.51.100.1"}, "", "203.0.113.7"},
+		{"UntrustedPeerRealIP", "203.0.113.7:5000", nil, "198.51.100.1", "203.0.113.7"},
+		{"TrustedProxy", "10.0.0.2:5000", []string{"198.51.100.1"}, "", "198.51.100.1"},
+		{"SpoofedFirstHop", "10.0.0.2:5000", []string{"1.2.3.4, 198.51.100.1"}, "", "198.51.100.1"},
+		{"ProxyChain", "10.0.0.2:5000", []string{"1.2.3.4, 198.51.100.1, 10.0.0.3"}, "", "198.51.100.1"},
+		{"RepeatedHeaders", "10.0.0.2:5000", []string{"1.2.3.4", "198.51.100.1, 10.0.0.3"}, "", "198.51.100.1"},
+		{"OnlyProxies", "10.0.0.2:5000", []string{"10.0.0.4, 10.0.0.3"}, "", "10.0.0.4"},
+		{"MalformedHop", "10.0.0.2:5000", []string{"198.51.100.1, bogus, 10.0.0.3"}, "", "10.0.0.3"},
+		{"HopWithPort", "10.0.0.2:5000", []string{"198.51.100.1:1234"}, "", "198.51.100.1"},
+		{"TrustedProxyRealIP", "10.0.0.2:5000", nil, "198.51.100.1", "198.51.100.1"},
+		{"ForwardedForWinsOverRealIP", "10.0.0.2:5000", []string{"198.51.100.1"}, "1.2.3.4", "198.51.100.1"},
+		{"IPv6", "[fd00::2]:5000", []string{"2001:db8::1"}, "", "2001:db8::1"},
+		{"MappedIPv4", "[::ffff:10.0.0.2]:5000", []string{"198.51.100.1"}, "", "198.51.100.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, value := range tt.forwardedFor {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+			if tt.realIP != "" {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+
+			var got string
+			ResolveClientIP(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = ClientIP(r)
+			})).ServeHTTP(httptest.NewRecorder(), r)
+			if got != tt.want {
+				t.Fatalf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientIPWithoutResolver(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "203.0.113.7:5000"
+	r.Header.Set("X-Forwarded-For", "198.51.100.1")
+	r.Header.Set("X-Real-IP", "198.51.100.1")
+
+	if got := ClientIP(r); got != "203.0.113.7" {
+		t.Fatalf("ClientIP() = %q, want the connection peer", got)
+	}
+}
@@ -56,6 +56,7 @@ func RequestLogger(domainLogger logger.Logger) func(next http.Handler) http.Hand
 			// Use domain logger with structured fields
 			logFields := []logger.Field{
 				logger.String("remote_addr", remoteAddr),
+				logger.String("client_ip", ClientIP(r)),
 				logger.String("method", method),
 				logger.String("path", path),
 				logger.Int("status", statusCode),
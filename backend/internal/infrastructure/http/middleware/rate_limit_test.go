@@ -0,0 +1,119 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httpmiddleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	zl "github.com/rs/zerolog"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/logging/zerolog"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/ratelimit"
+)
+
+type testSecurityConfig struct {
+	config.SecurityConfig
+}
+
+func (c testSecurityConfig) RateLimitEnabled() bool { return true }
+
+func (c testSecurityConfig) RateLimitPolicy(name string) (config.RateLimitPolicy, bool) {
+	return config.RateLimitPolicy{Requests: 2, Window: time.Minute}, name == "login"
+}
+
+// newRateLimited serves the login policy behind client IP resolution without trusted proxies
+func newRateLimited(auth func(http.Handler) http.Handler) http.Handler {
+	log := zerolog.NewWithLogger(zl.Nop())
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	limited := RateLimit(log, testSecurityConfig{}, ratelimit.NewMemoryLimiter(), "login")(ok)
+	if auth != nil {
+		limited = auth(limited)
+	}
+	return ResolveClientIP(nil)(limited)
+}
+
+func TestRateLimitIgnoresClientHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{"ID", "id"},
+		{"ForwardedFor", "X-Forwarded-For"},
+		{"RealIP", "X-Real-IP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newRateLimited(nil)
+
+			for i := range 3 {
+				r := httptest.NewRequest(http.MethodPost, "/api/user/login", nil)
+				r.RemoteAddr = "203.0.113.7:5000"
+				r.Header.Set(tt.header, "198.51.100."+strconv.Itoa(i))
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, r)
+
+				want := http.StatusOK
+				if i == 2 {
+					want = http.StatusTooManyRequests
+				}
+				if w.Code != want {
+					t.Fatalf("request %d: status = %d, want %d", i+1, w.Code, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitCountsAuthenticatedUsers(t *testing.T) {
+	// Stands in for JwtAuth, authenticating the user named by the test header
+	auth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), userIDContextKey{}, r.Header.Get("X-Test-User"))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+	handler := newRateLimited(auth)
+
+	send := func(user string) int {
+		r := httptest.NewRequest(http.MethodPost, "/api/user/login", nil)
+		r.RemoteAddr = "203.0.113.7:5000"
+		r.Header.Set("X-Test-User", user)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	for i := range 2 {
+		if code := send("alice"); code != http.StatusOK {
+			t.Fatalf("request %d of alice: status = %d, want %d", i+1, code, http.StatusOK)
+		}
+	}
+	if code := send("alice"); code != http.StatusTooManyRequests {
+		t.Fatalf("third request of alice: status = %d, want %d", code, http.StatusTooManyRequests)
+	}
+	// Users sharing an address are limited separately
+	if code := send("bob"); code != http.StatusOK {
+		t.Fatalf("request of bob: status = %d, want %d", code, http.StatusOK)
+	}
+}
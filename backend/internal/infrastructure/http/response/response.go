@@ -65,6 +65,10 @@ func mapDomainErrorToHTTP(err error) (int, string) {
 		return http.StatusUnauthorized, err.Error()
 	case domainErrors.ErrorTypeForbidden:
 		return http.StatusForbidden, err.Error()
+	case domainErrors.ErrorTypeGone:
+		return http.StatusGone, err.Error()
+	case domainErrors.ErrorTypeTooManyRequests:
+		return http.StatusTooManyRequests, err.Error()
 	case domainErrors.ErrorTypeInternal:
 		return http.StatusInternalServerError, "Something went wrong!"
 	default:
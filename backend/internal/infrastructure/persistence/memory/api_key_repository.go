@@ -0,0 +1,115 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/user/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/user/repository"
+)
+
+type apiKeyRepository struct {
+	store *Store
+}
+
+// NewAPIKeyRepository creates a new in-memory API key repository
+func NewAPIKeyRepository(store *Store) repository.APIKeyRepository {
+	return &apiKeyRepository{store: store}
+}
+
+func cloneAPIKey(key *entity.APIKey, lastUsedAt *time.Time) *entity.APIKey {
+	return entity.NewAPIKeyFromRepository(
+		key.ID(),
+		key.UserID(),
+		key.Name(),
+		key.Prefix(),
+		key.KeyHash(),
+		append([]string(nil), key.Scopes()...),
+		copyTime(key.ExpiresAt()),
+		copyTime(lastUsedAt),
+		key.CreatedAt(),
+	)
+}
+
+func (r *apiKeyRepository) Save(ctx context.Context, key *entity.APIKey) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.apiKeyIDsByPrefix[key.Prefix()]; exists {
+		return errors.ConflictError("API key prefix already exists")
+	}
+
+	r.store.apiKeys[key.ID()] = cloneAPIKey(key, key.LastUsedAt())
+	r.store.apiKeyIDsByPrefix[key.Prefix()] = key.ID()
+	return nil
+}
+
+func (r *apiKeyRepository) FindByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	id, ok := r.store.apiKeyIDsByPrefix[prefix]
+	if !ok {
+		return nil, errors.NotFoundError("API key not found")
+	}
+	key := r.store.apiKeys[id]
+	return cloneAPIKey(key, key.LastUsedAt()), nil
+}
+
+func (r *apiKeyRepository) FindByUserID(ctx context.Context, userID string) ([]*entity.APIKey, error) {
+	r.store.mu.RLock()
+	var keys []*entity.APIKey
+	for _, key := range r.store.apiKeys {
+		if key.UserID() == userID {
+			keys = append(keys, cloneAPIKey(key, key.LastUsedAt()))
+		}
+	}
+	r.store.mu.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt().After(keys[j].CreatedAt())
+	})
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Delete(ctx context.Context, id, userID string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key, ok := r.store.apiKeys[id]
+	if !ok || key.UserID() != userID {
+		return errors.NotFoundError("API key not found")
+	}
+
+	delete(r.store.apiKeys, id)
+	delete(r.store.apiKeyIDsByPrefix, key.Prefix())
+	return nil
+}
+
+func (r *apiKeyRepository) UpdateLastUsed(ctx context.Context, id string, lastUsedAt time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if key, ok := r.store.apiKeys[id]; ok {
+		r.store.apiKeys[id] = cloneAPIKey(key, &lastUsedAt)
+	}
+	return nil
+}
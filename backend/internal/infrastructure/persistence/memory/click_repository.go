@@ -0,0 +1,115 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+)
+
+type clickRepository struct {
+	store *Store
+}
+
+// NewClickRepository creates a new in-memory click repository
+func NewClickRepository(store *Store) repository.ClickRepository {
+	return &clickRepository{store: store}
+}
+
+func (r *clickRepository) SaveBatch(ctx context.Context, clicks []*entity.Click) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Clicks are immutable, so they can be stored without copying. Clicks for URLs
+	// deleted in the meantime are dropped.
+	for _, click := range clicks {
+		if _, ok := r.store.urls[click.URLID()]; !ok {
+			continue
+		}
+		r.store.clicks[click.URLID()] = append(r.store.clicks[click.URLID()], click)
+	}
+
+	return nil
+}
+
+func (r *clickRepository) GetStats(
+	ctx context.Context,
+	urlID string,
+	from, to time.Time,
+	interval entity.ClickInterval,
+	top int,
+) (*entity.ClickStats, error) {
+	buckets := make(map[time.Time]int)
+	referrers := make(map[string]int)
+	userAgents := make(map[string]int)
+	stats := &entity.ClickStats{}
+
+	r.store.mu.RLock()
+	for _, click := range r.store.clicks[urlID] {
+		if click.ClickedAt().Before(from) || !click.ClickedAt().Before(to) {
+			continue
+		}
+
+		stats.Total++
+		buckets[interval.Truncate(click.ClickedAt())]++
+		referrers[valueOr(click.Referrer(), "direct")]++
+		userAgents[valueOr(click.UserAgent(), "unknown")]++
+	}
+	r.store.mu.RUnlock()
+
+	for start, count := range buckets {
+		stats.Timeline = append(stats.Timeline, entity.ClickBucket{Start: start, Count: count})
+	}
+	sort.Slice(stats.Timeline, func(i, j int) bool {
+		return stats.Timeline[i].Start.Before(stats.Timeline[j].Start)
+	})
+
+	stats.TopReferrers = topCounts(referrers, top)
+	stats.TopUserAgents = topCounts(userAgents, top)
+
+	return stats, nil
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// topCounts orders counts like the SQL implementation: by count, then by value
+func topCounts(counts map[string]int, top int) []entity.ClickCount {
+	result := make([]entity.ClickCount, 0, len(counts))
+	for value, count := range counts {
+		result = append(result, entity.ClickCount{Value: value, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Value < result[j].Value
+	})
+
+	if top < len(result) {
+		result = result[:top]
+	}
+	return result
+}
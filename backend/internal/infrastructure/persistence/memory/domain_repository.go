@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+)
+
+type domainRepository struct {
+	store *Store
+}
+
+// NewDomainRepository creates a new in-memory domain repository
+func NewDomainRepository(store *Store) repository.DomainRepository {
+	return &domainRepository{store: store}
+}
+
+func cloneDomain(domain *entity.Domain, verifiedAt *time.Time) *entity.Domain {
+	return entity.NewDomainFromRepository(
+		domain.ID(),
+		domain.UserID(),
+		domain.Hostname(),
+		domain.VerificationToken(),
+		copyTime(verifiedAt),
+		domain.CreatedAt(),
+	)
+}
+
+func (r *domainRepository) Save(ctx context.Context, domain *entity.Domain) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, stored := range r.store.domains {
+		if stored.ID() == domain.ID() ||
+			(stored.UserID() == domain.UserID() && stored.Hostname() == domain.Hostname()) {
+			return errors.ConflictError("domain already exists")
+		}
+	}
+
+	r.store.domains[domain.ID()] = cloneDomain(domain, domain.VerifiedAt())
+	return nil
+}
+
+func (r *domainRepository) FindByID(ctx context.Context, id string) (*entity.Domain, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	domain, ok := r.store.domains[id]
+	if !ok {
+		return nil, errors.NotFoundError("domain not found")
+	}
+	return cloneDomain(domain, domain.VerifiedAt()), nil
+}
+
+func (r *domainRepository) FindByUserID(ctx context.Context, userID string) ([]*entity.Domain, error) {
+	r.store.mu.RLock()
+	var domains []*entity.Domain
+	for _, domain := range r.store.domains {
+		if domain.UserID() == userID {
+			domains = append(domains, cloneDomain(domain, domain.VerifiedAt()))
+		}
+	}
+	r.store.mu.RUnlock()
+
+	sort.Slice(domains, func(i, j int) bool {
+		return domains[i].Hostname() < domains[j].Hostname()
+	})
+	return domains, nil
+}
+
+func (r *domainRepository) FindVerified(ctx context.Context, hostname string) (*entity.Domain, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if domain := r.verified(hostname); domain != nil {
+		return cloneDomain(domain, domain.VerifiedAt()), nil
+	}
+	return nil, errors.NotFoundError("domain not found")
+}
+
+// verified returns the domain verified for the hostname, if any. The caller must hold
+// the store lock.
+func (r *domainRepository) verified(hostname string) *entity.Domain {
+	for _, domain := range r.store.domains {
+		if domain.Hostname() == hostname && domain.IsVerified() {
+			return domain
+		}
+	}
+	return nil
+}
+
+func (r *domainRepository) MarkVerified(ctx context.Context, id string, verifiedAt time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	domain, ok := r.store.domains[id]
+	if !ok {
+		return errors.NotFoundError("domain not found")
+	}
+	if domain.IsVerified() {
+		return nil
+	}
+	if r.verified(domain.Hostname()) != nil {
+		return errors.ConflictError("domain is already verified by another user")
+	}
+
+	verifiedAt = verifiedAt.UTC()
+	r.store.domains[id] = cloneDomain(domain, &verifiedAt)
+	return nil
+}
+
+func (r *domainRepository) Delete(ctx context.Context, id, userID string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	domain, ok := r.store.domains[id]
+	if !ok || domain.UserID() != userID {
+		return errors.NotFoundError("domain not found")
+	}
+
+	delete(r.store.domains, id)
+	return nil
+}
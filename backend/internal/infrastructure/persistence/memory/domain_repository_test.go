@@ -0,0 +1,32 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"testing"
+
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository/repositorytest"
+)
+
+func TestDomainRepository(t *testing.T) {
+	repositorytest.TestDomainRepository(t, repositorytest.DomainRepositoryHarness{
+		New: func(t *testing.T) repository.DomainRepository {
+			return NewDomainRepository(NewStore())
+		},
+	})
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/user/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/user/repository"
+)
+
+type refreshTokenRepository struct {
+	store *Store
+}
+
+// NewRefreshTokenRepository creates a new in-memory refresh token repository
+func NewRefreshTokenRepository(store *Store) repository.RefreshTokenRepository {
+	return &refreshTokenRepository{store: store}
+}
+
+func cloneRefreshToken(token *entity.RefreshToken) *entity.RefreshToken {
+	return entity.NewRefreshTokenFromRepository(
+		token.ID(),
+		token.UserID(),
+		token.FamilyID(),
+		token.TokenHash(),
+		token.ExpiresAt(),
+		copyTime(token.RevokedAt()),
+		token.CreatedAt(),
+	)
+}
+
+func (r *refreshTokenRepository) Save(ctx context.Context, token *entity.RefreshToken) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.refreshTokenIDsByHash[token.TokenHash()]; exists {
+		return errors.InternalError("database operation failed")
+	}
+
+	r.store.refreshTokens[token.ID()] = cloneRefreshToken(token)
+	r.store.refreshTokenIDsByHash[token.TokenHash()] = token.ID()
+	return nil
+}
+
+func (r *refreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	id, ok := r.store.refreshTokenIDsByHash[tokenHash]
+	if !ok {
+		return nil, errors.NotFoundError("refresh token not found")
+	}
+	return cloneRefreshToken(r.store.refreshTokens[id]), nil
+}
+
+func (r *refreshTokenRepository) Revoke(ctx context.Context, id string) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	token, ok := r.store.refreshTokens[id]
+	if !ok || token.IsRevoked() {
+		return false, nil
+	}
+
+	r.revoke(token, time.Now().UTC())
+	return true, nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return r.revokeWhere(func(token *entity.RefreshToken) bool {
+		return token.FamilyID() == familyID
+	})
+}
+
+func (r *refreshTokenRepository) RevokeByUserID(ctx context.Context, userID string) error {
+	return r.revokeWhere(func(token *entity.RefreshToken) bool {
+		return token.UserID() == userID
+	})
+}
+
+func (r *refreshTokenRepository) revokeWhere(match func(token *entity.RefreshToken) bool) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now().UTC()
+	for _, token := range r.store.refreshTokens {
+		if match(token) && !token.IsRevoked() {
+			r.revoke(token, now)
+		}
+	}
+	return nil
+}
+
+// revoke replaces a stored token with a revoked copy; the caller must hold the lock
+func (r *refreshTokenRepository) revoke(token *entity.RefreshToken, now time.Time) {
+	r.store.refreshTokens[token.ID()] = entity.NewRefreshTokenFromRepository(
+		token.ID(),
+		token.UserID(),
+		token.FamilyID(),
+		token.TokenHash(),
+		token.ExpiresAt(),
+		&now,
+		token.CreatedAt(),
+	)
+}
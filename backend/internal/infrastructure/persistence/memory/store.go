@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package memory implements the repositories on top of in-process maps. It lets the
+// service run without PostgreSQL for local development and tests; nothing is persisted
+// across restarts.
+package memory
+
+import (
+	"sync"
+	"time"
+
+	urlEntity "github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+	userEntity "github.com/PraveenGongada/shortly/internal/domain/user/entity"
+	workspaceEntity "github.com/PraveenGongada/shortly/internal/domain/workspace/entity"
+)
+
+// Store holds the data of every in-memory repository behind a single lock, playing
+// the role of the database so that repositories sharing a store see the same data.
+// Entities are copied on the way in and out, so callers never share state with it.
+type Store struct {
+	mu sync.RWMutex
+
+	urls         map[string]*urlEntity.URL
+	urlIDsByLink map[repository.ShortLink]string
+	clicks       map[string][]*urlEntity.Click
+	// revisions of each URL, oldest first, numbered from lastRevisionID
+	revisions      map[string][]*urlEntity.URLRevision
+	lastRevisionID int64
+	domains        map[string]*urlEntity.Domain
+
+	users          map[string]*userEntity.User
+	userIDsByEmail map[string]string
+
+	refreshTokens         map[string]*userEntity.RefreshToken
+	refreshTokenIDsByHash map[string]string
+	apiKeys               map[string]*userEntity.APIKey
+	apiKeyIDsByPrefix     map[string]string
+
+	workspaces map[string]*workspaceEntity.Workspace
+	// members of each workspace, by user ID
+	members     map[string]map[string]*workspaceEntity.Member
+	invitations map[string]*workspaceEntity.Invitation
+}
+
+// NewStore creates an empty in-memory store
+func NewStore() *Store {
+	return &Store{
+		urls:                  make(map[string]*urlEntity.URL),
+		urlIDsByLink:          make(map[repository.ShortLink]string),
+		clicks:                make(map[string][]*urlEntity.Click),
+		revisions:             make(map[string][]*urlEntity.URLRevision),
+		domains:               make(map[string]*urlEntity.Domain),
+		users:                 make(map[string]*userEntity.User),
+		userIDsByEmail:        make(map[string]string),
+		refreshTokens:         make(map[string]*userEntity.RefreshToken),
+		refreshTokenIDsByHash: make(map[string]string),
+		apiKeys:               make(map[string]*userEntity.APIKey),
+		apiKeyIDsByPrefix:     make(map[string]string),
+		workspaces:            make(map[string]*workspaceEntity.Workspace),
+		members:               make(map[string]map[string]*workspaceEntity.Member),
+		invitations:           make(map[string]*workspaceEntity.Invitation),
+	}
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+func copyInt(i *int) *int {
+	if i == nil {
+		return nil
+	}
+	c := *i
+	return &c
+}
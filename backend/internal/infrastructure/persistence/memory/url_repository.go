@@ -0,0 +1,548 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+)
+
+type urlRepository struct {
+	store *Store
+}
+
+// NewURLRepository creates a new in-memory URL repository
+func NewURLRepository(store *Store) repository.URLRepository {
+	return &urlRepository{store: store}
+}
+
+// cloneURL copies a URL so that stored entities are never shared with callers
+func cloneURL(url *entity.URL) *entity.URL {
+	return entity.NewURLFromRepository(copySnapshot(url))
+}
+
+// copySnapshot returns the state of a URL without any pointers or tags shared with it
+func copySnapshot(url *entity.URL) entity.URLSnapshot {
+	snapshot := url.Snapshot()
+	snapshot.ExpiresAt = copyTime(snapshot.ExpiresAt)
+	snapshot.MaxClicks = copyInt(snapshot.MaxClicks)
+	snapshot.Tags = slices.Clone(snapshot.Tags)
+	snapshot.UpdatedAt = copyTime(snapshot.UpdatedAt)
+	snapshot.DeletedAt = copyTime(snapshot.DeletedAt)
+	snapshot.DisabledAt = copyTime(snapshot.DisabledAt)
+	return snapshot
+}
+
+func (r *urlRepository) Save(ctx context.Context, url *entity.URL) (*entity.URL, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.conflicts(url) {
+		return nil, errors.ConflictError("short code already exists")
+	}
+
+	return cloneURL(r.insert(url)), nil
+}
+
+func (r *urlRepository) SaveBatch(ctx context.Context, urls []*entity.URL) ([]*entity.URL, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	savedURLs := make([]*entity.URL, len(urls))
+	for i, url := range urls {
+		if r.conflicts(url) {
+			continue
+		}
+		savedURLs[i] = cloneURL(r.insert(url))
+	}
+
+	return savedURLs, nil
+}
+
+// conflicts reports whether the id or short code of the URL is already stored.
+// The caller must hold the store lock.
+func (r *urlRepository) conflicts(url *entity.URL) bool {
+	if _, exists := r.store.urlIDsByLink[shortLink(url)]; exists {
+		return true
+	}
+	_, exists := r.store.urls[url.ID()]
+	return exists
+}
+
+// insert stores a copy of a new URL. The caller must hold the store lock.
+func (r *urlRepository) insert(url *entity.URL) *entity.URL {
+	// Like the database, a new row starts without an update time
+	snapshot := copySnapshot(url)
+	snapshot.UpdatedAt = nil
+	snapshot.DeletedAt = nil
+	snapshot.DisabledAt = nil
+	snapshot.DisabledReason = ""
+	saved := entity.NewURLFromRepository(snapshot)
+	r.store.urls[saved.ID()] = saved
+	r.store.urlIDsByLink[shortLink(saved)] = saved.ID()
+	return saved
+}
+
+func shortLink(url *entity.URL) repository.ShortLink {
+	return repository.ShortLink{Domain: url.Domain(), ShortCode: url.ShortCode()}
+}
+
+func (r *urlRepository) FindByShortCode(ctx context.Context, domain, shortCode string) (*entity.URL, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	id, ok := r.store.urlIDsByLink[repository.ShortLink{Domain: domain, ShortCode: shortCode}]
+	if !ok || r.store.urls[id].IsDeleted() {
+		return nil, errors.NotFoundError("URL not found")
+	}
+	return cloneURL(r.store.urls[id]), nil
+}
+
+func (r *urlRepository) FindByID(ctx context.Context, id string) (*entity.URL, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	url, ok := r.store.urls[id]
+	if !ok || url.IsDeleted() {
+		return nil, errors.NotFoundError("URL not found")
+	}
+	return cloneURL(url), nil
+}
+
+func (r *urlRepository) FindDeletedByID(ctx context.Context, id string) (*entity.URL, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	url, ok := r.store.urls[id]
+	if !ok || !url.IsDeleted() {
+		return nil, errors.NotFoundError("URL not found in trash")
+	}
+	return cloneURL(url), nil
+}
+
+func (r *urlRepository) FindByWorkspaceID(
+	ctx context.Context,
+	workspaceID string,
+	filter repository.URLFilter,
+	page repository.URLPage,
+) ([]*entity.URL, error) {
+	return listURLs(r.inWorkspace(workspaceID), filter, page), nil
+}
+
+func (r *urlRepository) FindAll(
+	ctx context.Context,
+	filter repository.URLFilter,
+	page repository.URLPage,
+) ([]*entity.URL, error) {
+	return listURLs(r.inWorkspace(""), filter, page), nil
+}
+
+// listURLs returns the page of the URLs matching the filter
+func listURLs(urls []*entity.URL, filter repository.URLFilter, page repository.URLPage) []*entity.URL {
+	urls = slices.DeleteFunc(urls, func(url *entity.URL) bool {
+		return !matchesFilter(url, filter) ||
+			(page.After != nil && compareURLs(page.Sort, repository.NewURLCursor(url), *page.After) <= 0)
+	})
+
+	slices.SortFunc(urls, func(a, b *entity.URL) int {
+		return compareURLs(page.Sort, repository.NewURLCursor(a), repository.NewURLCursor(b))
+	})
+
+	if page.Limit < len(urls) {
+		urls = urls[:page.Limit]
+	}
+
+	return urls
+}
+
+// compareURLs compares the positions of two URLs in a listing in the given order, returning
+// a negative number when a is listed first
+func compareURLs(order repository.URLSort, a, b repository.URLCursor) int {
+	switch order {
+	case repository.SortUpdated:
+		if c := b.UpdatedAt.Compare(a.UpdatedAt); c != 0 {
+			return c
+		}
+	case repository.SortRedirects:
+		if c := cmp.Compare(b.Redirects, a.Redirects); c != 0 {
+			return c
+		}
+	case repository.SortAlphabetical:
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	default:
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+	}
+	return strings.Compare(b.ID, a.ID)
+}
+
+func (r *urlRepository) CountByWorkspaceID(
+	ctx context.Context,
+	workspaceID string,
+	filter repository.URLFilter,
+) (int, error) {
+	return countURLs(r.inWorkspace(workspaceID), filter), nil
+}
+
+func (r *urlRepository) CountAll(ctx context.Context, filter repository.URLFilter) (int, error) {
+	return countURLs(r.inWorkspace(""), filter), nil
+}
+
+func countURLs(urls []*entity.URL, filter repository.URLFilter) int {
+	count := 0
+	for _, url := range urls {
+		if matchesFilter(url, filter) {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *urlRepository) Totals(ctx context.Context) (repository.URLTotals, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var totals repository.URLTotals
+	for _, url := range r.store.urls {
+		switch {
+		case url.IsDeleted():
+			totals.Deleted++
+		case url.IsDisabled():
+			totals.Active++
+			totals.Disabled++
+		default:
+			totals.Active++
+		}
+		totals.Redirects += url.Redirects()
+	}
+	return totals, nil
+}
+
+func (r *urlRepository) ForEachByWorkspaceID(
+	ctx context.Context,
+	workspaceID string,
+	fn func(url *entity.URL) error,
+) error {
+	urls := slices.DeleteFunc(r.inWorkspace(workspaceID), (*entity.URL).IsDeleted)
+
+	sort.Slice(urls, func(i, j int) bool {
+		if !urls[i].CreatedAt().Equal(urls[j].CreatedAt()) {
+			return urls[i].CreatedAt().Before(urls[j].CreatedAt())
+		}
+		return urls[i].ID() < urls[j].ID()
+	})
+
+	for _, url := range urls {
+		if err := fn(url); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchesFilter applies a URL filter the way the database does
+func matchesFilter(url *entity.URL, filter repository.URLFilter) bool {
+	if url.IsDeleted() != filter.Deleted {
+		return false
+	}
+	if filter.UserID != "" && url.UserID() != filter.UserID {
+		return false
+	}
+	for _, tag := range filter.Tags {
+		if !url.HasTag(tag) {
+			return false
+		}
+	}
+	if filter.Search != "" {
+		search := strings.ToLower(filter.Search)
+		if !strings.Contains(strings.ToLower(url.ShortCode()), search) &&
+			!strings.Contains(strings.ToLower(url.LongURL()), search) &&
+			!strings.Contains(strings.ToLower(url.Title()), search) {
+			return false
+		}
+	}
+	if filter.CreatedFrom != nil && url.CreatedAt().Before(*filter.CreatedFrom) {
+		return false
+	}
+	if filter.CreatedTo != nil && !url.CreatedAt().Before(*filter.CreatedTo) {
+		return false
+	}
+	return true
+}
+
+// inWorkspace returns copies of the URLs of the workspace, or of every workspace when
+// workspaceID is empty, so they can be used without the store lock
+func (r *urlRepository) inWorkspace(workspaceID string) []*entity.URL {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var urls []*entity.URL
+	for _, url := range r.store.urls {
+		if workspaceID == "" || url.BelongsTo(workspaceID) {
+			urls = append(urls, cloneURL(url))
+		}
+	}
+	return urls
+}
+
+func (r *urlRepository) ExistsByShortCode(ctx context.Context, domain, shortCode string) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	_, exists := r.store.urlIDsByLink[repository.ShortLink{Domain: domain, ShortCode: shortCode}]
+	return exists, nil
+}
+
+func (r *urlRepository) ExistsByShortCodes(
+	ctx context.Context,
+	links []repository.ShortLink,
+) (map[repository.ShortLink]bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	existing := make(map[repository.ShortLink]bool)
+	for _, link := range links {
+		if _, exists := r.store.urlIDsByLink[link]; exists {
+			existing[link] = true
+		}
+	}
+	return existing, nil
+}
+
+func (r *urlRepository) ExistsByDomain(ctx context.Context, domain string) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for link := range r.store.urlIDsByLink {
+		if link.Domain == domain {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *urlRepository) Update(ctx context.Context, url *entity.URL) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.urls[url.ID()]
+	if !ok || stored.IsDeleted() {
+		return errors.NotFoundError("URL not found")
+	}
+
+	now := time.Now().UTC()
+	// Only the fields a user can change are taken from the URL
+	snapshot := copySnapshot(url)
+	snapshot.UserID = stored.UserID()
+	snapshot.WorkspaceID = stored.WorkspaceID()
+	snapshot.Domain = stored.Domain()
+	snapshot.ShortCode = stored.ShortCode()
+	snapshot.Redirects = stored.Redirects()
+	snapshot.CreatedAt = stored.CreatedAt()
+	snapshot.UpdatedAt = &now
+	snapshot.DeletedAt = nil
+	snapshot.DisabledAt = copyTime(stored.DisabledAt())
+	snapshot.DisabledReason = stored.DisabledReason()
+	r.store.urls[url.ID()] = entity.NewURLFromRepository(snapshot)
+
+	if revision := url.PendingRevision(); revision != nil {
+		r.store.lastRevisionID++
+		r.store.revisions[url.ID()] = append(r.store.revisions[url.ID()], entity.NewURLRevisionFromRepository(
+			r.store.lastRevisionID,
+			url.ID(),
+			revision.OldURL(),
+			revision.NewURL(),
+			revision.ActorID(),
+			revision.CreatedAt(),
+		))
+	}
+
+	return nil
+}
+
+func (r *urlRepository) MoveToTrash(ctx context.Context, id, workspaceID string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	url, ok := r.store.urls[id]
+	if !ok || !url.BelongsTo(workspaceID) || url.IsDeleted() {
+		return errors.NotFoundError("URL not found or not authorized")
+	}
+
+	// Stored entities are private to the store, so they can be changed in place
+	url.MoveToTrash(time.Now())
+	return nil
+}
+
+func (r *urlRepository) Restore(ctx context.Context, id, workspaceID string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	url, ok := r.store.urls[id]
+	if !ok || !url.BelongsTo(workspaceID) || !url.IsDeleted() {
+		return errors.NotFoundError("URL not found in trash")
+	}
+
+	url.Restore()
+	return nil
+}
+
+func (r *urlRepository) Disable(ctx context.Context, id, reason string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	url, ok := r.store.urls[id]
+	if !ok || url.IsDeleted() {
+		return errors.NotFoundError("URL not found")
+	}
+	if !url.IsDisabled() {
+		// Stored entities are private to the store, so they can be changed in place
+		url.Disable(reason, time.Now())
+	}
+	return nil
+}
+
+func (r *urlRepository) FindEnabled(ctx context.Context, afterID string, limit int) ([]*entity.URL, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var urls []*entity.URL
+	for _, url := range r.store.urls {
+		if !url.IsDeleted() && !url.IsDisabled() && url.ID() > afterID {
+			urls = append(urls, url)
+		}
+	}
+	slices.SortFunc(urls, func(a, b *entity.URL) int { return strings.Compare(a.ID(), b.ID()) })
+
+	enabled := make([]*entity.URL, 0, min(limit, len(urls)))
+	for _, url := range urls[:min(limit, len(urls))] {
+		enabled = append(enabled, cloneURL(url))
+	}
+	return enabled, nil
+}
+
+func (r *urlRepository) PurgeTrash(ctx context.Context, deletedBefore time.Time, limit int) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	purged := 0
+	for id, url := range r.store.urls {
+		if purged == limit {
+			break
+		}
+		if !url.IsDeleted() || !url.DeletedAt().Before(deletedBefore) {
+			continue
+		}
+		delete(r.store.urls, id)
+		delete(r.store.urlIDsByLink, shortLink(url))
+		delete(r.store.clicks, id)
+		delete(r.store.revisions, id)
+		purged++
+	}
+
+	return purged, nil
+}
+
+func (r *urlRepository) AddRedirects(ctx context.Context, counts map[string]int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, count := range counts {
+		url, ok := r.store.urls[id]
+		if !ok {
+			continue
+		}
+		// Stored entities are private to the store, so they can be changed in place
+		url.IncludePendingRedirects(count)
+	}
+
+	return nil
+}
+
+func (r *urlRepository) ClaimRedirect(ctx context.Context, id string, pending int) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	url, ok := r.store.urls[id]
+	if !ok {
+		return false, nil
+	}
+	if maxClicks := url.MaxClicks(); maxClicks != nil && url.Redirects()+pending >= *maxClicks {
+		return false, nil
+	}
+	url.IncludePendingRedirects(1)
+	return true, nil
+}
+
+func (r *urlRepository) ListTags(ctx context.Context, workspaceID string) ([]entity.TagCount, error) {
+	counts := make(map[string]int)
+	for _, url := range slices.DeleteFunc(r.inWorkspace(workspaceID), (*entity.URL).IsDeleted) {
+		for _, tag := range url.Tags() {
+			counts[tag]++
+		}
+	}
+
+	tags := make([]entity.TagCount, 0, len(counts))
+	for name, urls := range counts {
+		tags = append(tags, entity.TagCount{Name: name, URLs: urls})
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+func (r *urlRepository) FindRevisions(
+	ctx context.Context,
+	urlID string,
+	before int64,
+	limit int,
+) ([]*entity.URLRevision, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	revisions := make([]*entity.URLRevision, 0, limit)
+	stored := r.store.revisions[urlID]
+	for i := len(stored) - 1; i >= 0 && len(revisions) < limit; i-- {
+		if before == 0 || stored[i].ID() < before {
+			revisions = append(revisions, stored[i])
+		}
+	}
+	return revisions, nil
+}
+
+func (r *urlRepository) FindRevision(ctx context.Context, urlID string, id int64) (*entity.URLRevision, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, revision := range r.store.revisions[urlID] {
+		if revision.ID() == id {
+			return revision, nil
+		}
+	}
+	return nil, errors.NotFoundError("revision not found")
+}
@@ -0,0 +1,284 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/user/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/user/repository"
+)
+
+type userRepository struct {
+	store *Store
+}
+
+// NewUserRepository creates a new in-memory user repository
+func NewUserRepository(store *Store) repository.UserRepository {
+	return &userRepository{store: store}
+}
+
+// cloneUser copies a user so that stored entities are never shared with callers
+func cloneUser(user *entity.User) *entity.User {
+	return entity.NewUserFromRepository(
+		user.ID(),
+		user.Email(),
+		user.HashedPassword(),
+		user.Name(),
+		user.Role(),
+		user.TokenVersion(),
+		user.CreatedAt(),
+		copyTime(user.UpdatedAt()),
+		copyTime(user.SuspendedAt()),
+	)
+}
+
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	id, ok := r.store.userIDsByEmail[email]
+	if !ok {
+		return nil, errors.NotFoundError("user not found")
+	}
+	return cloneUser(r.store.users[id]), nil
+}
+
+func (r *userRepository) FindByID(ctx context.Context, id string) (*entity.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	user, ok := r.store.users[id]
+	if !ok {
+		return nil, errors.NotFoundError("user not found")
+	}
+	return cloneUser(user), nil
+}
+
+func (r *userRepository) Save(ctx context.Context, user *entity.User) (*entity.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.userIDsByEmail[user.Email()]; exists {
+		return nil, errors.ConflictError("email already registered")
+	}
+	if _, exists := r.store.users[user.ID()]; exists {
+		return nil, errors.ConflictError("email already registered")
+	}
+
+	// Like the database, a new row starts with the initial token version, no update
+	// time and no suspension
+	saved := entity.NewUserFromRepository(
+		user.ID(),
+		user.Email(),
+		user.HashedPassword(),
+		user.Name(),
+		user.Role(),
+		0,
+		user.CreatedAt(),
+		nil,
+		nil,
+	)
+	r.store.users[saved.ID()] = saved
+	r.store.userIDsByEmail[saved.Email()] = saved.ID()
+
+	return cloneUser(saved), nil
+}
+
+func (r *userRepository) Update(ctx context.Context, user *entity.User) (*entity.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.users[user.ID()]
+	if !ok {
+		return nil, errors.NotFoundError("user not found")
+	}
+	if id, exists := r.store.userIDsByEmail[user.Email()]; exists && id != user.ID() {
+		return nil, errors.ConflictError("email already registered")
+	}
+
+	updated := entity.NewUserFromRepository(
+		stored.ID(),
+		user.Email(),
+		user.HashedPassword(),
+		user.Name(),
+		stored.Role(),
+		stored.TokenVersion(),
+		stored.CreatedAt(),
+		copyTime(user.UpdatedAt()),
+		stored.SuspendedAt(),
+	)
+	delete(r.store.userIDsByEmail, stored.Email())
+	r.store.users[updated.ID()] = updated
+	r.store.userIDsByEmail[updated.Email()] = updated.ID()
+
+	return cloneUser(updated), nil
+}
+
+func (r *userRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	_, exists := r.store.userIDsByEmail[email]
+	return exists, nil
+}
+
+func (r *userRepository) IncrementTokenVersion(ctx context.Context, id string) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.users[id]
+	if !ok {
+		return 0, errors.NotFoundError("user not found")
+	}
+
+	tokenVersion := stored.TokenVersion() + 1
+	r.store.users[id] = entity.NewUserFromRepository(
+		stored.ID(),
+		stored.Email(),
+		stored.HashedPassword(),
+		stored.Name(),
+		stored.Role(),
+		tokenVersion,
+		stored.CreatedAt(),
+		stored.UpdatedAt(),
+		stored.SuspendedAt(),
+	)
+
+	return tokenVersion, nil
+}
+
+func (r *userRepository) FindAll(
+	ctx context.Context,
+	filter repository.UserFilter,
+	after *repository.UserCursor,
+	limit int,
+) ([]*entity.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var users []*entity.User
+	for _, user := range r.store.users {
+		if matchesUserFilter(user, filter) && (after == nil || compareUsers(user, *after) > 0) {
+			users = append(users, user)
+		}
+	}
+	slices.SortFunc(users, func(a, b *entity.User) int {
+		return compareUsers(a, repository.UserCursor{CreatedAt: b.CreatedAt(), ID: b.ID()})
+	})
+
+	found := make([]*entity.User, 0, min(limit, len(users)))
+	for _, user := range users[:min(limit, len(users))] {
+		found = append(found, cloneUser(user))
+	}
+	return found, nil
+}
+
+// compareUsers compares the position of a user in a listing with a cursor, returning a
+// negative number when the user is listed first
+func compareUsers(user *entity.User, cursor repository.UserCursor) int {
+	if c := cursor.CreatedAt.Compare(user.CreatedAt()); c != 0 {
+		return c
+	}
+	return strings.Compare(cursor.ID, user.ID())
+}
+
+func matchesUserFilter(user *entity.User, filter repository.UserFilter) bool {
+	if filter.Search != "" {
+		search := strings.ToLower(filter.Search)
+		if !strings.Contains(strings.ToLower(user.Email()), search) &&
+			!strings.Contains(strings.ToLower(user.Name()), search) {
+			return false
+		}
+	}
+	if filter.Role != "" && user.Role() != filter.Role {
+		return false
+	}
+	if filter.Suspended && !user.IsSuspended() {
+		return false
+	}
+	return true
+}
+
+func (r *userRepository) CountAll(ctx context.Context, filter repository.UserFilter) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, user := range r.store.users {
+		if matchesUserFilter(user, filter) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *userRepository) Suspend(ctx context.Context, id string, suspendedAt time.Time) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.users[id]
+	if !ok {
+		return 0, errors.NotFoundError("user not found")
+	}
+	if stored.IsSuspended() {
+		suspendedAt = *stored.SuspendedAt()
+	}
+
+	tokenVersion := stored.TokenVersion() + 1
+	r.store.users[id] = entity.NewUserFromRepository(
+		stored.ID(),
+		stored.Email(),
+		stored.HashedPassword(),
+		stored.Name(),
+		stored.Role(),
+		tokenVersion,
+		stored.CreatedAt(),
+		stored.UpdatedAt(),
+		&suspendedAt,
+	)
+
+	return tokenVersion, nil
+}
+
+func (r *userRepository) Unsuspend(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.users[id]
+	if !ok {
+		return errors.NotFoundError("user not found")
+	}
+
+	r.store.users[id] = entity.NewUserFromRepository(
+		stored.ID(),
+		stored.Email(),
+		stored.HashedPassword(),
+		stored.Name(),
+		stored.Role(),
+		stored.TokenVersion(),
+		stored.CreatedAt(),
+		stored.UpdatedAt(),
+		nil,
+	)
+
+	return nil
+}
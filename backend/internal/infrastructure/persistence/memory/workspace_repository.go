@@ -0,0 +1,311 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/repository"
+)
+
+// Workspaces, members and invitations have no setters, so stored entities are never
+// changed and can be handed out as they are. Members are rebuilt on the way out to
+// carry the current email of their user.
+type workspaceRepository struct {
+	store *Store
+}
+
+// NewWorkspaceRepository creates a new in-memory workspace repository
+func NewWorkspaceRepository(store *Store) repository.WorkspaceRepository {
+	return &workspaceRepository{store: store}
+}
+
+func (r *workspaceRepository) Save(
+	ctx context.Context,
+	workspace *entity.Workspace,
+	owner *entity.Member,
+) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, exists := r.store.workspaces[workspace.ID()]; exists {
+		return errors.ConflictError("workspace already exists")
+	}
+	if workspace.IsPersonal() && r.personal(workspace.PersonalUserID()) != nil {
+		return errors.ConflictError("user already has a personal workspace")
+	}
+
+	r.store.workspaces[workspace.ID()] = workspace
+	r.store.members[workspace.ID()] = map[string]*entity.Member{owner.UserID(): owner}
+	return nil
+}
+
+func (r *workspaceRepository) FindByID(ctx context.Context, id string) (*entity.Workspace, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	workspace, ok := r.store.workspaces[id]
+	if !ok {
+		return nil, errors.NotFoundError("workspace not found")
+	}
+	return workspace, nil
+}
+
+func (r *workspaceRepository) FindPersonal(ctx context.Context, userID string) (*entity.Workspace, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if workspace := r.personal(userID); workspace != nil {
+		return workspace, nil
+	}
+	return nil, errors.NotFoundError("workspace not found")
+}
+
+// personal returns the personal workspace of the user, if any. The caller must hold
+// the store lock.
+func (r *workspaceRepository) personal(userID string) *entity.Workspace {
+	for _, workspace := range r.store.workspaces {
+		if workspace.PersonalUserID() == userID {
+			return workspace
+		}
+	}
+	return nil
+}
+
+func (r *workspaceRepository) FindByUserID(ctx context.Context, userID string) ([]entity.Membership, error) {
+	r.store.mu.RLock()
+	var memberships []entity.Membership
+	for id, members := range r.store.members {
+		if member, ok := members[userID]; ok {
+			memberships = append(memberships, entity.Membership{
+				Workspace: r.store.workspaces[id],
+				Role:      member.Role(),
+			})
+		}
+	}
+	r.store.mu.RUnlock()
+
+	slices.SortFunc(memberships, func(a, b entity.Membership) int {
+		if a.Workspace.IsPersonal() != b.Workspace.IsPersonal() {
+			if a.Workspace.IsPersonal() {
+				return -1
+			}
+			return 1
+		}
+		if c := strings.Compare(a.Workspace.Name(), b.Workspace.Name()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Workspace.ID(), b.Workspace.ID())
+	})
+	return memberships, nil
+}
+
+func (r *workspaceRepository) FindMember(ctx context.Context, workspaceID, userID string) (*entity.Member, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	member, ok := r.store.members[workspaceID][userID]
+	if !ok {
+		return nil, errors.NotFoundError("member not found")
+	}
+	return r.withEmail(member), nil
+}
+
+// withEmail returns a copy of the member carrying the email of its user. The caller
+// must hold the store lock.
+func (r *workspaceRepository) withEmail(member *entity.Member) *entity.Member {
+	var email string
+	if user, ok := r.store.users[member.UserID()]; ok {
+		email = user.Email()
+	}
+	return entity.NewMemberFromRepository(
+		member.WorkspaceID(),
+		member.UserID(),
+		email,
+		member.Role(),
+		member.CreatedAt(),
+	)
+}
+
+func (r *workspaceRepository) FindMembers(ctx context.Context, workspaceID string) ([]*entity.Member, error) {
+	r.store.mu.RLock()
+	members := make([]*entity.Member, 0, len(r.store.members[workspaceID]))
+	for _, member := range r.store.members[workspaceID] {
+		members = append(members, r.withEmail(member))
+	}
+	r.store.mu.RUnlock()
+
+	slices.SortFunc(members, func(a, b *entity.Member) int {
+		if c := strings.Compare(a.Email(), b.Email()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.UserID(), b.UserID())
+	})
+	return members, nil
+}
+
+func (r *workspaceRepository) UpdateMemberRole(
+	ctx context.Context,
+	workspaceID, userID string,
+	role entity.Role,
+) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	member, ok := r.store.members[workspaceID][userID]
+	if !ok {
+		return errors.NotFoundError("member not found")
+	}
+	if role != entity.RoleOwner {
+		if err := r.keepOwner(workspaceID, member); err != nil {
+			return err
+		}
+	}
+
+	r.store.members[workspaceID][userID] = entity.NewMemberFromRepository(
+		workspaceID,
+		userID,
+		"",
+		role,
+		member.CreatedAt(),
+	)
+	return nil
+}
+
+func (r *workspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	member, ok := r.store.members[workspaceID][userID]
+	if !ok {
+		return errors.NotFoundError("member not found")
+	}
+	if err := r.keepOwner(workspaceID, member); err != nil {
+		return err
+	}
+
+	delete(r.store.members[workspaceID], userID)
+	return nil
+}
+
+// keepOwner fails when the member is the last owner of the workspace, who is about to
+// lose the role. The caller must hold the store lock.
+func (r *workspaceRepository) keepOwner(workspaceID string, member *entity.Member) error {
+	if member.Role() != entity.RoleOwner {
+		return nil
+	}
+
+	owners := 0
+	for _, other := range r.store.members[workspaceID] {
+		if other.Role() == entity.RoleOwner {
+			owners++
+		}
+	}
+	if owners <= 1 {
+		return errors.ConflictError("a workspace needs at least one owner")
+	}
+	return nil
+}
+
+func (r *workspaceRepository) SaveInvitation(ctx context.Context, invitation *entity.Invitation) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.workspaces[invitation.WorkspaceID()]; !ok {
+		return errors.NotFoundError("workspace not found")
+	}
+	for id, stored := range r.store.invitations {
+		if stored.WorkspaceID() == invitation.WorkspaceID() && stored.Email() == invitation.Email() {
+			delete(r.store.invitations, id)
+		}
+	}
+
+	r.store.invitations[invitation.ID()] = invitation
+	return nil
+}
+
+func (r *workspaceRepository) FindInvitationByHash(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, invitation := range r.store.invitations {
+		if invitation.TokenHash() == tokenHash {
+			return invitation, nil
+		}
+	}
+	return nil, errors.NotFoundError("invitation not found")
+}
+
+func (r *workspaceRepository) FindInvitations(ctx context.Context, workspaceID string) ([]*entity.Invitation, error) {
+	r.store.mu.RLock()
+	var invitations []*entity.Invitation
+	for _, invitation := range r.store.invitations {
+		if invitation.WorkspaceID() == workspaceID {
+			invitations = append(invitations, invitation)
+		}
+	}
+	r.store.mu.RUnlock()
+
+	slices.SortFunc(invitations, func(a, b *entity.Invitation) int {
+		if c := b.CreatedAt().Compare(a.CreatedAt()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID(), b.ID())
+	})
+	return invitations, nil
+}
+
+func (r *workspaceRepository) DeleteInvitation(ctx context.Context, id, workspaceID string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	invitation, ok := r.store.invitations[id]
+	if !ok || invitation.WorkspaceID() != workspaceID {
+		return errors.NotFoundError("invitation not found")
+	}
+
+	delete(r.store.invitations, id)
+	return nil
+}
+
+func (r *workspaceRepository) AcceptInvitation(
+	ctx context.Context,
+	invitationID string,
+	member *entity.Member,
+) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.invitations[invitationID]; !ok {
+		return errors.NotFoundError("invitation not found")
+	}
+	members, ok := r.store.members[member.WorkspaceID()]
+	if !ok {
+		return errors.NotFoundError("workspace not found")
+	}
+	if _, exists := members[member.UserID()]; exists {
+		return errors.ConflictError("user is already a member")
+	}
+
+	members[member.UserID()] = member
+	delete(r.store.invitations, invitationID)
+	return nil
+}
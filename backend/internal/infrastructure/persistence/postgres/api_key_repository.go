@@ -0,0 +1,184 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/user/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/user/repository"
+)
+
+const apiKeyColumns = `id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, created_at`
+
+type apiKeyRepository struct {
+	store  Store
+	logger logger.Logger
+}
+
+// NewAPIKeyRepository creates a new API key repository implementation
+func NewAPIKeyRepository(store Store, logger logger.Logger) repository.APIKeyRepository {
+	return &apiKeyRepository{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (r *apiKeyRepository) Save(ctx context.Context, key *entity.APIKey) error {
+	query := `INSERT INTO api_key (id, user_id, name, prefix, key_hash, scopes, expires_at, created_at) 
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := r.store.Pool().Exec(ctx, query,
+		key.ID(),
+		key.UserID(),
+		key.Name(),
+		key.Prefix(),
+		key.KeyHash(),
+		key.Scopes(),
+		key.ExpiresAt(),
+		key.CreatedAt(),
+	)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == "23505" { // unique_violation
+			return errors.ConflictError("API key prefix already exists")
+		}
+		r.logger.Error(ctx, "Error saving API key",
+			logger.String("userId", key.UserID()),
+			logger.String("operation", "Save"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	r.logger.Info(ctx, "API key saved successfully",
+		logger.String("userId", key.UserID()),
+		logger.String("keyId", key.ID()),
+		logger.String("operation", "Save"))
+	return nil
+}
+
+func (r *apiKeyRepository) FindByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_key WHERE prefix = $1`
+
+	key, err := scanAPIKey(r.store.Pool().QueryRow(ctx, query, prefix))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFoundError("API key not found")
+		}
+		r.logger.Error(ctx, "Error finding API key by prefix",
+			logger.String("operation", "FindByPrefix"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return key, nil
+}
+
+func (r *apiKeyRepository) FindByUserID(ctx context.Context, userID string) ([]*entity.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_key WHERE user_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.store.Pool().Query(ctx, query, userID)
+	if err != nil {
+		r.logger.Error(ctx, "Error finding API keys by user ID",
+			logger.String("userId", userID),
+			logger.String("operation", "FindByUserID"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+	defer rows.Close()
+
+	var keys []*entity.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			r.logger.Error(ctx, "Error scanning API key row",
+				logger.String("userId", userID),
+				logger.String("operation", "FindByUserID"),
+				logger.Error(err))
+			return nil, errors.InternalError("database operation failed")
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error(ctx, "Error iterating API key rows",
+			logger.String("userId", userID),
+			logger.String("operation", "FindByUserID"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Delete(ctx context.Context, id, userID string) error {
+	query := `DELETE FROM api_key WHERE id = $1 AND user_id = $2`
+
+	result, err := r.store.Pool().Exec(ctx, query, id, userID)
+	if err != nil {
+		r.logger.Error(ctx, "Error deleting API key",
+			logger.String("keyId", id),
+			logger.String("operation", "Delete"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.NotFoundError("API key not found")
+	}
+
+	r.logger.Info(ctx, "API key deleted successfully",
+		logger.String("keyId", id),
+		logger.String("userId", userID),
+		logger.String("operation", "Delete"))
+	return nil
+}
+
+func (r *apiKeyRepository) UpdateLastUsed(ctx context.Context, id string, lastUsedAt time.Time) error {
+	query := `UPDATE api_key SET last_used_at = $2 WHERE id = $1`
+
+	if _, err := r.store.Pool().Exec(ctx, query, id, lastUsedAt); err != nil {
+		r.logger.Error(ctx, "Error updating API key last used time",
+			logger.String("keyId", id),
+			logger.String("operation", "UpdateLastUsed"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	return nil
+}
+
+// scanAPIKey scans a row selected with apiKeyColumns into an API key
+func scanAPIKey(row pgx.Row) (*entity.APIKey, error) {
+	var id, userID, name, prefix, keyHash string
+	var scopes []string
+	var expiresAt, lastUsedAt *time.Time
+	var createdAt time.Time
+
+	err := row.Scan(&id, &userID, &name, &prefix, &keyHash, &scopes, &expiresAt, &lastUsedAt, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewAPIKeyFromRepository(id, userID, name, prefix, keyHash, scopes, expiresAt, lastUsedAt, createdAt), nil
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+)
+
+type clickRepository struct {
+	store  Store
+	logger logger.Logger
+}
+
+// NewClickRepository creates a new click repository implementation
+func NewClickRepository(store Store, logger logger.Logger) repository.ClickRepository {
+	return &clickRepository{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (r *clickRepository) SaveBatch(ctx context.Context, clicks []*entity.Click) error {
+	if len(clicks) == 0 {
+		return nil
+	}
+
+	// Clicks for URLs deleted in the meantime insert nothing
+	query := `INSERT INTO url_click (url_id, clicked_at, referrer, user_agent, ip_address, accept_language) 
+			  SELECT id, $2, $3, $4, $5, $6 FROM "url" WHERE id = $1`
+
+	batch := &pgx.Batch{}
+	for _, click := range clicks {
+		batch.Queue(query,
+			click.URLID(),
+			click.ClickedAt(),
+			click.Referrer(),
+			click.UserAgent(),
+			click.IPAddress(),
+			click.AcceptLanguage(),
+		)
+	}
+
+	if err := r.store.Pool().SendBatch(ctx, batch).Close(); err != nil {
+		r.logger.Error(ctx, "Error saving click batch",
+			logger.Int("count", len(clicks)),
+			logger.String("operation", "SaveBatch"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	r.logger.Debug(ctx, "Click batch saved successfully",
+		logger.Int("count", len(clicks)),
+		logger.String("operation", "SaveBatch"))
+	return nil
+}
+
+func (r *clickRepository) GetStats(
+	ctx context.Context,
+	urlID string,
+	from, to time.Time,
+	interval entity.ClickInterval,
+	top int,
+) (*entity.ClickStats, error) {
+	stats := &entity.ClickStats{}
+
+	timelineQuery := `SELECT date_trunc($4, clicked_at AT TIME ZONE 'UTC') AS bucket, COUNT(*) 
+			  FROM url_click 
+			  WHERE url_id = $1 AND clicked_at >= $2 AND clicked_at < $3 
+			  GROUP BY bucket 
+			  ORDER BY bucket`
+
+	rows, err := r.store.Pool().Query(ctx, timelineQuery, urlID, from, to, string(interval))
+	if err != nil {
+		return nil, r.statsError(ctx, urlID, "timeline", err)
+	}
+	stats.Timeline, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.ClickBucket, error) {
+		var bucket entity.ClickBucket
+		err := row.Scan(&bucket.Start, &bucket.Count)
+		bucket.Start = bucket.Start.UTC()
+		return bucket, err
+	})
+	if err != nil {
+		return nil, r.statsError(ctx, urlID, "timeline", err)
+	}
+	for _, bucket := range stats.Timeline {
+		stats.Total += bucket.Count
+	}
+
+	referrerQuery := `SELECT COALESCE(NULLIF(referrer, ''), 'direct') AS value, COUNT(*) AS count 
+			  FROM url_click 
+			  WHERE url_id = $1 AND clicked_at >= $2 AND clicked_at < $3 
+			  GROUP BY value 
+			  ORDER BY count DESC, value 
+			  LIMIT $4`
+
+	if stats.TopReferrers, err = r.topValues(ctx, referrerQuery, urlID, from, to, top); err != nil {
+		return nil, r.statsError(ctx, urlID, "referrers", err)
+	}
+
+	userAgentQuery := `SELECT COALESCE(NULLIF(user_agent, ''), 'unknown') AS value, COUNT(*) AS count 
+			  FROM url_click 
+			  WHERE url_id = $1 AND clicked_at >= $2 AND clicked_at < $3 
+			  GROUP BY value 
+			  ORDER BY count DESC, value 
+			  LIMIT $4`
+
+	if stats.TopUserAgents, err = r.topValues(ctx, userAgentQuery, urlID, from, to, top); err != nil {
+		return nil, r.statsError(ctx, urlID, "user_agents", err)
+	}
+
+	r.logger.Debug(ctx, "Click stats computed successfully",
+		logger.String("urlId", urlID),
+		logger.Int("total", stats.Total),
+		logger.String("operation", "GetStats"))
+	return stats, nil
+}
+
+func (r *clickRepository) topValues(
+	ctx context.Context,
+	query string,
+	urlID string,
+	from, to time.Time,
+	top int,
+) ([]entity.ClickCount, error) {
+	rows, err := r.store.Pool().Query(ctx, query, urlID, from, to, top)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.ClickCount, error) {
+		var count entity.ClickCount
+		err := row.Scan(&count.Value, &count.Count)
+		return count, err
+	})
+}
+
+func (r *clickRepository) statsError(ctx context.Context, urlID, section string, err error) error {
+	r.logger.Error(ctx, "Error computing click stats",
+		logger.String("urlId", urlID),
+		logger.String("section", section),
+		logger.String("operation", "GetStats"),
+		logger.Error(err))
+	return errors.InternalError("database operation failed")
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+)
+
+const domainColumns = `id, user_id, hostname, verification_token, verified_at, created_at`
+
+type domainRepository struct {
+	store  Store
+	logger logger.Logger
+}
+
+// NewDomainRepository creates a new domain repository implementation
+func NewDomainRepository(store Store, logger logger.Logger) repository.DomainRepository {
+	return &domainRepository{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (r *domainRepository) Save(ctx context.Context, domain *entity.Domain) error {
+	query := `INSERT INTO "domain" (id, user_id, hostname, verification_token, verified_at, created_at) 
+			  VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.store.Pool().Exec(ctx, query,
+		domain.ID(),
+		domain.UserID(),
+		domain.Hostname(),
+		domain.VerificationToken(),
+		domain.VerifiedAt(),
+		domain.CreatedAt(),
+	)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == "23505" { // unique_violation
+			return errors.ConflictError("domain already exists")
+		}
+		r.logger.Error(ctx, "Error saving domain",
+			logger.String("userId", domain.UserID()),
+			logger.String("hostname", domain.Hostname()),
+			logger.String("operation", "Save"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	r.logger.Info(ctx, "Domain saved successfully",
+		logger.String("userId", domain.UserID()),
+		logger.String("domainId", domain.ID()),
+		logger.String("operation", "Save"))
+	return nil
+}
+
+func (r *domainRepository) FindByID(ctx context.Context, id string) (*entity.Domain, error) {
+	query := `SELECT ` + domainColumns + ` FROM "domain" WHERE id = $1`
+
+	return r.findOne(ctx, "FindByID", query, id)
+}
+
+func (r *domainRepository) FindVerified(ctx context.Context, hostname string) (*entity.Domain, error) {
+	query := `SELECT ` + domainColumns + ` FROM "domain" WHERE hostname = $1 AND verified_at IS NOT NULL`
+
+	return r.findOne(ctx, "FindVerified", query, hostname)
+}
+
+// findOne reads the single domain selected by query, if any
+func (r *domainRepository) findOne(ctx context.Context, operation, query string, args ...any) (*entity.Domain, error) {
+	domain, err := scanDomain(r.store.Pool().QueryRow(ctx, query, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFoundError("domain not found")
+		}
+		r.logger.Error(ctx, "Error finding domain",
+			logger.String("operation", operation),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return domain, nil
+}
+
+func (r *domainRepository) FindByUserID(ctx context.Context, userID string) ([]*entity.Domain, error) {
+	query := `SELECT ` + domainColumns + ` FROM "domain" WHERE user_id = $1 ORDER BY hostname`
+
+	rows, err := r.store.Pool().Query(ctx, query, userID)
+	if err != nil {
+		r.logger.Error(ctx, "Error finding domains by user ID",
+			logger.String("userId", userID),
+			logger.String("operation", "FindByUserID"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+	defer rows.Close()
+
+	var domains []*entity.Domain
+	for rows.Next() {
+		domain, err := scanDomain(rows)
+		if err != nil {
+			r.logger.Error(ctx, "Error scanning domain row",
+				logger.String("userId", userID),
+				logger.String("operation", "FindByUserID"),
+				logger.Error(err))
+			return nil, errors.InternalError("database operation failed")
+		}
+		domains = append(domains, domain)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error(ctx, "Error iterating domain rows",
+			logger.String("userId", userID),
+			logger.String("operation", "FindByUserID"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return domains, nil
+}
+
+func (r *domainRepository) MarkVerified(ctx context.Context, id string, verifiedAt time.Time) error {
+	// A domain that is already verified keeps its original verification time
+	query := `UPDATE "domain" SET verified_at = COALESCE(verified_at, $2) WHERE id = $1`
+
+	result, err := r.store.Pool().Exec(ctx, query, id, verifiedAt)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == "23505" { // unique_violation
+			return errors.ConflictError("domain is already verified by another user")
+		}
+		r.logger.Error(ctx, "Error marking domain as verified",
+			logger.String("domainId", id),
+			logger.String("operation", "MarkVerified"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.NotFoundError("domain not found")
+	}
+
+	r.logger.Info(ctx, "Domain verified successfully",
+		logger.String("domainId", id),
+		logger.String("operation", "MarkVerified"))
+	return nil
+}
+
+func (r *domainRepository) Delete(ctx context.Context, id, userID string) error {
+	query := `DELETE FROM "domain" WHERE id = $1 AND user_id = $2`
+
+	result, err := r.store.Pool().Exec(ctx, query, id, userID)
+	if err != nil {
+		r.logger.Error(ctx, "Error deleting domain",
+			logger.String("domainId", id),
+			logger.String("operation", "Delete"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	if result.RowsAffected() == 0 {
+		return errors.NotFoundError("domain not found")
+	}
+
+	r.logger.Info(ctx, "Domain deleted successfully",
+		logger.String("domainId", id),
+		logger.String("userId", userID),
+		logger.String("operation", "Delete"))
+	return nil
+}
+
+// scanDomain scans a row selected with domainColumns into a domain
+func scanDomain(row pgx.Row) (*entity.Domain, error) {
+	var id, userID, hostname, verificationToken string
+	var verifiedAt *time.Time
+	var createdAt time.Time
+
+	err := row.Scan(&id, &userID, &hostname, &verificationToken, &verifiedAt, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewDomainFromRepository(id, userID, hostname, verificationToken, verifiedAt, createdAt), nil
+}
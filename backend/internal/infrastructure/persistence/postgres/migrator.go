@@ -0,0 +1,301 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+)
+
+// migrationLockID is the key of the session advisory lock held while migrating, so
+// that replicas starting at the same time apply migrations one after another
+const migrationLockID int64 = 0x73686f72746c79 // "shortly"
+
+// migrationFilePattern matches golang-migrate style file names
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration is a versioned schema change with its rollback
+type Migration struct {
+	Version uint64
+	Name    string
+	up      string
+	down    string
+}
+
+// MigrationStatus reports whether a migration has been applied
+type MigrationStatus struct {
+	Version uint64
+	Name    string
+	Applied bool
+}
+
+// Migrator applies schema migrations and records the current version in the
+// schema_migrations table. The table layout matches golang-migrate, so databases
+// migrated with either tool can be managed by the other.
+type Migrator struct {
+	store      Store
+	migrations []Migration
+	logger     logger.Logger
+}
+
+// NewMigrator creates a migrator for the migration files in source
+func NewMigrator(store Store, source fs.FS, logger logger.Logger) (*Migrator, error) {
+	migrations, err := loadMigrations(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		store:      store,
+		migrations: migrations,
+		logger:     logger,
+	}, nil
+}
+
+// Up applies up to steps pending migrations, or all of them when steps is not positive,
+// and returns the number applied
+func (m *Migrator) Up(ctx context.Context, steps int) (int, error) {
+	applied := 0
+	err := m.withLock(ctx, func(conn *pgx.Conn, current uint64) error {
+		for _, migration := range m.migrations {
+			if migration.Version <= current {
+				continue
+			}
+			if steps > 0 && applied == steps {
+				break
+			}
+
+			if err := m.apply(ctx, conn, migration.Version, migration.up, migration.Version); err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+			}
+			m.logger.Info(ctx, "Migration applied",
+				logger.Int64("version", int64(migration.Version)),
+				logger.String("name", migration.Name),
+				logger.String("operation", "Up"))
+			applied++
+		}
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down rolls back up to steps applied migrations, or a single one when steps is not
+// positive, and returns the number rolled back
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		steps = 1
+	}
+
+	rolledBack := 0
+	err := m.withLock(ctx, func(conn *pgx.Conn, current uint64) error {
+		for i := len(m.migrations) - 1; i >= 0 && rolledBack < steps; i-- {
+			migration := m.migrations[i]
+			if migration.Version > current {
+				continue
+			}
+			if migration.down == "" {
+				return fmt.Errorf("migration %d_%s has no down migration", migration.Version, migration.Name)
+			}
+
+			var previous uint64
+			if i > 0 {
+				previous = m.migrations[i-1].Version
+			}
+
+			if err := m.apply(ctx, conn, migration.Version, migration.down, previous); err != nil {
+				return fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+			}
+			m.logger.Info(ctx, "Migration rolled back",
+				logger.Int64("version", int64(migration.Version)),
+				logger.String("name", migration.Name),
+				logger.String("operation", "Down"))
+			current = previous
+			rolledBack++
+		}
+		return nil
+	})
+
+	return rolledBack, err
+}
+
+// Status returns the current schema version and whether each known migration is applied
+func (m *Migrator) Status(ctx context.Context) (uint64, []MigrationStatus, error) {
+	conn, err := m.store.Pool().Acquire(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer conn.Release()
+
+	current, err := m.currentVersion(ctx, conn.Conn())
+	if err != nil {
+		return 0, nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+			Applied: migration.Version <= current,
+		}
+	}
+
+	return current, statuses, nil
+}
+
+// withLock runs fn on a dedicated connection while holding the migration advisory lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgx.Conn, current uint64) error) error {
+	conn, err := m.store.Pool().Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("acquiring migration lock: %w", err)
+	}
+	defer func() {
+		// The lock belongs to the session, so it must be released even if ctx is done
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID); err != nil {
+			m.logger.Error(ctx, "Error releasing migration lock", logger.Error(err))
+		}
+	}()
+
+	// The version is read under the lock, after any concurrent migrator has finished
+	current, err := m.currentVersion(ctx, conn.Conn())
+	if err != nil {
+		return err
+	}
+
+	return fn(conn.Conn(), current)
+}
+
+// apply runs a migration script and records the resulting version in one transaction
+func (m *Migrator) apply(ctx context.Context, conn *pgx.Conn, version uint64, script string, resultVersion uint64) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Without arguments pgx uses the simple protocol, which allows multiple statements
+	if _, err := tx.Exec(ctx, script); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return err
+	}
+	if resultVersion > 0 {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`,
+			int64(resultVersion))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// currentVersion returns the applied schema version, creating the version table if needed
+func (m *Migrator) currentVersion(ctx context.Context, conn *pgx.Conn) (uint64, error) {
+	_, err := conn.Exec(ctx,
+		`CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`)
+	if err != nil {
+		return 0, fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	var version int64
+	var dirty bool
+	err = conn.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err == pgx.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading schema version: %w", err)
+	}
+
+	// Migrations run in transactions here, but golang-migrate can leave a failed one behind
+	if dirty {
+		return 0, fmt.Errorf("schema version %d is dirty; repair the schema and reset the version before migrating", version)
+	}
+
+	return uint64(version), nil
+}
+
+// loadMigrations reads and orders the migration files in source
+func loadMigrations(source fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+
+		content, err := fs.ReadFile(source, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("conflicting names for migration version %d", version)
+		}
+
+		if match[3] == "up" {
+			migration.up = string(content)
+		} else {
+			migration.down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up migration", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/user/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/user/repository"
+)
+
+type refreshTokenRepository struct {
+	store  Store
+	logger logger.Logger
+}
+
+// NewRefreshTokenRepository creates a new refresh token repository implementation
+func NewRefreshTokenRepository(store Store, logger logger.Logger) repository.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (r *refreshTokenRepository) Save(ctx context.Context, token *entity.RefreshToken) error {
+	query := `INSERT INTO refresh_token (id, user_id, family_id, token_hash, expires_at, created_at) 
+			  VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.store.Pool().Exec(ctx, query,
+		token.ID(),
+		token.UserID(),
+		token.FamilyID(),
+		token.TokenHash(),
+		token.ExpiresAt(),
+		token.CreatedAt(),
+	)
+	if err != nil {
+		r.logger.Error(ctx, "Error saving refresh token",
+			logger.String("userId", token.UserID()),
+			logger.String("operation", "Save"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	return nil
+}
+
+func (r *refreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	query := `SELECT id, user_id, family_id, token_hash, expires_at, revoked_at, created_at 
+			  FROM refresh_token WHERE token_hash = $1`
+
+	var id, userID, familyID, hash string
+	var expiresAt, createdAt time.Time
+	var revokedAt *time.Time
+
+	err := r.store.Pool().QueryRow(ctx, query, tokenHash).Scan(
+		&id, &userID, &familyID, &hash, &expiresAt, &revokedAt, &createdAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFoundError("refresh token not found")
+		}
+		r.logger.Error(ctx, "Error finding refresh token",
+			logger.String("operation", "FindByHash"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return entity.NewRefreshTokenFromRepository(id, userID, familyID, hash, expiresAt, revokedAt, createdAt), nil
+}
+
+func (r *refreshTokenRepository) Revoke(ctx context.Context, id string) (bool, error) {
+	query := `UPDATE refresh_token SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`
+
+	result, err := r.store.Pool().Exec(ctx, query, id, time.Now().UTC())
+	if err != nil {
+		r.logger.Error(ctx, "Error revoking refresh token",
+			logger.String("tokenId", id),
+			logger.String("operation", "Revoke"),
+			logger.Error(err))
+		return false, errors.InternalError("database operation failed")
+	}
+
+	return result.RowsAffected() == 1, nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	query := `UPDATE refresh_token SET revoked_at = $2 WHERE family_id = $1 AND revoked_at IS NULL`
+
+	result, err := r.store.Pool().Exec(ctx, query, familyID, time.Now().UTC())
+	if err != nil {
+		r.logger.Error(ctx, "Error revoking refresh token family",
+			logger.String("familyId", familyID),
+			logger.String("operation", "RevokeFamily"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	r.logger.Info(ctx, "Refresh token family revoked",
+		logger.String("familyId", familyID),
+		logger.Int64("revoked", result.RowsAffected()),
+		logger.String("operation", "RevokeFamily"))
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeByUserID(ctx context.Context, userID string) error {
+	query := `UPDATE refresh_token SET revoked_at = $2 WHERE user_id = $1 AND revoked_at IS NULL`
+
+	result, err := r.store.Pool().Exec(ctx, query, userID, time.Now().UTC())
+	if err != nil {
+		r.logger.Error(ctx, "Error revoking refresh tokens of user",
+			logger.String("userId", userID),
+			logger.String("operation", "RevokeByUserID"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	r.logger.Info(ctx, "Refresh tokens of user revoked",
+		logger.String("userId", userID),
+		logger.Int64("revoked", result.RowsAffected()),
+		logger.String("operation", "RevokeByUserID"))
+	return nil
+}
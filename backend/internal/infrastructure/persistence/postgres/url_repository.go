@@ -18,9 +18,12 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
 	"github.com/PraveenGongada/shortly/internal/domain/url/entity"
@@ -28,6 +31,30 @@ import (
 	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
 )
 
+// urlColumns lists the columns read by scanURL, in scan order. Tags are aggregated from
+// url_tag, so the columns can only be selected from "url" without an alias.
+const urlColumns = `id, user_id, workspace_id, domain, short_url, long_url, redirects, expires_at, max_clicks, password_hash, redirect_type, forward_query, title, description, 
+			  ARRAY(SELECT t.name FROM url_tag ut JOIN tag t ON t.id = ut.tag_id WHERE ut.url_id = "url".id ORDER BY t.name), 
+			  created_at, updated_at, deleted_at, disabled_at, COALESCE(disabled_reason, '')`
+
+const (
+	// unlinkTagsQuery detaches all tags from an active URL
+	unlinkTagsQuery = `DELETE FROM url_tag ut USING "url" u 
+			  WHERE ut.url_id = $1 AND u.id = ut.url_id AND u.deleted_at IS NULL`
+
+	// insertTagsQuery creates the tags of an active URL its owner does not have yet
+	insertTagsQuery = `INSERT INTO tag (user_id, name) 
+			  SELECT u.user_id, name FROM "url" u, unnest($2::text[]) AS name 
+			  WHERE u.id = $1 AND u.deleted_at IS NULL 
+			  ON CONFLICT (user_id, name) DO NOTHING`
+
+	// linkTagsQuery attaches the named tags of its owner to an active URL
+	linkTagsQuery = `INSERT INTO url_tag (url_id, tag_id) 
+			  SELECT u.id, t.id FROM "url" u JOIN tag t ON t.user_id = u.user_id 
+			  WHERE u.id = $1 AND u.deleted_at IS NULL AND t.name = ANY($2) 
+			  ON CONFLICT DO NOTHING`
+)
+
 type urlRepository struct {
 	store  Store
 	logger logger.Logger
@@ -41,27 +68,96 @@ func NewURLRepository(store Store, logger logger.Logger) repository.URLRepositor
 	}
 }
 
-func (r *urlRepository) Save(ctx context.Context, url *entity.URL) (*entity.URL, error) {
+// scanURL reads a single row selected with urlColumns into a URL entity
+func scanURL(row pgx.Row) (*entity.URL, error) {
+	var snapshot entity.URLSnapshot
+	var passwordHash *string
+
+	err := row.Scan(
+		&snapshot.ID, &snapshot.UserID, &snapshot.WorkspaceID, &snapshot.Domain, &snapshot.ShortCode,
+		&snapshot.LongURL, &snapshot.Redirects, &snapshot.ExpiresAt, &snapshot.MaxClicks, &passwordHash,
+		&snapshot.RedirectType, &snapshot.ForwardQuery, &snapshot.Title, &snapshot.Description, &snapshot.Tags,
+		&snapshot.CreatedAt, &snapshot.UpdatedAt, &snapshot.DeletedAt, &snapshot.DisabledAt, &snapshot.DisabledReason,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-	query := `INSERT INTO "url" (id, user_id, short_url, long_url, redirects, created_at) 
-			  VALUES ($1, $2, $3, $4, $5, $6) 
-			  RETURNING id, user_id, short_url, long_url, redirects, created_at, updated_at`
+	if passwordHash != nil {
+		snapshot.PasswordHash = *passwordHash
+	}
+
+	return entity.NewURLFromRepository(snapshot), nil
+}
+
+// queueTags queues the statements writing the tags of a URL, replacing any it had
+// before if replace is set, and returns how many were queued. They find the URL by
+// id, so they do nothing for a URL that was not inserted or is in the trash.
+func queueTags(batch *pgx.Batch, url *entity.URL, replace bool) int {
+	queued := 0
+	if replace {
+		batch.Queue(unlinkTagsQuery, url.ID())
+		queued++
+	}
+	if len(url.Tags()) == 0 {
+		return queued
+	}
+	batch.Queue(insertTagsQuery, url.ID(), url.Tags())
+	batch.Queue(linkTagsQuery, url.ID(), url.Tags())
+	return queued + 2
+}
 
-	var id, userID, shortCode, longURL string
-	var redirects int
-	var createdAt time.Time
-	var updatedAt *time.Time
+// withTags returns the URL with the given tags, for rows returned by an insert, which
+// are read before the tags of the URL are written
+func withTags(url *entity.URL, tags []string) *entity.URL {
+	snapshot := url.Snapshot()
+	snapshot.Tags = tags
+	return entity.NewURLFromRepository(snapshot)
+}
+
+func (r *urlRepository) Save(ctx context.Context, url *entity.URL) (*entity.URL, error) {
 
-	err := r.store.Pool().QueryRow(ctx, query,
+	query := `INSERT INTO "url" (id, user_id, workspace_id, domain, short_url, long_url, redirects, expires_at, max_clicks, password_hash, redirect_type, forward_query, title, description, created_at) 
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11, $12, $13, $14, $15) 
+			  RETURNING ` + urlColumns
+
+	// Sent as a single pipeline, so the URL is only saved together with its tags
+	batch := &pgx.Batch{}
+	batch.Queue(query,
 		url.ID(),
 		url.UserID(),
+		url.WorkspaceID(),
+		url.Domain(),
 		url.ShortCode(),
 		url.LongURL(),
 		url.Redirects(),
+		url.ExpiresAt(),
+		url.MaxClicks(),
+		url.PasswordHash(),
+		url.RedirectType(),
+		url.ForwardQuery(),
+		url.Title(),
+		url.Description(),
 		url.CreatedAt(),
-	).Scan(&id, &userID, &shortCode, &longURL, &redirects, &createdAt, &updatedAt)
+	)
+	queueTags(batch, url, false)
+
+	results := r.store.Pool().SendBatch(ctx, batch)
+	defer results.Close()
 
+	savedURL, err := scanURL(results.QueryRow())
+	if err == nil {
+		err = results.Close()
+	}
 	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == "23505" { // unique_violation
+			r.logger.Warn(ctx, "Short code already exists",
+				logger.String("urlId", url.ID()),
+				logger.String("shortCode", url.ShortCode()),
+				logger.String("operation", "Save"))
+			return nil, errors.ConflictError("short code already exists")
+		}
 		r.logger.Error(ctx, "Error saving URL", 
 			logger.String("urlId", url.ID()),
 			logger.String("operation", "Save"),
@@ -69,26 +165,92 @@ func (r *urlRepository) Save(ctx context.Context, url *entity.URL) (*entity.URL,
 		return nil, errors.InternalError("database operation failed")
 	}
 
-	savedURL := entity.NewURLFromRepository(id, userID, shortCode, longURL, redirects, createdAt, updatedAt)
 	r.logger.Info(ctx, "URL saved successfully",
 		logger.String("urlId", url.ID()),
 		logger.String("operation", "Save"))
-	return savedURL, nil
+	return withTags(savedURL, url.Tags()), nil
 }
 
-func (r *urlRepository) FindByShortCode(ctx context.Context, shortCode string) (*entity.URL, error) {
+func (r *urlRepository) SaveBatch(ctx context.Context, urls []*entity.URL) ([]*entity.URL, error) {
+	if len(urls) == 0 {
+		return nil, nil
+	}
 
-	query := `SELECT id, user_id, short_url, long_url, redirects, created_at, updated_at 
-			  FROM "url" WHERE short_url = $1`
+	// The batch is sent as a single pipeline, so either every insert is applied or none.
+	// Rows conflicting with an existing id or short code insert nothing and return no row.
+	query := `INSERT INTO "url" (id, user_id, workspace_id, domain, short_url, long_url, redirects, expires_at, max_clicks, password_hash, redirect_type, forward_query, title, description, created_at) 
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11, $12, $13, $14, $15) 
+			  ON CONFLICT DO NOTHING 
+			  RETURNING ` + urlColumns
 
-	var id, userID, scannedShortCode, longURL string
-	var redirects int
-	var createdAt time.Time
-	var updatedAt *time.Time
+	batch := &pgx.Batch{}
+	tagStatements := make([]int, len(urls))
+	for i, url := range urls {
+		batch.Queue(query,
+			url.ID(),
+			url.UserID(),
+			url.WorkspaceID(),
+			url.Domain(),
+			url.ShortCode(),
+			url.LongURL(),
+			url.Redirects(),
+			url.ExpiresAt(),
+			url.MaxClicks(),
+			url.PasswordHash(),
+			url.RedirectType(),
+			url.ForwardQuery(),
+			url.Title(),
+			url.Description(),
+			url.CreatedAt(),
+		)
+		tagStatements[i] = queueTags(batch, url, false)
+	}
 
-	err := r.store.Pool().QueryRow(ctx, query, shortCode).Scan(
-		&id, &userID, &scannedShortCode, &longURL, &redirects, &createdAt, &updatedAt,
-	)
+	results := r.store.Pool().SendBatch(ctx, batch)
+	defer results.Close()
+
+	savedURLs := make([]*entity.URL, len(urls))
+	for i, url := range urls {
+		savedURL, err := scanURL(results.QueryRow())
+		if err == pgx.ErrNoRows {
+			err = nil
+		}
+		// Read past the results of the tag statements queued after the insert
+		for n := 0; err == nil && n < tagStatements[i]; n++ {
+			_, err = results.Exec()
+		}
+		if err != nil {
+			r.logger.Error(ctx, "Error saving URL batch",
+				logger.Int("count", len(urls)),
+				logger.String("operation", "SaveBatch"),
+				logger.Error(err))
+			return nil, errors.InternalError("database operation failed")
+		}
+		if savedURL != nil {
+			savedURLs[i] = withTags(savedURL, url.Tags())
+		}
+	}
+
+	if err := results.Close(); err != nil {
+		r.logger.Error(ctx, "Error saving URL batch",
+			logger.Int("count", len(urls)),
+			logger.String("operation", "SaveBatch"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	r.logger.Info(ctx, "URL batch saved successfully",
+		logger.Int("count", len(urls)),
+		logger.String("operation", "SaveBatch"))
+	return savedURLs, nil
+}
+
+func (r *urlRepository) FindByShortCode(ctx context.Context, domain, shortCode string) (*entity.URL, error) {
+
+	query := `SELECT ` + urlColumns + ` 
+			  FROM "url" WHERE domain = $1 AND short_url = $2 AND deleted_at IS NULL`
+
+	url, err := scanURL(r.store.Pool().QueryRow(ctx, query, domain, shortCode))
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -104,7 +266,6 @@ func (r *urlRepository) FindByShortCode(ctx context.Context, shortCode string) (
 		return nil, errors.InternalError("database operation failed")
 	}
 
-	url := entity.NewURLFromRepository(id, userID, scannedShortCode, longURL, redirects, createdAt, updatedAt)
 	r.logger.Debug(ctx, "URL found successfully",
 		logger.String("shortCode", shortCode),
 		logger.String("operation", "FindByShortCode"))
@@ -113,17 +274,10 @@ func (r *urlRepository) FindByShortCode(ctx context.Context, shortCode string) (
 
 func (r *urlRepository) FindByID(ctx context.Context, id string) (*entity.URL, error) {
 
-	query := `SELECT id, user_id, short_url, long_url, redirects, created_at, updated_at 
-			  FROM "url" WHERE id = $1`
-
-	var urlID, userID, shortCode, longURL string
-	var redirects int
-	var createdAt time.Time
-	var updatedAt *time.Time
+	query := `SELECT ` + urlColumns + ` 
+			  FROM "url" WHERE id = $1 AND deleted_at IS NULL`
 
-	err := r.store.Pool().QueryRow(ctx, query, id).Scan(
-		&urlID, &userID, &shortCode, &longURL, &redirects, &createdAt, &updatedAt,
-	)
+	url, err := scanURL(r.store.Pool().QueryRow(ctx, query, id))
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -139,28 +293,123 @@ func (r *urlRepository) FindByID(ctx context.Context, id string) (*entity.URL, e
 		return nil, errors.InternalError("database operation failed")
 	}
 
-	url := entity.NewURLFromRepository(urlID, userID, shortCode, longURL, redirects, createdAt, updatedAt)
 	r.logger.Debug(ctx, "URL found successfully",
 		logger.String("urlId", id),
 		logger.String("operation", "FindByID"))
 	return url, nil
 }
 
-func (r *urlRepository) FindByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.URL, error) {
+func (r *urlRepository) FindDeletedByID(ctx context.Context, id string) (*entity.URL, error) {
+
+	query := `SELECT ` + urlColumns + ` 
+			  FROM "url" WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	url, err := scanURL(r.store.Pool().QueryRow(ctx, query, id))
 
-	query := `SELECT id, user_id, short_url, long_url, redirects, created_at, updated_at 
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			r.logger.Debug(ctx, "URL not found in trash",
+				logger.String("urlId", id),
+				logger.String("operation", "FindDeletedByID"))
+			return nil, errors.NotFoundError("URL not found in trash")
+		}
+		r.logger.Error(ctx, "Error finding URL in trash by ID",
+			logger.String("urlId", id),
+			logger.String("operation", "FindDeletedByID"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return url, nil
+}
+
+// urlSortKey is the expression a listing order sorts URLs by, before their id
+type urlSortKey struct {
+	expr  string
+	desc  bool
+	value func(cursor repository.URLCursor) any
+}
+
+var urlSortKeys = map[repository.URLSort]urlSortKey{
+	repository.SortCreated: {
+		expr:  "created_at",
+		desc:  true,
+		value: func(cursor repository.URLCursor) any { return cursor.CreatedAt },
+	},
+	repository.SortUpdated: {
+		expr:  "COALESCE(updated_at, created_at)",
+		desc:  true,
+		value: func(cursor repository.URLCursor) any { return cursor.UpdatedAt },
+	},
+	repository.SortRedirects: {
+		expr:  "redirects",
+		desc:  true,
+		value: func(cursor repository.URLCursor) any { return cursor.Redirects },
+	},
+	repository.SortAlphabetical: {
+		// Byte order, as repository.SortName values compare in Go
+		expr:  `LOWER(COALESCE(NULLIF(title, ''), short_url)) COLLATE "C"`,
+		value: func(cursor repository.URLCursor) any { return cursor.Name },
+	},
+}
+
+func (r *urlRepository) FindByWorkspaceID(
+	ctx context.Context,
+	workspaceID string,
+	filter repository.URLFilter,
+	page repository.URLPage,
+) ([]*entity.URL, error) {
+	return r.findURLs(ctx, "FindByWorkspaceID", workspaceID, filter, page)
+}
+
+func (r *urlRepository) FindAll(
+	ctx context.Context,
+	filter repository.URLFilter,
+	page repository.URLPage,
+) ([]*entity.URL, error) {
+	return r.findURLs(ctx, "FindAll", "", filter, page)
+}
+
+// findURLs returns a page of the URLs of the workspace, or of every workspace when
+// workspaceID is empty, that match the filter
+func (r *urlRepository) findURLs(
+	ctx context.Context,
+	operation string,
+	workspaceID string,
+	filter repository.URLFilter,
+	page repository.URLPage,
+) ([]*entity.URL, error) {
+
+	key, ok := urlSortKeys[page.Sort]
+	if !ok {
+		key = urlSortKeys[repository.SortCreated]
+	}
+	direction, comparison := "ASC", ">"
+	if key.desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	var args queryArgs
+	conditions := urlFilterConditions(&args, workspaceID, filter)
+	if page.After != nil {
+		// Rows after the cursor in listing order, served by the (workspace_id, key, id)
+		// indexes, or by url_created_at_id_idx across workspaces
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s, %s)",
+			key.expr, comparison, args.add(key.value(*page.After)), args.add(page.After.ID)))
+	}
+	query := fmt.Sprintf(`SELECT `+urlColumns+` 
 			  FROM "url" 
-			  WHERE user_id = $1 
-			  ORDER BY created_at DESC 
-			  LIMIT $2 OFFSET $3`
+			  WHERE %s 
+			  ORDER BY %s %s, id %s 
+			  LIMIT %s`, strings.Join(conditions, " AND "), key.expr, direction, direction, args.add(page.Limit))
 
-	rows, err := r.store.Pool().Query(ctx, query, userID, limit, offset)
+	rows, err := r.store.Pool().Query(ctx, query, args...)
 	if err != nil {
-		r.logger.Error(ctx, "Error querying URLs by user ID",
-			logger.String("userId", userID),
-			logger.Int("limit", limit),
-			logger.Int("offset", offset),
-			logger.String("operation", "FindByUserID"),
+		r.logger.Error(ctx, "Error querying URLs",
+			logger.String("workspaceId", workspaceID),
+			logger.String("sort", string(page.Sort)),
+			logger.Int("limit", page.Limit),
+			logger.String("operation", operation),
 			logger.Error(err))
 		return nil, errors.InternalError("database operation failed")
 	}
@@ -169,45 +418,192 @@ func (r *urlRepository) FindByUserID(ctx context.Context, userID string, limit,
 	var urls []*entity.URL
 
 	for rows.Next() {
-		var id, uid, shortCode, longURL string
-		var redirects int
-		var createdAt time.Time
-		var updatedAt *time.Time
-
-		err := rows.Scan(&id, &uid, &shortCode, &longURL, &redirects, &createdAt, &updatedAt)
+		url, err := scanURL(rows)
 		if err != nil {
 			r.logger.Error(ctx, "Error scanning URL row",
-				logger.String("userId", userID),
-				logger.String("operation", "FindByUserID"),
+				logger.String("workspaceId", workspaceID),
+				logger.String("operation", operation),
 				logger.Error(err))
 			return nil, errors.InternalError("database operation failed")
 		}
 
-		url := entity.NewURLFromRepository(id, uid, shortCode, longURL, redirects, createdAt, updatedAt)
 		urls = append(urls, url)
 	}
 
 	if err := rows.Err(); err != nil {
 		r.logger.Error(ctx, "Error iterating URL rows",
-			logger.String("userId", userID),
-			logger.String("operation", "FindByUserID"),
+			logger.String("workspaceId", workspaceID),
+			logger.String("operation", operation),
 			logger.Error(err))
 		return nil, errors.InternalError("database operation failed")
 	}
 
 	r.logger.Debug(ctx, "URLs found successfully",
-		logger.String("userId", userID),
+		logger.String("workspaceId", workspaceID),
 		logger.Int("count", len(urls)),
-		logger.String("operation", "FindByUserID"))
+		logger.String("operation", operation))
 	return urls, nil
 }
 
-func (r *urlRepository) ExistsByShortCode(ctx context.Context, shortCode string) (bool, error) {
+func (r *urlRepository) CountByWorkspaceID(
+	ctx context.Context,
+	workspaceID string,
+	filter repository.URLFilter,
+) (int, error) {
+	return r.countURLs(ctx, "CountByWorkspaceID", workspaceID, filter)
+}
+
+func (r *urlRepository) CountAll(ctx context.Context, filter repository.URLFilter) (int, error) {
+	return r.countURLs(ctx, "CountAll", "", filter)
+}
+
+// countURLs counts the URLs of the workspace, or of every workspace when workspaceID is
+// empty, that match the filter
+func (r *urlRepository) countURLs(
+	ctx context.Context,
+	operation string,
+	workspaceID string,
+	filter repository.URLFilter,
+) (int, error) {
+
+	var args queryArgs
+	query := `SELECT COUNT(*) FROM "url" WHERE ` + strings.Join(urlFilterConditions(&args, workspaceID, filter), " AND ")
+
+	var count int
+	if err := r.store.Pool().QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		r.logger.Error(ctx, "Error counting URLs",
+			logger.String("workspaceId", workspaceID),
+			logger.String("operation", operation),
+			logger.Error(err))
+		return 0, errors.InternalError("database operation failed")
+	}
+
+	return count, nil
+}
+
+func (r *urlRepository) Totals(ctx context.Context) (repository.URLTotals, error) {
+
+	query := `SELECT COUNT(*) FILTER (WHERE deleted_at IS NULL), 
+			         COUNT(*) FILTER (WHERE deleted_at IS NULL AND disabled_at IS NOT NULL), 
+			         COUNT(*) FILTER (WHERE deleted_at IS NOT NULL), 
+			         COALESCE(SUM(redirects), 0) 
+			  FROM "url"`
+
+	var totals repository.URLTotals
+	err := r.store.Pool().QueryRow(ctx, query).Scan(
+		&totals.Active, &totals.Disabled, &totals.Deleted, &totals.Redirects,
+	)
+	if err != nil {
+		r.logger.Error(ctx, "Error totalling URLs",
+			logger.String("operation", "Totals"),
+			logger.Error(err))
+		return repository.URLTotals{}, errors.InternalError("database operation failed")
+	}
+
+	return totals, nil
+}
+
+// queryArgs collects the arguments of a query built from parts
+type queryArgs []any
+
+// add appends an argument and returns its placeholder
+func (a *queryArgs) add(value any) string {
+	*a = append(*a, value)
+	return fmt.Sprintf("$%d", len(*a))
+}
+
+// urlFilterConditions returns the WHERE conditions selecting the URLs of a workspace, or
+// of every workspace when workspaceID is empty, that match the filter, adding their
+// arguments to args
+func urlFilterConditions(args *queryArgs, workspaceID string, filter repository.URLFilter) []string {
+	conditions := []string{"deleted_at IS NULL"}
+	if filter.Deleted {
+		conditions[0] = "deleted_at IS NOT NULL"
+	}
+	if workspaceID != "" {
+		conditions = append(conditions, "workspace_id = "+args.add(workspaceID))
+	}
+	if filter.UserID != "" {
+		conditions = append(conditions, "user_id = "+args.add(filter.UserID))
+	}
+
+	for _, tag := range filter.Tags {
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM url_tag ut JOIN tag t ON t.id = ut.tag_id 
+			  WHERE ut.url_id = "url".id AND t.name = `+args.add(tag)+`)`)
+	}
+	if filter.Search != "" {
+		// Substring matches are served by the trigram indexes on these columns
+		pattern := args.add("%" + escapeLike(filter.Search) + "%")
+		conditions = append(conditions,
+			"(short_url ILIKE "+pattern+" OR long_url ILIKE "+pattern+" OR title ILIKE "+pattern+")")
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= "+args.add(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < "+args.add(*filter.CreatedTo))
+	}
+
+	return conditions
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern so the value matches literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+func (r *urlRepository) ForEachByWorkspaceID(
+	ctx context.Context,
+	workspaceID string,
+	fn func(url *entity.URL) error,
+) error {
+
+	query := `SELECT ` + urlColumns + ` 
+			  FROM "url" 
+			  WHERE workspace_id = $1 AND deleted_at IS NULL 
+			  ORDER BY created_at, id`
+
+	rows, err := r.store.Pool().Query(ctx, query, workspaceID)
+	if err != nil {
+		r.logger.Error(ctx, "Error querying URLs by workspace ID",
+			logger.String("workspaceId", workspaceID),
+			logger.String("operation", "ForEachByWorkspaceID"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		url, err := scanURL(rows)
+		if err != nil {
+			r.logger.Error(ctx, "Error scanning URL row",
+				logger.String("workspaceId", workspaceID),
+				logger.String("operation", "ForEachByWorkspaceID"),
+				logger.Error(err))
+			return errors.InternalError("database operation failed")
+		}
+		if err := fn(url); err != nil {
+			return err
+		}
+	}
 
-	query := `SELECT EXISTS(SELECT 1 FROM "url" WHERE short_url = $1)`
+	if err := rows.Err(); err != nil {
+		r.logger.Error(ctx, "Error iterating URL rows",
+			logger.String("workspaceId", workspaceID),
+			logger.String("operation", "ForEachByWorkspaceID"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	return nil
+}
+
+func (r *urlRepository) ExistsByShortCode(ctx context.Context, domain, shortCode string) (bool, error) {
+
+	query := `SELECT EXISTS(SELECT 1 FROM "url" WHERE domain = $1 AND short_url = $2)`
 
 	var exists bool
-	err := r.store.Pool().QueryRow(ctx, query, shortCode).Scan(&exists)
+	err := r.store.Pool().QueryRow(ctx, query, domain, shortCode).Scan(&exists)
 	if err != nil {
 		r.logger.Error(ctx, "Error checking if short code exists",
 			logger.String("shortCode", shortCode),
@@ -219,18 +615,112 @@ func (r *urlRepository) ExistsByShortCode(ctx context.Context, shortCode string)
 	return exists, nil
 }
 
+func (r *urlRepository) ExistsByShortCodes(
+	ctx context.Context,
+	links []repository.ShortLink,
+) (map[repository.ShortLink]bool, error) {
+	existing := make(map[repository.ShortLink]bool)
+	if len(links) == 0 {
+		return existing, nil
+	}
+
+	domains := make([]string, len(links))
+	shortCodes := make([]string, len(links))
+	for i, link := range links {
+		domains[i] = link.Domain
+		shortCodes[i] = link.ShortCode
+	}
+
+	query := `SELECT u.domain, u.short_url FROM "url" u 
+			  JOIN unnest($1::text[], $2::text[]) AS l(domain, short_url) 
+			  ON u.domain = l.domain AND u.short_url = l.short_url`
+
+	rows, err := r.store.Pool().Query(ctx, query, domains, shortCodes)
+	if err != nil {
+		r.logger.Error(ctx, "Error checking if short codes exist",
+			logger.Int("count", len(links)),
+			logger.String("operation", "ExistsByShortCodes"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var link repository.ShortLink
+		if err := rows.Scan(&link.Domain, &link.ShortCode); err != nil {
+			r.logger.Error(ctx, "Error scanning short code",
+				logger.String("operation", "ExistsByShortCodes"),
+				logger.Error(err))
+			return nil, errors.InternalError("database operation failed")
+		}
+		existing[link] = true
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error(ctx, "Error iterating short codes",
+			logger.String("operation", "ExistsByShortCodes"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return existing, nil
+}
+
+func (r *urlRepository) ExistsByDomain(ctx context.Context, domain string) (bool, error) {
+
+	query := `SELECT EXISTS(SELECT 1 FROM "url" WHERE domain = $1)`
+
+	var exists bool
+	err := r.store.Pool().QueryRow(ctx, query, domain).Scan(&exists)
+	if err != nil {
+		r.logger.Error(ctx, "Error checking if domain has URLs",
+			logger.String("domain", domain),
+			logger.String("operation", "ExistsByDomain"),
+			logger.Error(err))
+		return false, errors.InternalError("database operation failed")
+	}
+
+	return exists, nil
+}
+
 func (r *urlRepository) Update(ctx context.Context, url *entity.URL) error {
 
 	query := `UPDATE "url" 
-			  SET long_url = $1, updated_at = $2 
-			  WHERE id = $3`
+			  SET long_url = $1, expires_at = $2, max_clicks = $3, password_hash = NULLIF($4, ''), 
+			      redirect_type = $5, forward_query = $6, title = $7, description = $8, updated_at = $9 
+			  WHERE id = $10 AND deleted_at IS NULL`
 
-	cmdTag, err := r.store.Pool().Exec(ctx, query,
+	// Sent as a single pipeline, so the URL and its tags are updated together
+	batch := &pgx.Batch{}
+	batch.Queue(query,
 		url.LongURL(),
+		url.ExpiresAt(),
+		url.MaxClicks(),
+		url.PasswordHash(),
+		url.RedirectType(),
+		url.ForwardQuery(),
+		url.Title(),
+		url.Description(),
 		time.Now().UTC(),
 		url.ID(),
 	)
+	queueTags(batch, url, true)
+	if revision := url.PendingRevision(); revision != nil {
+		batch.Queue(insertRevisionQuery,
+			url.ID(),
+			revision.OldURL(),
+			revision.NewURL(),
+			revision.ActorID(),
+			revision.CreatedAt(),
+		)
+	}
+
+	results := r.store.Pool().SendBatch(ctx, batch)
+	defer results.Close()
 
+	cmdTag, err := results.Exec()
+	if err == nil {
+		err = results.Close()
+	}
 	if err != nil {
 		r.logger.Error(ctx, "Error updating URL",
 			logger.String("urlId", url.ID()),
@@ -252,16 +742,17 @@ func (r *urlRepository) Update(ctx context.Context, url *entity.URL) error {
 	return nil
 }
 
-func (r *urlRepository) Delete(ctx context.Context, id, userID string) error {
+func (r *urlRepository) MoveToTrash(ctx context.Context, id, workspaceID string) error {
 
-	query := `DELETE FROM "url" WHERE id = $1 AND user_id = $2`
+	query := `UPDATE "url" SET deleted_at = $1 
+			  WHERE id = $2 AND workspace_id = $3 AND deleted_at IS NULL`
 
-	cmdTag, err := r.store.Pool().Exec(ctx, query, id, userID)
+	cmdTag, err := r.store.Pool().Exec(ctx, query, time.Now().UTC(), id, workspaceID)
 	if err != nil {
-		r.logger.Error(ctx, "Error deleting URL",
+		r.logger.Error(ctx, "Error moving URL to trash",
 			logger.String("urlId", id),
-			logger.String("userId", userID),
-			logger.String("operation", "Delete"),
+			logger.String("workspaceId", workspaceID),
+			logger.String("operation", "MoveToTrash"),
 			logger.Error(err))
 		return errors.InternalError("database operation failed")
 	}
@@ -269,42 +760,308 @@ func (r *urlRepository) Delete(ctx context.Context, id, userID string) error {
 	if cmdTag.RowsAffected() == 0 {
 		r.logger.Debug(ctx, "URL not found for deletion or unauthorized",
 			logger.String("urlId", id),
-			logger.String("userId", userID),
-			logger.String("operation", "Delete"))
+			logger.String("workspaceId", workspaceID),
+			logger.String("operation", "MoveToTrash"))
 		return errors.NotFoundError("URL not found or not authorized")
 	}
 
-	r.logger.Info(ctx, "URL deleted successfully",
+	r.logger.Info(ctx, "URL moved to trash successfully",
 		logger.String("urlId", id),
-		logger.String("userId", userID),
-		logger.String("operation", "Delete"))
+		logger.String("workspaceId", workspaceID),
+		logger.String("operation", "MoveToTrash"))
 	return nil
 }
 
-func (r *urlRepository) IncrementRedirects(ctx context.Context, shortCode string) error {
+func (r *urlRepository) Restore(ctx context.Context, id, workspaceID string) error {
+
+	query := `UPDATE "url" SET deleted_at = NULL 
+			  WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL`
+
+	cmdTag, err := r.store.Pool().Exec(ctx, query, id, workspaceID)
+	if err != nil {
+		r.logger.Error(ctx, "Error restoring URL",
+			logger.String("urlId", id),
+			logger.String("workspaceId", workspaceID),
+			logger.String("operation", "Restore"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
 
+	if cmdTag.RowsAffected() == 0 {
+		r.logger.Debug(ctx, "URL not found in trash or unauthorized",
+			logger.String("urlId", id),
+			logger.String("workspaceId", workspaceID),
+			logger.String("operation", "Restore"))
+		return errors.NotFoundError("URL not found in trash")
+	}
+
+	r.logger.Info(ctx, "URL restored successfully",
+		logger.String("urlId", id),
+		logger.String("workspaceId", workspaceID),
+		logger.String("operation", "Restore"))
+	return nil
+}
+
+func (r *urlRepository) Disable(ctx context.Context, id, reason string) error {
+
+	// A URL disabled before keeps its original time and reason
 	query := `UPDATE "url" 
-			  SET redirects = redirects + 1, updated_at = $1 
-			  WHERE short_url = $2`
+			  SET disabled_reason = CASE WHEN disabled_at IS NULL THEN $1 ELSE disabled_reason END, 
+			      disabled_at = COALESCE(disabled_at, $2) 
+			  WHERE id = $3 AND deleted_at IS NULL`
 
-	cmdTag, err := r.store.Pool().Exec(ctx, query, time.Now().UTC(), shortCode)
+	cmdTag, err := r.store.Pool().Exec(ctx, query, reason, time.Now().UTC(), id)
 	if err != nil {
-		r.logger.Error(ctx, "Error incrementing redirects",
-			logger.String("shortCode", shortCode),
-			logger.String("operation", "IncrementRedirects"),
+		r.logger.Error(ctx, "Error disabling URL",
+			logger.String("urlId", id),
+			logger.String("operation", "Disable"),
 			logger.Error(err))
 		return errors.InternalError("database operation failed")
 	}
 
 	if cmdTag.RowsAffected() == 0 {
-		r.logger.Debug(ctx, "URL not found for redirect increment",
-			logger.String("shortCode", shortCode),
-			logger.String("operation", "IncrementRedirects"))
+		r.logger.Debug(ctx, "URL not found for disabling",
+			logger.String("urlId", id),
+			logger.String("operation", "Disable"))
 		return errors.NotFoundError("URL not found")
 	}
 
-	r.logger.Debug(ctx, "Redirects incremented successfully",
-		logger.String("shortCode", shortCode),
-		logger.String("operation", "IncrementRedirects"))
+	r.logger.Info(ctx, "URL disabled successfully",
+		logger.String("urlId", id),
+		logger.String("reason", reason),
+		logger.String("operation", "Disable"))
 	return nil
 }
+
+func (r *urlRepository) FindEnabled(ctx context.Context, afterID string, limit int) ([]*entity.URL, error) {
+
+	query := `SELECT ` + urlColumns + ` 
+			  FROM "url" 
+			  WHERE deleted_at IS NULL AND disabled_at IS NULL AND id > $1 
+			  ORDER BY id 
+			  LIMIT $2`
+
+	rows, err := r.store.Pool().Query(ctx, query, afterID, limit)
+	if err != nil {
+		r.logger.Error(ctx, "Error querying enabled URLs",
+			logger.String("afterId", afterID),
+			logger.String("operation", "FindEnabled"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+	defer rows.Close()
+
+	var urls []*entity.URL
+
+	for rows.Next() {
+		url, err := scanURL(rows)
+		if err != nil {
+			r.logger.Error(ctx, "Error scanning URL row",
+				logger.String("operation", "FindEnabled"),
+				logger.Error(err))
+			return nil, errors.InternalError("database operation failed")
+		}
+
+		urls = append(urls, url)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error(ctx, "Error iterating URL rows",
+			logger.String("operation", "FindEnabled"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return urls, nil
+}
+
+func (r *urlRepository) PurgeTrash(ctx context.Context, deletedBefore time.Time, limit int) (int, error) {
+
+	// Clicks and tag links are deleted with their URL by the foreign keys
+	query := `DELETE FROM "url" 
+			  WHERE id IN (SELECT id FROM "url" WHERE deleted_at < $1 ORDER BY deleted_at LIMIT $2)`
+
+	cmdTag, err := r.store.Pool().Exec(ctx, query, deletedBefore, limit)
+	if err != nil {
+		r.logger.Error(ctx, "Error purging trash",
+			logger.String("operation", "PurgeTrash"),
+			logger.Error(err))
+		return 0, errors.InternalError("database operation failed")
+	}
+
+	r.logger.Debug(ctx, "Trash purged successfully",
+		logger.Int64("rowsAffected", cmdTag.RowsAffected()),
+		logger.String("operation", "PurgeTrash"))
+	return int(cmdTag.RowsAffected()), nil
+}
+
+func (r *urlRepository) AddRedirects(ctx context.Context, counts map[string]int) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(counts))
+	increments := make([]int32, 0, len(counts))
+	for id, count := range counts {
+		ids = append(ids, id)
+		increments = append(increments, int32(count))
+	}
+
+	// Counts are applied in a single statement and deliberately leave updated_at
+	// untouched, since following a link does not modify it
+	query := `UPDATE "url" AS u 
+			  SET redirects = u.redirects + c.count 
+			  FROM unnest($1::text[], $2::int[]) AS c(id, count) 
+			  WHERE u.id = c.id`
+
+	cmdTag, err := r.store.Pool().Exec(ctx, query, ids, increments)
+	if err != nil {
+		r.logger.Error(ctx, "Error adding redirects",
+			logger.Int("count", len(counts)),
+			logger.String("operation", "AddRedirects"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	r.logger.Debug(ctx, "Redirects added successfully",
+		logger.Int("count", len(counts)),
+		logger.Int64("rowsAffected", cmdTag.RowsAffected()),
+		logger.String("operation", "AddRedirects"))
+	return nil
+}
+
+func (r *urlRepository) ClaimRedirect(ctx context.Context, id string, pending int) (bool, error) {
+
+	// The limit is checked by the update itself, so concurrent claims are serialised by
+	// the row lock and cannot both take the last redirect
+	query := `UPDATE "url" 
+			  SET redirects = redirects + 1 
+			  WHERE id = $1 AND (max_clicks IS NULL OR redirects + $2 < max_clicks)`
+
+	cmdTag, err := r.store.Pool().Exec(ctx, query, id, pending)
+	if err != nil {
+		r.logger.Error(ctx, "Error claiming redirect",
+			logger.String("urlId", id),
+			logger.String("operation", "ClaimRedirect"),
+			logger.Error(err))
+		return false, errors.InternalError("database operation failed")
+	}
+
+	return cmdTag.RowsAffected() == 1, nil
+}
+
+func (r *urlRepository) ListTags(ctx context.Context, workspaceID string) ([]entity.TagCount, error) {
+
+	// Tags are kept per creator of the URL, so those of different members merge by name
+	query := `SELECT t.name, COUNT(*) 
+			  FROM tag t 
+			  JOIN url_tag ut ON ut.tag_id = t.id 
+			  JOIN "url" u ON u.id = ut.url_id 
+			  WHERE u.workspace_id = $1 AND u.deleted_at IS NULL 
+			  GROUP BY t.name 
+			  ORDER BY t.name`
+
+	rows, err := r.store.Pool().Query(ctx, query, workspaceID)
+	if err != nil {
+		r.logger.Error(ctx, "Error querying tags by workspace ID",
+			logger.String("workspaceId", workspaceID),
+			logger.String("operation", "ListTags"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	tags, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.TagCount, error) {
+		var tag entity.TagCount
+		err := row.Scan(&tag.Name, &tag.URLs)
+		return tag, err
+	})
+	if err != nil {
+		r.logger.Error(ctx, "Error scanning tag rows",
+			logger.String("workspaceId", workspaceID),
+			logger.String("operation", "ListTags"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return tags, nil
+}
+
+// insertRevisionQuery records a destination change of a URL unless it is in the trash.
+// The actor is stored as NULL when empty.
+const insertRevisionQuery = `INSERT INTO url_revision (url_id, old_url, new_url, actor_id, created_at) 
+		  SELECT id, $2, $3, NULLIF($4, ''), $5 FROM "url" WHERE id = $1 AND deleted_at IS NULL`
+
+const revisionColumns = `id, url_id, old_url, new_url, COALESCE(actor_id, ''), created_at`
+
+func scanRevision(row pgx.CollectableRow) (*entity.URLRevision, error) {
+	var (
+		id                             int64
+		urlID, oldURL, newURL, actorID string
+		createdAt                      time.Time
+	)
+	if err := row.Scan(&id, &urlID, &oldURL, &newURL, &actorID, &createdAt); err != nil {
+		return nil, err
+	}
+	return entity.NewURLRevisionFromRepository(id, strings.TrimSpace(urlID), oldURL, newURL,
+		strings.TrimSpace(actorID), createdAt), nil
+}
+
+func (r *urlRepository) FindRevisions(
+	ctx context.Context,
+	urlID string,
+	before int64,
+	limit int,
+) ([]*entity.URLRevision, error) {
+
+	query := `SELECT ` + revisionColumns + ` FROM url_revision 
+			  WHERE url_id = $1 AND ($2::bigint = 0 OR id < $2) 
+			  ORDER BY id DESC LIMIT $3`
+
+	rows, err := r.store.Pool().Query(ctx, query, urlID, before, limit)
+	if err != nil {
+		r.logger.Error(ctx, "Error querying URL revisions",
+			logger.String("urlId", urlID),
+			logger.String("operation", "FindRevisions"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	revisions, err := pgx.CollectRows(rows, scanRevision)
+	if err != nil {
+		r.logger.Error(ctx, "Error scanning URL revision rows",
+			logger.String("urlId", urlID),
+			logger.String("operation", "FindRevisions"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return revisions, nil
+}
+
+func (r *urlRepository) FindRevision(ctx context.Context, urlID string, id int64) (*entity.URLRevision, error) {
+
+	query := `SELECT ` + revisionColumns + ` FROM url_revision WHERE url_id = $1 AND id = $2`
+
+	rows, err := r.store.Pool().Query(ctx, query, urlID, id)
+	if err != nil {
+		r.logger.Error(ctx, "Error querying URL revision",
+			logger.String("urlId", urlID),
+			logger.String("operation", "FindRevision"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	revision, err := pgx.CollectExactlyOneRow(rows, scanRevision)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFoundError("revision not found")
+		}
+		r.logger.Error(ctx, "Error scanning URL revision",
+			logger.String("urlId", urlID),
+			logger.String("operation", "FindRevision"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return revision, nil
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository"
+	"github.com/PraveenGongada/shortly/internal/domain/url/repository/repositorytest"
+)
+
+func TestURLRepository(t *testing.T) {
+	requireTestDatabase(t)
+
+	var s Store
+	repositorytest.TestURLRepository(t, repositorytest.URLRepositoryHarness{
+		New: func(t *testing.T) repository.URLRepository {
+			s = newTestStore(t)
+			return NewURLRepository(s, testLogger)
+		},
+		// URLs reference their creator and workspace through foreign keys
+		CreateUser: func(t *testing.T, userID string) {
+			_, err := s.Pool().Exec(context.Background(),
+				`INSERT INTO "user" (id, name, email, password) VALUES ($1, 'Test User', $1 || '@example.com', 'hash')`,
+				userID)
+			if err != nil {
+				t.Fatalf("creating user: %v", err)
+			}
+			_, err = s.Pool().Exec(context.Background(),
+				`INSERT INTO workspace (id, name, personal_user_id) VALUES ($1, 'Personal', $1)`, userID)
+			if err != nil {
+				t.Fatalf("creating workspace: %v", err)
+			}
+		},
+	})
+}
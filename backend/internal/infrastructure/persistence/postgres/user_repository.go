@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -31,6 +32,9 @@ import (
 	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
 )
 
+// userColumns lists the columns of a user in the order scanUser reads them
+const userColumns = `id, name, email, password, role, token_version, created_at, updated_at, suspended_at`
+
 type userRepository struct {
 	store  Store
 	logger logger.Logger
@@ -44,6 +48,23 @@ func NewUserRepository(store Store, logger logger.Logger) repository.UserReposit
 	}
 }
 
+// scanUser reads a single row selected with userColumns into a user entity
+func scanUser(row pgx.Row) (*entity.User, error) {
+	var id, name, email, password, role string
+	var tokenVersion int
+	var createdAt time.Time
+	var updatedAt, suspendedAt *time.Time
+
+	err := row.Scan(&id, &name, &email, &password, &role, &tokenVersion, &createdAt, &updatedAt, &suspendedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewUserFromRepository(
+		id, email, password, name, role, tokenVersion, createdAt, updatedAt, suspendedAt,
+	), nil
+}
+
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	// Hash email for privacy-safe logging - same approach as in service layer
 	emailHash := fmt.Sprintf("%x", sha256.Sum256([]byte(email)))[:12]
@@ -51,15 +72,9 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity
 		logger.String("emailHash", emailHash),
 		logger.String("operation", "FindByEmail"))
 
-	query := `SELECT id, name, email, password, created_at, updated_at FROM "user" WHERE email=$1`
-
-	var id, name, userEmail, password string
-	var createdAt time.Time
-	var updatedAt *time.Time
+	query := `SELECT ` + userColumns + ` FROM "user" WHERE email=$1`
 
-	err := r.store.Pool().QueryRow(ctx, query, email).Scan(
-		&id, &name, &userEmail, &password, &createdAt, &updatedAt,
-	)
+	user, err := scanUser(r.store.Pool().QueryRow(ctx, query, email))
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -75,7 +90,6 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity
 		return nil, errors.InternalError("database operation failed")
 	}
 
-	user := entity.NewUserFromRepository(id, userEmail, password, name, createdAt, updatedAt)
 	r.logger.Debug(ctx, "User found successfully",
 		logger.String("emailHash", emailHash),
 		logger.String("userId", user.ID()),
@@ -85,15 +99,9 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity
 
 func (r *userRepository) FindByID(ctx context.Context, id string) (*entity.User, error) {
 
-	query := `SELECT id, name, email, password, created_at, updated_at FROM "user" WHERE id=$1`
+	query := `SELECT ` + userColumns + ` FROM "user" WHERE id=$1`
 
-	var userId, name, email, password string
-	var createdAt time.Time
-	var updatedAt *time.Time
-
-	err := r.store.Pool().QueryRow(ctx, query, id).Scan(
-		&userId, &name, &email, &password, &createdAt, &updatedAt,
-	)
+	user, err := scanUser(r.store.Pool().QueryRow(ctx, query, id))
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -109,7 +117,6 @@ func (r *userRepository) FindByID(ctx context.Context, id string) (*entity.User,
 		return nil, errors.InternalError("database operation failed")
 	}
 
-	user := entity.NewUserFromRepository(userId, email, password, name, createdAt, updatedAt)
 	r.logger.Debug(ctx, "User found successfully",
 		logger.String("userId", id),
 		logger.String("operation", "FindByID"))
@@ -118,21 +125,18 @@ func (r *userRepository) FindByID(ctx context.Context, id string) (*entity.User,
 
 func (r *userRepository) Save(ctx context.Context, user *entity.User) (*entity.User, error) {
 
-	query := `INSERT INTO "user" (id, name, email, password, created_at) 
-			  VALUES ($1, $2, $3, $4, $5) 
-			  RETURNING id, name, email, password, created_at, updated_at`
-
-	var id, name, email, password string
-	var createdAt time.Time
-	var updatedAt *time.Time
+	query := `INSERT INTO "user" (id, name, email, password, role, created_at) 
+			  VALUES ($1, $2, $3, $4, $5, $6) 
+			  RETURNING ` + userColumns
 
-	err := r.store.Pool().QueryRow(ctx, query,
+	savedUser, err := scanUser(r.store.Pool().QueryRow(ctx, query,
 		user.ID(),
 		user.Name(),
 		user.Email(),
 		user.HashedPassword(),
+		user.Role(),
 		user.CreatedAt(),
-	).Scan(&id, &name, &email, &password, &createdAt, &updatedAt)
+	))
 
 	if err != nil {
 		pgErr, ok := err.(*pgconn.PgError)
@@ -149,13 +153,50 @@ func (r *userRepository) Save(ctx context.Context, user *entity.User) (*entity.U
 		return nil, errors.InternalError("database operation failed")
 	}
 
-	savedUser := entity.NewUserFromRepository(id, email, password, name, createdAt, updatedAt)
 	r.logger.Info(ctx, "User saved successfully",
 		logger.String("userId", user.ID()),
 		logger.String("operation", "Save"))
 	return savedUser, nil
 }
 
+func (r *userRepository) Update(ctx context.Context, user *entity.User) (*entity.User, error) {
+
+	query := `UPDATE "user" SET name = $2, email = $3, password = $4, updated_at = $5 
+			  WHERE id = $1 
+			  RETURNING ` + userColumns
+
+	updatedUser, err := scanUser(r.store.Pool().QueryRow(ctx, query,
+		user.ID(),
+		user.Name(),
+		user.Email(),
+		user.HashedPassword(),
+		user.UpdatedAt(),
+	))
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFoundError("user not found")
+		}
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == "23505" { // unique_violation
+			r.logger.Warn(ctx, "Email already exists",
+				logger.String("userId", user.ID()),
+				logger.String("operation", "Update"))
+			return nil, errors.ConflictError("email already registered")
+		}
+		r.logger.Error(ctx, "Error updating user",
+			logger.String("userId", user.ID()),
+			logger.String("operation", "Update"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	r.logger.Info(ctx, "User updated successfully",
+		logger.String("userId", user.ID()),
+		logger.String("operation", "Update"))
+	return updatedUser, nil
+}
+
 func (r *userRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 
 	query := `SELECT EXISTS(SELECT 1 FROM "user" WHERE email = $1)`
@@ -171,3 +212,166 @@ func (r *userRepository) ExistsByEmail(ctx context.Context, email string) (bool,
 
 	return exists, nil
 }
+
+func (r *userRepository) IncrementTokenVersion(ctx context.Context, id string) (int, error) {
+
+	query := `UPDATE "user" SET token_version = token_version + 1 WHERE id = $1 RETURNING token_version`
+
+	var tokenVersion int
+	err := r.store.Pool().QueryRow(ctx, query, id).Scan(&tokenVersion)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, errors.NotFoundError("user not found")
+		}
+		r.logger.Error(ctx, "Error incrementing token version",
+			logger.String("userId", id),
+			logger.String("operation", "IncrementTokenVersion"),
+			logger.Error(err))
+		return 0, errors.InternalError("database operation failed")
+	}
+
+	r.logger.Info(ctx, "Token version incremented",
+		logger.String("userId", id),
+		logger.Int("tokenVersion", tokenVersion),
+		logger.String("operation", "IncrementTokenVersion"))
+	return tokenVersion, nil
+}
+
+func (r *userRepository) FindAll(
+	ctx context.Context,
+	filter repository.UserFilter,
+	after *repository.UserCursor,
+	limit int,
+) ([]*entity.User, error) {
+
+	var args queryArgs
+	conditions := userFilterConditions(&args, filter)
+	if after != nil {
+		// Rows after the cursor, newest first, served by user_created_at_id_idx
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < (%s, %s)",
+			args.add(after.CreatedAt), args.add(after.ID)))
+	}
+	query := fmt.Sprintf(`SELECT `+userColumns+` 
+			  FROM "user" 
+			  WHERE %s 
+			  ORDER BY created_at DESC, id DESC 
+			  LIMIT %s`, strings.Join(conditions, " AND "), args.add(limit))
+
+	rows, err := r.store.Pool().Query(ctx, query, args...)
+	if err != nil {
+		r.logger.Error(ctx, "Error querying users",
+			logger.Int("limit", limit),
+			logger.String("operation", "FindAll"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+	defer rows.Close()
+
+	var users []*entity.User
+
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			r.logger.Error(ctx, "Error scanning user row",
+				logger.String("operation", "FindAll"),
+				logger.Error(err))
+			return nil, errors.InternalError("database operation failed")
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error(ctx, "Error iterating user rows",
+			logger.String("operation", "FindAll"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return users, nil
+}
+
+func (r *userRepository) CountAll(ctx context.Context, filter repository.UserFilter) (int, error) {
+
+	var args queryArgs
+	query := `SELECT COUNT(*) FROM "user" WHERE ` + strings.Join(userFilterConditions(&args, filter), " AND ")
+
+	var count int
+	if err := r.store.Pool().QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		r.logger.Error(ctx, "Error counting users",
+			logger.String("operation", "CountAll"),
+			logger.Error(err))
+		return 0, errors.InternalError("database operation failed")
+	}
+
+	return count, nil
+}
+
+// userFilterConditions returns the WHERE conditions selecting the users that match the
+// filter, adding their arguments to args
+func userFilterConditions(args *queryArgs, filter repository.UserFilter) []string {
+	conditions := []string{"TRUE"}
+	if filter.Search != "" {
+		// Substring matches are served by the trigram indexes on these columns
+		pattern := args.add("%" + escapeLike(filter.Search) + "%")
+		conditions = append(conditions, "(email ILIKE "+pattern+" OR name ILIKE "+pattern+")")
+	}
+	if filter.Role != "" {
+		conditions = append(conditions, "role = "+args.add(filter.Role))
+	}
+	if filter.Suspended {
+		conditions = append(conditions, "suspended_at IS NOT NULL")
+	}
+
+	return conditions
+}
+
+func (r *userRepository) Suspend(ctx context.Context, id string, suspendedAt time.Time) (int, error) {
+
+	query := `UPDATE "user" 
+			  SET suspended_at = COALESCE(suspended_at, $2), token_version = token_version + 1 
+			  WHERE id = $1 
+			  RETURNING token_version`
+
+	var tokenVersion int
+	err := r.store.Pool().QueryRow(ctx, query, id, suspendedAt).Scan(&tokenVersion)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, errors.NotFoundError("user not found")
+		}
+		r.logger.Error(ctx, "Error suspending user",
+			logger.String("userId", id),
+			logger.String("operation", "Suspend"),
+			logger.Error(err))
+		return 0, errors.InternalError("database operation failed")
+	}
+
+	r.logger.Info(ctx, "User suspended",
+		logger.String("userId", id),
+		logger.Int("tokenVersion", tokenVersion),
+		logger.String("operation", "Suspend"))
+	return tokenVersion, nil
+}
+
+func (r *userRepository) Unsuspend(ctx context.Context, id string) error {
+
+	query := `UPDATE "user" SET suspended_at = NULL WHERE id = $1`
+
+	cmdTag, err := r.store.Pool().Exec(ctx, query, id)
+	if err != nil {
+		r.logger.Error(ctx, "Error unsuspending user",
+			logger.String("userId", id),
+			logger.String("operation", "Unsuspend"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errors.NotFoundError("user not found")
+	}
+
+	r.logger.Info(ctx, "User unsuspended",
+		logger.String("userId", id),
+		logger.String("operation", "Unsuspend"))
+	return nil
+}
@@ -0,0 +1,458 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/entity"
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/repository"
+)
+
+const (
+	workspaceColumns = `w.id, w.name, COALESCE(w.personal_user_id, ''), w.created_at`
+
+	memberColumns = `m.workspace_id, m.user_id, u.email, m.role, m.created_at`
+
+	invitationColumns = `id, workspace_id, email, role, token_hash, invited_by, expires_at, created_at`
+
+	insertMemberQuery = `INSERT INTO workspace_member (workspace_id, user_id, role, created_at) 
+			  VALUES ($1, $2, $3, $4)`
+)
+
+type workspaceRepository struct {
+	store  Store
+	logger logger.Logger
+}
+
+// NewWorkspaceRepository creates a new workspace repository implementation
+func NewWorkspaceRepository(store Store, logger logger.Logger) repository.WorkspaceRepository {
+	return &workspaceRepository{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func scanWorkspace(row pgx.Row) (*entity.Workspace, error) {
+	var id, name, personalUserID string
+	var createdAt time.Time
+	if err := row.Scan(&id, &name, &personalUserID, &createdAt); err != nil {
+		return nil, err
+	}
+	return entity.NewWorkspaceFromRepository(id, name, strings.TrimSpace(personalUserID), createdAt), nil
+}
+
+func scanMember(row pgx.Row) (*entity.Member, error) {
+	var workspaceID, userID, email, role string
+	var createdAt time.Time
+	if err := row.Scan(&workspaceID, &userID, &email, &role, &createdAt); err != nil {
+		return nil, err
+	}
+	return entity.NewMemberFromRepository(workspaceID, userID, email, entity.Role(role), createdAt), nil
+}
+
+func scanInvitation(row pgx.Row) (*entity.Invitation, error) {
+	var id, workspaceID, email, role, tokenHash, invitedBy string
+	var expiresAt, createdAt time.Time
+	err := row.Scan(&id, &workspaceID, &email, &role, &tokenHash, &invitedBy, &expiresAt, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	return entity.NewInvitationFromRepository(
+		id, workspaceID, email, entity.Role(role), tokenHash, invitedBy, expiresAt, createdAt,
+	), nil
+}
+
+func (r *workspaceRepository) Save(
+	ctx context.Context,
+	workspace *entity.Workspace,
+	owner *entity.Member,
+) error {
+	query := `INSERT INTO workspace (id, name, personal_user_id, created_at) 
+			  VALUES ($1, $2, NULLIF($3, ''), $4)`
+
+	// Sent as a single pipeline, so the workspace is only saved together with its owner
+	batch := &pgx.Batch{}
+	batch.Queue(query, workspace.ID(), workspace.Name(), workspace.PersonalUserID(), workspace.CreatedAt())
+	batch.Queue(insertMemberQuery, owner.WorkspaceID(), owner.UserID(), string(owner.Role()), owner.CreatedAt())
+
+	if err := r.store.Pool().SendBatch(ctx, batch).Close(); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == "23505" { // unique_violation
+			return errors.ConflictError("workspace already exists")
+		}
+		r.logger.Error(ctx, "Error saving workspace",
+			logger.String("workspaceId", workspace.ID()),
+			logger.String("operation", "Save"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	r.logger.Info(ctx, "Workspace saved successfully",
+		logger.String("workspaceId", workspace.ID()),
+		logger.String("userId", owner.UserID()),
+		logger.String("operation", "Save"))
+	return nil
+}
+
+func (r *workspaceRepository) FindByID(ctx context.Context, id string) (*entity.Workspace, error) {
+	query := `SELECT ` + workspaceColumns + ` FROM workspace w WHERE w.id = $1`
+
+	return r.findOne(ctx, "FindByID", query, id)
+}
+
+func (r *workspaceRepository) FindPersonal(ctx context.Context, userID string) (*entity.Workspace, error) {
+	query := `SELECT ` + workspaceColumns + ` FROM workspace w WHERE w.personal_user_id = $1`
+
+	return r.findOne(ctx, "FindPersonal", query, userID)
+}
+
+// findOne reads the single workspace selected by query, if any
+func (r *workspaceRepository) findOne(
+	ctx context.Context,
+	operation, query string,
+	args ...any,
+) (*entity.Workspace, error) {
+	workspace, err := scanWorkspace(r.store.Pool().QueryRow(ctx, query, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFoundError("workspace not found")
+		}
+		r.logger.Error(ctx, "Error finding workspace",
+			logger.String("operation", operation),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return workspace, nil
+}
+
+func (r *workspaceRepository) FindByUserID(ctx context.Context, userID string) ([]entity.Membership, error) {
+	query := `SELECT ` + workspaceColumns + `, m.role 
+			  FROM workspace w 
+			  JOIN workspace_member m ON m.workspace_id = w.id 
+			  WHERE m.user_id = $1 
+			  ORDER BY w.personal_user_id IS NULL, w.name, w.id`
+
+	rows, err := r.store.Pool().Query(ctx, query, userID)
+	if err != nil {
+		r.logger.Error(ctx, "Error finding workspaces by user ID",
+			logger.String("userId", userID),
+			logger.String("operation", "FindByUserID"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	memberships, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Membership, error) {
+		var id, name, personalUserID, role string
+		var createdAt time.Time
+		if err := row.Scan(&id, &name, &personalUserID, &createdAt, &role); err != nil {
+			return entity.Membership{}, err
+		}
+		return entity.Membership{
+			Workspace: entity.NewWorkspaceFromRepository(id, name, strings.TrimSpace(personalUserID), createdAt),
+			Role:      entity.Role(role),
+		}, nil
+	})
+	if err != nil {
+		r.logger.Error(ctx, "Error scanning workspace rows",
+			logger.String("userId", userID),
+			logger.String("operation", "FindByUserID"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return memberships, nil
+}
+
+func (r *workspaceRepository) FindMember(ctx context.Context, workspaceID, userID string) (*entity.Member, error) {
+	query := `SELECT ` + memberColumns + ` 
+			  FROM workspace_member m JOIN "user" u ON u.id = m.user_id 
+			  WHERE m.workspace_id = $1 AND m.user_id = $2`
+
+	member, err := scanMember(r.store.Pool().QueryRow(ctx, query, workspaceID, userID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFoundError("member not found")
+		}
+		r.logger.Error(ctx, "Error finding workspace member",
+			logger.String("workspaceId", workspaceID),
+			logger.String("userId", userID),
+			logger.String("operation", "FindMember"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return member, nil
+}
+
+func (r *workspaceRepository) FindMembers(ctx context.Context, workspaceID string) ([]*entity.Member, error) {
+	query := `SELECT ` + memberColumns + ` 
+			  FROM workspace_member m JOIN "user" u ON u.id = m.user_id 
+			  WHERE m.workspace_id = $1 
+			  ORDER BY u.email, m.user_id`
+
+	rows, err := r.store.Pool().Query(ctx, query, workspaceID)
+	if err != nil {
+		r.logger.Error(ctx, "Error finding workspace members",
+			logger.String("workspaceId", workspaceID),
+			logger.String("operation", "FindMembers"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	members, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entity.Member, error) {
+		return scanMember(row)
+	})
+	if err != nil {
+		r.logger.Error(ctx, "Error scanning workspace member rows",
+			logger.String("workspaceId", workspaceID),
+			logger.String("operation", "FindMembers"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return members, nil
+}
+
+func (r *workspaceRepository) UpdateMemberRole(
+	ctx context.Context,
+	workspaceID, userID string,
+	role entity.Role,
+) error {
+	// The owners are locked while they are counted, so that concurrent changes cannot
+	// take the role from each of the last two owners
+	query := `WITH owners AS (
+				SELECT user_id FROM workspace_member
+				WHERE workspace_id = $2 AND role = 'owner'
+				FOR UPDATE
+			  )
+			  UPDATE workspace_member SET role = $1
+			  WHERE workspace_id = $2 AND user_id = $3
+			  AND (role <> 'owner' OR $1 = 'owner' OR (SELECT count(*) FROM owners) > 1)`
+
+	return r.execMember(ctx, "UpdateMemberRole", workspaceID, userID, query, string(role), workspaceID, userID)
+}
+
+func (r *workspaceRepository) RemoveMember(ctx context.Context, workspaceID, userID string) error {
+	query := `WITH owners AS (
+				SELECT user_id FROM workspace_member
+				WHERE workspace_id = $1 AND role = 'owner'
+				FOR UPDATE
+			  )
+			  DELETE FROM workspace_member
+			  WHERE workspace_id = $1 AND user_id = $2
+			  AND (role <> 'owner' OR (SELECT count(*) FROM owners) > 1)`
+
+	return r.execMember(ctx, "RemoveMember", workspaceID, userID, query, workspaceID, userID)
+}
+
+// execMember runs a statement changing a single member, which has to exist and must
+// not be the last owner of the workspace
+func (r *workspaceRepository) execMember(
+	ctx context.Context,
+	operation, workspaceID, userID, query string,
+	args ...any,
+) error {
+	cmdTag, err := r.store.Pool().Exec(ctx, query, args...)
+	if err != nil {
+		r.logger.Error(ctx, "Error changing workspace member",
+			logger.String("workspaceId", workspaceID),
+			logger.String("userId", userID),
+			logger.String("operation", operation),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		if _, err := r.FindMember(ctx, workspaceID, userID); err != nil {
+			return err
+		}
+		return errors.ConflictError("a workspace needs at least one owner")
+	}
+
+	r.logger.Info(ctx, "Workspace member changed successfully",
+		logger.String("workspaceId", workspaceID),
+		logger.String("userId", userID),
+		logger.String("operation", operation))
+	return nil
+}
+
+func (r *workspaceRepository) SaveInvitation(ctx context.Context, invitation *entity.Invitation) error {
+	query := `INSERT INTO workspace_invitation (` + invitationColumns + `) 
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8) 
+			  ON CONFLICT (workspace_id, email) DO UPDATE 
+			  SET id = EXCLUDED.id, role = EXCLUDED.role, token_hash = EXCLUDED.token_hash, 
+			      invited_by = EXCLUDED.invited_by, expires_at = EXCLUDED.expires_at, created_at = EXCLUDED.created_at`
+
+	_, err := r.store.Pool().Exec(ctx, query,
+		invitation.ID(),
+		invitation.WorkspaceID(),
+		invitation.Email(),
+		string(invitation.Role()),
+		invitation.TokenHash(),
+		invitation.InvitedBy(),
+		invitation.ExpiresAt(),
+		invitation.CreatedAt(),
+	)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == "23503" { // foreign_key_violation
+			return errors.NotFoundError("workspace not found")
+		}
+		r.logger.Error(ctx, "Error saving workspace invitation",
+			logger.String("workspaceId", invitation.WorkspaceID()),
+			logger.String("operation", "SaveInvitation"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	r.logger.Info(ctx, "Workspace invitation saved successfully",
+		logger.String("workspaceId", invitation.WorkspaceID()),
+		logger.String("invitationId", invitation.ID()),
+		logger.String("operation", "SaveInvitation"))
+	return nil
+}
+
+func (r *workspaceRepository) FindInvitationByHash(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM workspace_invitation WHERE token_hash = $1`
+
+	invitation, err := scanInvitation(r.store.Pool().QueryRow(ctx, query, tokenHash))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.NotFoundError("invitation not found")
+		}
+		r.logger.Error(ctx, "Error finding workspace invitation",
+			logger.String("operation", "FindInvitationByHash"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return invitation, nil
+}
+
+func (r *workspaceRepository) FindInvitations(ctx context.Context, workspaceID string) ([]*entity.Invitation, error) {
+	query := `SELECT ` + invitationColumns + ` FROM workspace_invitation 
+			  WHERE workspace_id = $1 
+			  ORDER BY created_at DESC, id`
+
+	rows, err := r.store.Pool().Query(ctx, query, workspaceID)
+	if err != nil {
+		r.logger.Error(ctx, "Error finding workspace invitations",
+			logger.String("workspaceId", workspaceID),
+			logger.String("operation", "FindInvitations"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	invitations, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*entity.Invitation, error) {
+		return scanInvitation(row)
+	})
+	if err != nil {
+		r.logger.Error(ctx, "Error scanning workspace invitation rows",
+			logger.String("workspaceId", workspaceID),
+			logger.String("operation", "FindInvitations"),
+			logger.Error(err))
+		return nil, errors.InternalError("database operation failed")
+	}
+
+	return invitations, nil
+}
+
+func (r *workspaceRepository) DeleteInvitation(ctx context.Context, id, workspaceID string) error {
+	query := `DELETE FROM workspace_invitation WHERE id = $1 AND workspace_id = $2`
+
+	cmdTag, err := r.store.Pool().Exec(ctx, query, id, workspaceID)
+	if err != nil {
+		r.logger.Error(ctx, "Error deleting workspace invitation",
+			logger.String("invitationId", id),
+			logger.String("workspaceId", workspaceID),
+			logger.String("operation", "DeleteInvitation"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errors.NotFoundError("invitation not found")
+	}
+
+	r.logger.Info(ctx, "Workspace invitation deleted successfully",
+		logger.String("invitationId", id),
+		logger.String("workspaceId", workspaceID),
+		logger.String("operation", "DeleteInvitation"))
+	return nil
+}
+
+func (r *workspaceRepository) AcceptInvitation(
+	ctx context.Context,
+	invitationID string,
+	member *entity.Member,
+) error {
+	// The member is only added while the invitation exists, and the invitation only
+	// deleted along with the insert, since the pipeline runs as a single transaction
+	insertQuery := `INSERT INTO workspace_member (workspace_id, user_id, role, created_at) 
+			  SELECT $1, $2, $3, $4 
+			  WHERE EXISTS (SELECT 1 FROM workspace_invitation WHERE id = $5)`
+	deleteQuery := `DELETE FROM workspace_invitation WHERE id = $1`
+
+	batch := &pgx.Batch{}
+	batch.Queue(insertQuery,
+		member.WorkspaceID(),
+		member.UserID(),
+		string(member.Role()),
+		member.CreatedAt(),
+		invitationID,
+	)
+	batch.Queue(deleteQuery, invitationID)
+
+	results := r.store.Pool().SendBatch(ctx, batch)
+	defer results.Close()
+
+	cmdTag, err := results.Exec()
+	if err == nil {
+		err = results.Close()
+	}
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == "23505" { // unique_violation
+			return errors.ConflictError("user is already a member")
+		}
+		r.logger.Error(ctx, "Error accepting workspace invitation",
+			logger.String("invitationId", invitationID),
+			logger.String("operation", "AcceptInvitation"),
+			logger.Error(err))
+		return errors.InternalError("database operation failed")
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return errors.NotFoundError("invitation not found")
+	}
+
+	r.logger.Info(ctx, "Workspace invitation accepted successfully",
+		logger.String("invitationId", invitationID),
+		logger.String("workspaceId", member.WorkspaceID()),
+		logger.String("userId", member.UserID()),
+		logger.String("operation", "AcceptInvitation"))
+	return nil
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/repository"
+	"github.com/PraveenGongada/shortly/internal/domain/workspace/repository/repositorytest"
+)
+
+func TestWorkspaceRepository(t *testing.T) {
+	requireTestDatabase(t)
+
+	var s Store
+	repositorytest.TestWorkspaceRepository(t, repositorytest.WorkspaceRepositoryHarness{
+		New: func(t *testing.T) repository.WorkspaceRepository {
+			s = newTestStore(t)
+			return NewWorkspaceRepository(s, testLogger)
+		},
+		CreateUser: func(t *testing.T, userID, email string) {
+			_, err := s.Pool().Exec(context.Background(),
+				`INSERT INTO "user" (id, name, email, password) VALUES ($1, 'Test User', $2, 'hash')`,
+				userID, email)
+			if err != nil {
+				t.Fatalf("creating user: %v", err)
+			}
+		},
+	})
+}
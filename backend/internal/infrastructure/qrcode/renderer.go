@@ -0,0 +1,156 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package qrcode renders QR codes as PNG or SVG images
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	goqrcode "github.com/skip2/go-qrcode"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+)
+
+// Renderer defines interface for rendering content as a QR code image
+type Renderer interface {
+	Render(content string, options valueobject.QRCodeOptions) ([]byte, error)
+}
+
+type renderer struct{}
+
+func NewRenderer() Renderer {
+	return &renderer{}
+}
+
+func (r *renderer) Render(content string, options valueobject.QRCodeOptions) ([]byte, error) {
+	level, err := recoveryLevel(options.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := goqrcode.New(content, level)
+	if err != nil {
+		return nil, fmt.Errorf("encode QR code: %w", err)
+	}
+	// The quiet zone is drawn from options.Margin instead of the fixed library border
+	code.DisableBorder = true
+	modules := code.Bitmap()
+
+	switch options.Format {
+	case valueobject.QRCodeFormatSVG:
+		return renderSVG(modules, options), nil
+	case valueobject.QRCodeFormatPNG:
+		return renderPNG(modules, options)
+	default:
+		return nil, errors.ValidationError("unsupported QR code format")
+	}
+}
+
+func recoveryLevel(level string) (goqrcode.RecoveryLevel, error) {
+	switch level {
+	case "L":
+		return goqrcode.Low, nil
+	case "M":
+		return goqrcode.Medium, nil
+	case "Q":
+		return goqrcode.High, nil
+	case "H":
+		return goqrcode.Highest, nil
+	default:
+		return 0, errors.ValidationError("unsupported error correction level")
+	}
+}
+
+// renderPNG draws each module as a square of whole pixels, centred in the image so
+// edges stay sharp at any size
+func renderPNG(modules [][]bool, options valueobject.QRCodeOptions) ([]byte, error) {
+	total := len(modules) + 2*options.Margin
+	scale := options.Size / total
+	if scale == 0 {
+		return nil, errors.ValidationError(
+			fmt.Sprintf("size must be at least %d pixels for this QR code", total))
+	}
+	offset := (options.Size-total*scale)/2 + options.Margin*scale
+
+	img := image.NewPaletted(
+		image.Rect(0, 0, options.Size, options.Size),
+		color.Palette{options.Background, options.Foreground},
+	)
+	for y, row := range modules {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			for py := 0; py < scale; py++ {
+				start := img.PixOffset(offset+x*scale, offset+y*scale+py)
+				for px := 0; px < scale; px++ {
+					img.Pix[start+px] = 1
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encode PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderSVG draws the code in module units and lets the viewBox scale it, merging
+// horizontal runs of dark modules into single path segments
+func renderSVG(modules [][]bool, options valueobject.QRCodeOptions) []byte {
+	total := len(modules) + 2*options.Margin
+
+	var path strings.Builder
+	for y, row := range modules {
+		for x := 0; x < len(row); {
+			if !row[x] {
+				x++
+				continue
+			}
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+			fmt.Fprintf(&path, "M%d %dh%dv1h-%dz", start+options.Margin, y+options.Margin, x-start, x-start)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		options.Size, options.Size, total, total)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d"%s/>`, total, total, svgFill(options.Background))
+	fmt.Fprintf(&buf, `<path d="%s"%s/>`, path.String(), svgFill(options.Foreground))
+	buf.WriteString("</svg>\n")
+	return buf.Bytes()
+}
+
+func svgFill(c color.NRGBA) string {
+	fill := fmt.Sprintf(` fill="#%02x%02x%02x"`, c.R, c.G, c.B)
+	if c.A != 0xff {
+		fill += fmt.Sprintf(` fill-opacity="%.3g"`, float64(c.A)/0xff)
+	}
+	return fill
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package qrcode
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/url/valueobject"
+)
+
+const testContent = "https://sho.rt/spring-sale"
+
+func testOptions(format string) valueobject.QRCodeOptions {
+	return valueobject.QRCodeOptions{
+		Format:     format,
+		Size:       256,
+		Level:      "M",
+		Margin:     4,
+		Foreground: color.NRGBA{R: 0x11, G: 0x22, B: 0x33, A: 0xff},
+		Background: color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x80},
+	}
+}
+
+func TestRenderPNG(t *testing.T) {
+	options := testOptions(valueobject.QRCodeFormatPNG)
+	image, err := NewRenderer().Render(testContent, options)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(image))
+	if err != nil {
+		t.Fatalf("decode PNG: %v", err)
+	}
+	if bounds := img.Bounds(); bounds.Dx() != options.Size || bounds.Dy() != options.Size {
+		t.Fatalf("image is %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), options.Size, options.Size)
+	}
+
+	// The corner lies in the quiet zone, the finder pattern starts right after the margin
+	if got := color.NRGBAModel.Convert(img.At(0, 0)); got != options.Background {
+		t.Errorf("corner pixel = %v, want background %v", got, options.Background)
+	}
+	found := false
+	for p := 0; p < options.Size/2 && !found; p++ {
+		found = color.NRGBAModel.Convert(img.At(p, p)) == options.Foreground
+	}
+	if !found {
+		t.Error("no foreground pixel on the diagonal of the top left finder pattern")
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	image, err := NewRenderer().Render(testContent, testOptions(valueobject.QRCodeFormatSVG))
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	var svg struct {
+		XMLName xml.Name `xml:"svg"`
+		Width   string   `xml:"width,attr"`
+		Path    struct {
+			D    string `xml:"d,attr"`
+			Fill string `xml:"fill,attr"`
+		} `xml:"path"`
+	}
+	if err := xml.Unmarshal(image, &svg); err != nil {
+		t.Fatalf("parse SVG: %v", err)
+	}
+	if svg.Width != "256" {
+		t.Errorf("width = %q, want 256", svg.Width)
+	}
+	if svg.Path.D == "" || svg.Path.Fill != "#112233" {
+		t.Errorf("path d=%q fill=%q, want modules filled with #112233", svg.Path.D, svg.Path.Fill)
+	}
+}
+
+func TestRenderTooSmall(t *testing.T) {
+	options := testOptions(valueobject.QRCodeFormatPNG)
+	options.Size = 16
+
+	_, err := NewRenderer().Render(testContent, options)
+	if errors.GetErrorType(err) != errors.ErrorTypeValidation {
+		t.Fatalf("Render error = %v, want a validation error", err)
+	}
+}
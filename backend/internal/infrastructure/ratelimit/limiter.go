@@ -0,0 +1,40 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
+)
+
+// Result describes the outcome of a rate limited request
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long a rejected request has to wait before it would be allowed
+	RetryAfter time.Duration
+	// ResetAfter is how long until the full request allowance is available again
+	ResetAfter time.Duration
+}
+
+// Limiter decides whether a request identified by key is allowed under a policy
+type Limiter interface {
+	Allow(ctx context.Context, key string, policy config.RateLimitPolicy) (*Result, error)
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
+)
+
+// minSweepSize is the number of tracked keys below which idle keys are kept
+const minSweepSize = 1024
+
+type memoryLimiter struct {
+	mu sync.Mutex
+	// tats holds the theoretical arrival time of the next request per key, as in gcraScript
+	tats      map[string]time.Time
+	sweepSize int
+}
+
+// NewMemoryLimiter creates a limiter keeping its state in process, for single
+// instance deployments without Redis. It applies the same algorithm as the Redis limiter.
+func NewMemoryLimiter() Limiter {
+	return &memoryLimiter{
+		tats:      make(map[string]time.Time),
+		sweepSize: minSweepSize,
+	}
+}
+
+func (l *memoryLimiter) Allow(ctx context.Context, key string, policy config.RateLimitPolicy) (*Result, error) {
+	interval := policy.Window / time.Duration(policy.Requests)
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	tat, ok := l.tats[key]
+	if !ok || tat.Before(now) {
+		tat = now
+	}
+
+	newTAT := tat.Add(interval)
+	allowAt := newTAT.Add(-policy.Window)
+	if now.Before(allowAt) {
+		return &Result{
+			Allowed:    false,
+			Limit:      policy.Requests,
+			Remaining:  0,
+			RetryAfter: allowAt.Sub(now),
+			ResetAfter: tat.Sub(now),
+		}, nil
+	}
+
+	l.tats[key] = newTAT
+	l.sweep(now)
+
+	return &Result{
+		Allowed:    true,
+		Limit:      policy.Requests,
+		Remaining:  int((policy.Window - newTAT.Sub(now)) / interval),
+		ResetAfter: newTAT.Sub(now),
+	}, nil
+}
+
+// sweep forgets keys whose allowance has fully recovered once the map has doubled
+// in size since the last sweep; the caller must hold the lock
+func (l *memoryLimiter) sweep(now time.Time) {
+	if len(l.tats) < l.sweepSize {
+		return
+	}
+	for key, tat := range l.tats {
+		if !tat.After(now) {
+			delete(l.tats, key)
+		}
+	}
+	l.sweepSize = max(minSweepSize, 2*len(l.tats))
+}
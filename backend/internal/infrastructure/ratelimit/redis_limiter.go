@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/cache/redis"
+)
+
+const keyPrefix = "ratelimit:"
+
+// gcraScript implements the generic cell rate algorithm, a token bucket that stores
+// a single timestamp per key: the theoretical arrival time (TAT) of the next request.
+// Requests are spaced by interval = window / requests and may burst up to the full
+// allowance. Redis time is used so that all replicas share one clock.
+//
+// Returns {allowed, remaining, retry_after_us, reset_after_us}.
+var gcraScript = goredis.NewScript(`
+local interval = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+local tat = tonumber(redis.call('GET', KEYS[1]))
+if not tat or tat < now then
+  tat = now
+end
+
+local new_tat = tat + interval
+local allow_at = new_tat - window
+if now < allow_at then
+  return {0, 0, allow_at - now, tat - now}
+end
+
+redis.call('SET', KEYS[1], new_tat, 'PX', math.ceil((new_tat - now) / 1000))
+local remaining = math.floor((window - (new_tat - now)) / interval)
+return {1, remaining, 0, new_tat - now}
+`)
+
+type redisLimiter struct {
+	client redis.Client
+	logger logger.Logger
+}
+
+// NewRedisLimiter creates a limiter sharing its state across replicas through Redis
+func NewRedisLimiter(client redis.Client, logger logger.Logger) Limiter {
+	return &redisLimiter{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (l *redisLimiter) Allow(ctx context.Context, key string, policy config.RateLimitPolicy) (*Result, error) {
+	window := policy.Window.Microseconds()
+	interval := window / int64(policy.Requests)
+
+	values, err := gcraScript.Run(ctx, l.client.Client(), []string{keyPrefix + key}, interval, window).Int64Slice()
+	if err != nil {
+		l.logger.Error(ctx, "Error evaluating rate limit",
+			logger.String("key", key),
+			logger.String("operation", "Allow"),
+			logger.Error(err),
+		)
+		return nil, err
+	}
+
+	return &Result{
+		Allowed:    values[0] == 1,
+		Limit:      policy.Requests,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Microsecond,
+		ResetAfter: time.Duration(values[3]) * time.Microsecond,
+	}, nil
+}
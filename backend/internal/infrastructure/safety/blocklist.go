@@ -0,0 +1,193 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package safety
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Types of blocklist files
+const (
+	// TypeDomains lists one host per line, matching the host and its subdomains, or a
+	// host and path prefix such as example.com/login, matching URLs below that path
+	TypeDomains = "domains"
+	// TypeRegex lists one regular expression per line, matched against canonical URLs
+	TypeRegex = "regex"
+	// TypeHashPrefix lists one hex encoded SHA-256 prefix of 4 to 32 bytes per line,
+	// matched against the hashes of URL expressions as in Google Safe Browsing
+	TypeHashPrefix = "hash_prefix"
+)
+
+const (
+	minHashPrefixLength = 4
+	maxHashPrefixLength = 32
+)
+
+// matcher reports whether a canonical URL is on a blocklist
+type matcher interface {
+	match(t *target) bool
+}
+
+// parseBlocklist reads a blocklist file of the given type. Blank lines and lines
+// starting with # are skipped.
+func parseBlocklist(listType string, r io.Reader) (matcher, error) {
+	var add func(entry string) error
+	var m matcher
+	switch listType {
+	case TypeDomains:
+		domains := &domainMatcher{hosts: make(map[string]struct{}), paths: make(map[string][]string)}
+		add, m = domains.add, domains
+	case TypeRegex:
+		patterns := &regexMatcher{}
+		add, m = patterns.add, patterns
+	case TypeHashPrefix:
+		prefixes := &hashPrefixMatcher{prefixes: make(map[string]struct{})}
+		add, m = prefixes.add, prefixes
+	default:
+		return nil, fmt.Errorf("unknown blocklist type %q", listType)
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		if err := add(entry); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// domainMatcher matches hosts and their subdomains, optionally below a path
+type domainMatcher struct {
+	hosts map[string]struct{}
+	// paths holds the path prefixes listed for a host
+	paths map[string][]string
+}
+
+func (d *domainMatcher) add(entry string) error {
+	entry = strings.ToLower(entry)
+	if scheme, rest, found := strings.Cut(entry, "://"); found && (scheme == "http" || scheme == "https") {
+		entry = rest
+	}
+	host, path, hasPath := strings.Cut(entry, "/")
+	host = strings.Trim(strings.TrimPrefix(host, "*."), ".")
+	if host == "" || strings.ContainsAny(host, " :") {
+		return fmt.Errorf("invalid domain %q", entry)
+	}
+	if !hasPath || path == "" {
+		d.hosts[host] = struct{}{}
+		return nil
+	}
+	d.paths[host] = append(d.paths[host], canonicalPath("/"+path))
+	return nil
+}
+
+func (d *domainMatcher) match(t *target) bool {
+	host := t.host
+	for {
+		if _, ok := d.hosts[host]; ok {
+			return true
+		}
+		for _, prefix := range d.paths[host] {
+			if strings.HasPrefix(t.path, prefix) {
+				return true
+			}
+		}
+		if t.isIP {
+			return false
+		}
+		_, parent, found := strings.Cut(host, ".")
+		if !found {
+			return false
+		}
+		host = parent
+	}
+}
+
+// regexMatcher matches canonical URLs against regular expressions
+type regexMatcher struct {
+	patterns []*regexp.Regexp
+}
+
+func (r *regexMatcher) add(entry string) error {
+	pattern, err := regexp.Compile(entry)
+	if err != nil {
+		return fmt.Errorf("invalid regular expression: %w", err)
+	}
+	r.patterns = append(r.patterns, pattern)
+	return nil
+}
+
+func (r *regexMatcher) match(t *target) bool {
+	for _, pattern := range r.patterns {
+		if pattern.MatchString(t.url) {
+			return true
+		}
+	}
+	return false
+}
+
+// hashPrefixMatcher matches the SHA-256 hashes of URL expressions against hash prefixes
+type hashPrefixMatcher struct {
+	// prefixes holds the raw prefix bytes
+	prefixes map[string]struct{}
+	// lengths lists the distinct prefix lengths, so lookups only try those
+	lengths []int
+}
+
+func (h *hashPrefixMatcher) add(entry string) error {
+	prefix, err := hex.DecodeString(entry)
+	if err != nil {
+		return fmt.Errorf("invalid hex hash prefix: %w", err)
+	}
+	if len(prefix) < minHashPrefixLength || len(prefix) > maxHashPrefixLength {
+		return fmt.Errorf("hash prefix must be %d to %d bytes long", minHashPrefixLength, maxHashPrefixLength)
+	}
+	h.prefixes[string(prefix)] = struct{}{}
+	for _, length := range h.lengths {
+		if length == len(prefix) {
+			return nil
+		}
+	}
+	h.lengths = append(h.lengths, len(prefix))
+	return nil
+}
+
+func (h *hashPrefixMatcher) match(t *target) bool {
+	if len(h.prefixes) == 0 {
+		return false
+	}
+	for _, hash := range t.expressionHashes() {
+		for _, length := range h.lengths {
+			if _, ok := h.prefixes[string(hash[:length])]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
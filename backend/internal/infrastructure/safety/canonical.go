@@ -0,0 +1,192 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package safety
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+const (
+	// maxHostSuffixes and maxPathPrefixes bound the expressions looked up for a URL, as
+	// in Google Safe Browsing: the exact host and up to 4 of its suffixes, combined with
+	// the exact path, with and without query, and up to 4 of its prefixes
+	maxHostSuffixes = 4
+	maxPathPrefixes = 4
+	// maxHostComponents is how many trailing host components the first suffix keeps
+	maxHostComponents = 5
+	// maxUnescapes stops unescaping paths that keep decoding to new escapes
+	maxUnescapes = 8
+)
+
+// target is a URL in the canonical form blocklists are matched against
+type target struct {
+	// url is the canonical URL, with scheme, host, path and query
+	url   string
+	host  string
+	path  string
+	query string
+	// isIP is set for hosts that are IP addresses, which are only matched exactly
+	isIP bool
+}
+
+// canonicalize brings a URL into the form of Google Safe Browsing URL canonicalization:
+// without fragment and port, with a lower case host without redundant dots, and with a
+// path that is fully unescaped, has no dot segments and no repeated slashes
+func canonicalize(rawURL string) (*target, error) {
+	rawURL = strings.NewReplacer("\t", "", "\r", "", "\n", "").Replace(strings.TrimSpace(rawURL))
+	rawURL, _, _ = strings.Cut(rawURL, "#")
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing URL: %w", err)
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	host = strings.Trim(host, ".")
+	for strings.Contains(host, "..") {
+		host = strings.ReplaceAll(host, "..", ".")
+	}
+	if host == "" {
+		return nil, fmt.Errorf("URL has no host")
+	}
+
+	t := &target{host: host, query: parsed.RawQuery}
+	if ip := net.ParseIP(host); ip != nil {
+		t.host = ip.String()
+		t.isIP = true
+	}
+	t.path = canonicalPath(parsed.EscapedPath())
+
+	t.url = strings.ToLower(parsed.Scheme) + "://" + escape(t.host) + escape(t.path)
+	if parsed.ForceQuery || t.query != "" {
+		t.url += "?" + escape(t.query)
+	}
+	return t, nil
+}
+
+// canonicalPath unescapes a path until it no longer changes and resolves its dot
+// segments and repeated slashes, keeping a trailing slash
+func canonicalPath(escapedPath string) string {
+	path := escapedPath
+	for range maxUnescapes {
+		unescaped, err := url.PathUnescape(path)
+		if err != nil || unescaped == path {
+			break
+		}
+		path = unescaped
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		switch segment {
+		case "", ".":
+		case "..":
+			if len(segments) > 0 {
+				segments = segments[:len(segments)-1]
+			}
+		default:
+			segments = append(segments, segment)
+		}
+	}
+
+	canonical := "/" + strings.Join(segments, "/")
+	if len(segments) > 0 && (strings.HasSuffix(path, "/") || strings.HasSuffix(path, "/.") ||
+		strings.HasSuffix(path, "/..")) {
+		canonical += "/"
+	}
+	return canonical
+}
+
+// escape percent-escapes the characters Safe Browsing escapes in canonical URLs: control
+// characters, space, non-ASCII bytes, '#' and '%'
+func escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c >= 0x7f || c == '#' || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// hostSuffixes returns the exact host and the suffixes formed by starting with its last
+// five components and removing leading components, down to the registered domain
+func (t *target) hostSuffixes() []string {
+	suffixes := []string{t.host}
+	if t.isIP {
+		return suffixes
+	}
+	components := strings.Split(t.host, ".")
+	start := max(len(components)-maxHostComponents, 1)
+	for i := start; i <= len(components)-2 && len(suffixes) <= maxHostSuffixes; i++ {
+		suffixes = append(suffixes, strings.Join(components[i:], "."))
+	}
+	return suffixes
+}
+
+// pathPrefixes returns the exact path with and without its query, and the prefixes
+// formed by starting at the root and appending directories with a trailing slash
+func (t *target) pathPrefixes() []string {
+	var prefixes []string
+	if t.query != "" {
+		prefixes = append(prefixes, t.path+"?"+t.query)
+	}
+	prefixes = append(prefixes, t.path)
+
+	prefix := "/"
+	directory := t.path[:strings.LastIndex(t.path, "/")+1]
+	components := strings.Split(strings.Trim(directory, "/"), "/")
+	for i := 0; i < maxPathPrefixes; i++ {
+		if prefix != t.path {
+			prefixes = append(prefixes, prefix)
+		}
+		if i >= len(components) || components[i] == "" {
+			break
+		}
+		prefix += components[i] + "/"
+	}
+	return prefixes
+}
+
+// expressions returns the host suffix and path prefix combinations of the URL that are
+// hashed for hash prefix lookups, escaped as in canonical URLs
+func (t *target) expressions() []string {
+	var expressions []string
+	for _, host := range t.hostSuffixes() {
+		for _, path := range t.pathPrefixes() {
+			expressions = append(expressions, escape(host)+escape(path))
+		}
+	}
+	return expressions
+}
+
+// expressionHashes returns the SHA-256 digests of the expressions of the URL
+func (t *target) expressionHashes() [][sha256.Size]byte {
+	expressions := t.expressions()
+	hashes := make([][sha256.Size]byte, len(expressions))
+	for i, expression := range expressions {
+		hashes[i] = sha256.Sum256([]byte(expression))
+	}
+	return hashes
+}
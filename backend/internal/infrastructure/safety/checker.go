@@ -0,0 +1,181 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package safety
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/interfaces"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+)
+
+// blocklistFile is a loaded blocklist and the file version it was loaded from
+type blocklistFile struct {
+	config  config.Blocklist
+	matcher atomic.Pointer[matcher]
+	// modTime and size identify the version last read; only the reload loop uses them
+	modTime time.Time
+	size    int64
+	// missing is set while the file cannot be found, so that is logged once
+	missing bool
+}
+
+// BlocklistChecker flags URLs found on local blocklist files. Files are checked for
+// changes periodically and reloaded; a file that fails to reload keeps its previous
+// contents.
+type BlocklistChecker struct {
+	logger         logger.Logger
+	lists          []*blocklistFile
+	reloadInterval time.Duration
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+var _ interfaces.URLSafetyChecker = (*BlocklistChecker)(nil)
+
+// NewBlocklistChecker loads the configured blocklists and starts watching them for
+// changes. It fails if any of them cannot be loaded.
+func NewBlocklistChecker(logger logger.Logger, safetyConfig config.URLSafetyConfig) (*BlocklistChecker, error) {
+	c := &BlocklistChecker{
+		logger:         logger,
+		reloadInterval: safetyConfig.BlocklistReloadInterval(),
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+	for _, list := range safetyConfig.Blocklists() {
+		file := &blocklistFile{config: list}
+		if err := file.load(); err != nil {
+			return nil, fmt.Errorf("loading blocklist %s: %w", list.Name, err)
+		}
+		c.lists = append(c.lists, file)
+	}
+
+	if len(c.lists) == 0 {
+		close(c.done)
+	} else {
+		go c.run()
+	}
+	return c, nil
+}
+
+// CheckURL returns the name of the first blocklist the URL is found on
+func (c *BlocklistChecker) CheckURL(ctx context.Context, longURL string) (string, error) {
+	if len(c.lists) == 0 {
+		return "", nil
+	}
+	t, err := canonicalize(longURL)
+	if err != nil {
+		return "", err
+	}
+	for _, list := range c.lists {
+		if (*list.matcher.Load()).match(t) {
+			return list.config.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// Shutdown stops watching the blocklist files
+func (c *BlocklistChecker) Shutdown(ctx context.Context) error {
+	c.stopOnce.Do(func() { close(c.stop) })
+
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *BlocklistChecker) run() {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.reload()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// reload loads the blocklist files that changed since they were last loaded
+func (c *BlocklistChecker) reload() {
+	ctx := context.Background()
+	for _, list := range c.lists {
+		info, err := os.Stat(list.config.Path)
+		if err != nil {
+			if !list.missing {
+				c.logger.Error(ctx, "Blocklist file unavailable, keeping loaded version",
+					logger.String("blocklist", list.config.Name),
+					logger.Error(err))
+			}
+			list.missing = true
+			continue
+		}
+		list.missing = false
+		if info.ModTime().Equal(list.modTime) && info.Size() == list.size {
+			continue
+		}
+
+		if err := list.load(); err != nil {
+			c.logger.Error(ctx, "Blocklist reload failed, keeping loaded version",
+				logger.String("blocklist", list.config.Name),
+				logger.Error(err))
+			continue
+		}
+		c.logger.Info(ctx, "Blocklist reloaded",
+			logger.String("blocklist", list.config.Name))
+	}
+}
+
+// load parses the blocklist file and replaces the loaded matcher with it. The file
+// version is recorded even when parsing fails, so a broken file is only retried once
+// it changes again.
+func (f *blocklistFile) load() error {
+	file, err := os.Open(f.config.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+
+	m, err := parseBlocklist(f.config.Type, file)
+	if err != nil {
+		return err
+	}
+	f.matcher.Store(&m)
+	return nil
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package safety
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	zl "github.com/rs/zerolog"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/logging/zerolog"
+)
+
+var testLogger = zerolog.NewWithLogger(zl.Nop())
+
+type testConfig struct {
+	blocklists []config.Blocklist
+}
+
+func (c testConfig) Blocklists() []config.Blocklist         { return c.blocklists }
+func (c testConfig) BlocklistReloadInterval() time.Duration { return 10 * time.Millisecond }
+func (c testConfig) SafetyRescanInterval() time.Duration    { return 0 }
+
+func writeList(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestExpressions(t *testing.T) {
+	tests := []struct {
+		url  string
+		want []string
+	}{
+		{
+			url: "http://a.b.c/1/2.html?param=1",
+			want: []string{
+				"a.b.c/1/2.html?param=1", "a.b.c/1/2.html", "a.b.c/", "a.b.c/1/",
+				"b.c/1/2.html?param=1", "b.c/1/2.html", "b.c/", "b.c/1/",
+			},
+		},
+		{
+			url: "http://a.b.c.d.e.f.g/1.html",
+			want: []string{
+				"a.b.c.d.e.f.g/1.html", "a.b.c.d.e.f.g/", "c.d.e.f.g/1.html", "c.d.e.f.g/",
+				"d.e.f.g/1.html", "d.e.f.g/", "e.f.g/1.html", "e.f.g/", "f.g/1.html", "f.g/",
+			},
+		},
+		{
+			url:  "http://1.2.3.4/1/",
+			want: []string{"1.2.3.4/1/", "1.2.3.4/"},
+		},
+		{
+			url:  "HTTP://WWW.Example.COM.:8080/a/./b/../c//d%2541?x=1#frag",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		target, err := canonicalize(tt.url)
+		if err != nil {
+			t.Fatalf("canonicalize(%q) error = %v", tt.url, err)
+		}
+		if tt.want == nil {
+			continue
+		}
+		if got := target.expressions(); !slices.Equal(got, tt.want) {
+			t.Errorf("expressions(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	target, err := canonicalize("HTTP://WWW.Example.COM.:8080/a/./b/../c//d%2541?x=1#frag")
+	if err != nil {
+		t.Fatalf("canonicalize() error = %v", err)
+	}
+	if want := "http://www.example.com/a/c/dA?x=1"; target.url != want {
+		t.Errorf("canonical URL = %q, want %q", target.url, want)
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	dir := t.TempDir()
+	hash := sha256.Sum256([]byte("malware.test/payload/"))
+	checker, err := NewBlocklistChecker(testLogger, testConfig{blocklists: []config.Blocklist{
+		{Name: "phishing", Type: TypeDomains, Path: writeList(t, dir, "domains.txt",
+			"# phishing hosts\n\nevil.test\nshared.test/login\n")},
+		{Name: "scam", Type: TypeRegex, Path: writeList(t, dir, "regex.txt", `^https?://[^/]*\.scam\.test/`+"\n")},
+		{Name: "malware", Type: TypeHashPrefix, Path: writeList(t, dir, "hashes.txt",
+			hex.EncodeToString(hash[:4])+"\n")},
+	}})
+	if err != nil {
+		t.Fatalf("NewBlocklistChecker() error = %v", err)
+	}
+	t.Cleanup(func() { checker.Shutdown(context.Background()) })
+
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://evil.test/", "phishing"},
+		{"https://login.EVIL.test/account", "phishing"},
+		{"https://notevil.test/", ""},
+		{"https://shared.test/login/reset", "phishing"},
+		{"https://shared.test/home", ""},
+		{"http://www.scam.test/offer", "scam"},
+		{"http://scam.test.example/offer", ""},
+		{"https://cdn.malware.test/payload/run.exe?x=1", "malware"},
+		{"https://malware.test/other/", ""},
+		{"https://example.com/", ""},
+	}
+	for _, tt := range tests {
+		got, err := checker.CheckURL(context.Background(), tt.url)
+		if err != nil || got != tt.want {
+			t.Errorf("CheckURL(%q) = %q, %v, want %q", tt.url, got, err, tt.want)
+		}
+	}
+}
+
+func TestInvalidBlocklist(t *testing.T) {
+	dir := t.TempDir()
+	for _, list := range []config.Blocklist{
+		{Name: "regex", Type: TypeRegex, Path: writeList(t, dir, "regex.txt", "(unclosed\n")},
+		{Name: "hashes", Type: TypeHashPrefix, Path: writeList(t, dir, "hashes.txt", "abcd\n")},
+		{Name: "missing", Type: TypeDomains, Path: filepath.Join(dir, "missing.txt")},
+	} {
+		if _, err := NewBlocklistChecker(testLogger, testConfig{blocklists: []config.Blocklist{list}}); err == nil {
+			t.Errorf("NewBlocklistChecker(%s) error = nil, want an error", list.Name)
+		}
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	path := writeList(t, dir, "domains.txt", "evil.test\n")
+	checker, err := NewBlocklistChecker(testLogger, testConfig{blocklists: []config.Blocklist{
+		{Name: "phishing", Type: TypeDomains, Path: path},
+	}})
+	if err != nil {
+		t.Fatalf("NewBlocklistChecker() error = %v", err)
+	}
+	t.Cleanup(func() { checker.Shutdown(context.Background()) })
+
+	waitFor := func(url, want string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			got, _ := checker.CheckURL(context.Background(), url)
+			if got == want {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("CheckURL(%q) = %q, want %q after reload", url, got, want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	writeList(t, dir, "domains.txt", "evil.test\nworse.test\n")
+	waitFor("https://worse.test/", "phishing")
+
+	// A broken file keeps the list loaded before it
+	writeList(t, dir, "domains.txt", "bad host\n")
+	time.Sleep(50 * time.Millisecond)
+	waitFor("https://worse.test/", "phishing")
+
+	writeList(t, dir, "domains.txt", "other.test\n")
+	waitFor("https://evil.test/", "")
+}
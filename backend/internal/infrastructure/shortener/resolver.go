@@ -0,0 +1,187 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package shortener
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/errors"
+	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
+)
+
+// Resolver follows links on other URL shorteners to the destination they redirect to.
+// Only URLs on known shortener hosts are requested, so destinations themselves are
+// never visited.
+type Resolver struct {
+	client  *http.Client
+	logger  logger.Logger
+	hosts   map[string]struct{}
+	enabled bool
+	maxHops int
+	timeout time.Duration
+}
+
+// NewResolver creates a resolver sending its requests with client. Redirects are
+// followed one at a time by the resolver itself, whatever the redirect policy of the
+// client is. Unless the shortener policy is resolve, URLs are returned unchanged.
+func NewResolver(client *http.Client, logger logger.Logger, destinationConfig config.DestinationConfig) *Resolver {
+	noFollow := *client
+	noFollow.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	hosts := make(map[string]struct{}, len(destinationConfig.ShortenerHosts()))
+	for _, host := range destinationConfig.ShortenerHosts() {
+		if host = normalizeHost(strings.TrimSpace(host)); host != "" {
+			hosts[host] = struct{}{}
+		}
+	}
+
+	return &Resolver{
+		client:  &noFollow,
+		logger:  logger,
+		hosts:   hosts,
+		enabled: destinationConfig.ShortenerPolicy() == config.ShortenerPolicyResolve,
+		maxHops: destinationConfig.MaxShortenerHops(),
+		timeout: destinationConfig.ShortenerTimeout(),
+	}
+}
+
+// Resolve returns the URL a chain of shortener redirects starting at longURL ends at.
+// URLs that are not on a shortener host are returned as they are. Chains longer than
+// the hop limit and chains returning to a URL they passed are rejected.
+func (r *Resolver) Resolve(ctx context.Context, longURL string) (string, error) {
+	if !r.enabled {
+		return longURL, nil
+	}
+
+	current, err := url.Parse(strings.TrimSpace(longURL))
+	if err != nil || !r.isShortener(current) {
+		return longURL, nil
+	}
+
+	visited := map[string]struct{}{current.String(): {}}
+	for hop := 0; hop < r.maxHops; hop++ {
+		next, err := r.follow(ctx, current)
+		if err != nil {
+			r.logger.Warn(ctx, "Shortened URL could not be resolved",
+				logger.String("longURL", longURL),
+				logger.String("url", current.String()),
+				logger.Error(err))
+			return "", errors.ValidationError("shortened URL could not be resolved")
+		}
+		// The shortener answered without redirecting, so the chain ends here
+		if next == nil {
+			return current.String(), nil
+		}
+
+		if _, seen := visited[next.String()]; seen {
+			return "", errors.ValidationError("shortened URL redirects in a loop")
+		}
+		visited[next.String()] = struct{}{}
+		if !r.isShortener(next) {
+			r.logger.Info(ctx, "Shortened URL resolved",
+				logger.String("longURL", longURL),
+				logger.String("resolvedURL", next.String()),
+				logger.Int("hops", hop+1))
+			return next.String(), nil
+		}
+		current = next
+	}
+
+	return "", errors.ValidationError(fmt.Sprintf("shortened URL redirects more than %d times", r.maxHops))
+}
+
+// follow requests target and returns where it redirects to, or nil if it does not redirect
+func (r *Resolver) follow(ctx context.Context, target *url.URL) (*url.URL, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	resp, err := r.do(ctx, http.MethodHead, target)
+	if err != nil {
+		return nil, err
+	}
+	// Some shorteners only answer GET requests
+	if resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented {
+		if resp, err = r.do(ctx, http.MethodGet, target); err != nil {
+			return nil, err
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+	default:
+		return nil, nil
+	}
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return nil, fmt.Errorf("%d redirect without a location", resp.StatusCode)
+	}
+	next, err := target.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redirect location %q: %w", location, err)
+	}
+	return next, nil
+}
+
+// do sends a request, discarding the response body
+func (r *Resolver) do(ctx context.Context, method string, target *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	resp.Body.Close()
+	return resp, nil
+}
+
+// isShortener reports whether u is an http(s) URL on a shortener host or one of its subdomains
+func (r *Resolver) isShortener(u *url.URL) bool {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	host := normalizeHost(u.Hostname())
+	for host != "" {
+		if _, ok := r.hosts[host]; ok {
+			return true
+		}
+		_, parent, found := strings.Cut(host, ".")
+		if !found {
+			return false
+		}
+		host = parent
+	}
+	return false
+}
+
+// normalizeHost lowercases a hostname and drops a trailing dot
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
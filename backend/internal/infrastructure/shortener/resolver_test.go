@@ -0,0 +1,135 @@
+/*
+ * Copyright 2025 Praveen Kumar
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package shortener
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	zl "github.com/rs/zerolog"
+
+	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/logging/zerolog"
+)
+
+type testConfig struct {
+	hosts  []string
+	policy string
+}
+
+func (c testConfig) OwnHosts() []string              { return nil }
+func (c testConfig) ShortenerHosts() []string        { return c.hosts }
+func (c testConfig) ShortenerPolicy() string         { return c.policy }
+func (c testConfig) MaxShortenerHops() int           { return 3 }
+func (c testConfig) ShortenerTimeout() time.Duration { return time.Second }
+
+// newShortener serves redirects from the given paths; every request is counted
+func newShortener(t *testing.T, redirects map[string]string, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/get-only" && r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		location, ok := redirects[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.Redirect(w, r, location, http.StatusMovedPermanently)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestResolve(t *testing.T) {
+	var requests atomic.Int32
+	server := newShortener(t, map[string]string{
+		"/a":        "/b",
+		"/b":        "https://example.com/landing?utm_source=a",
+		"/get-only": "https://example.com/get",
+		"/loop1":    "/loop2",
+		"/loop2":    "/loop1",
+		"/long1":    "/long2",
+		"/long2":    "/long3",
+		"/long3":    "/long4",
+		"/long4":    "https://example.com/",
+	}, &requests)
+	resolver := NewResolver(server.Client(), zerolog.NewWithLogger(zl.Nop()), testConfig{
+		hosts:  []string{"127.0.0.1"},
+		policy: config.ShortenerPolicyResolve,
+	})
+
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr string
+	}{
+		{name: "chain", url: server.URL + "/a", want: "https://example.com/landing?utm_source=a"},
+		{name: "head not allowed", url: server.URL + "/get-only", want: "https://example.com/get"},
+		{name: "no redirect", url: server.URL + "/missing", want: server.URL + "/missing"},
+		{name: "not a shortener", url: "https://example.com/a", want: "https://example.com/a"},
+		{name: "loop", url: server.URL + "/loop1", wantErr: "loop"},
+		{name: "too many hops", url: server.URL + "/long1", wantErr: "more than 3 times"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolver.Resolve(context.Background(), tt.url)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Resolve(%q) error = %v, want it to mention %q", tt.url, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("Resolve(%q) = %q, %v, want %q", tt.url, got, err, tt.want)
+			}
+		})
+	}
+
+	unreachable := server.URL + "/a"
+	server.Close()
+	if _, err := resolver.Resolve(context.Background(), unreachable); err == nil {
+		t.Errorf("Resolve(%q) on a closed server error = nil, want an error", unreachable)
+	}
+}
+
+func TestResolveDisabled(t *testing.T) {
+	var requests atomic.Int32
+	server := newShortener(t, map[string]string{"/a": "https://example.com/"}, &requests)
+
+	for _, policy := range []string{config.ShortenerPolicyAllow, config.ShortenerPolicyReject} {
+		resolver := NewResolver(server.Client(), zerolog.NewWithLogger(zl.Nop()), testConfig{
+			hosts:  []string{"127.0.0.1"},
+			policy: policy,
+		})
+		got, err := resolver.Resolve(context.Background(), server.URL+"/a")
+		if err != nil || got != server.URL+"/a" {
+			t.Errorf("Resolve() with policy %s = %q, %v, want the URL unchanged", policy, got, err)
+		}
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("shortener received %d requests, want none", n)
+	}
+}
@@ -19,6 +19,9 @@
 package wire
 
 import (
+	"net"
+	"net/http"
+
 	"github.com/PraveenGongada/shortly/internal/application/service"
 	"github.com/PraveenGongada/shortly/internal/domain/interfaces"
 	"github.com/PraveenGongada/shortly/internal/domain/shared/config"
@@ -26,8 +29,15 @@ import (
 	urlCache "github.com/PraveenGongada/shortly/internal/domain/url/cache"
 	urlRepository "github.com/PraveenGongada/shortly/internal/domain/url/repository"
 	urlDomainService "github.com/PraveenGongada/shortly/internal/domain/url/service"
+	userCache "github.com/PraveenGongada/shortly/internal/domain/user/cache"
+	userRepository "github.com/PraveenGongada/shortly/internal/domain/user/repository"
+	workspaceRepository "github.com/PraveenGongada/shortly/internal/domain/workspace/repository"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/cache/redis"
 	infraConfig "github.com/PraveenGongada/shortly/internal/infrastructure/config"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/dns"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/persistence/postgres"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/shortener"
+	"github.com/PraveenGongada/shortly/migrations"
 )
 
 // Config providers that create domain config interfaces
@@ -48,6 +58,26 @@ func ProvideURLConfig() config.URLConfig {
 	return infraConfig.NewURLConfigAdapter(cfg)
 }
 
+func ProvideAnalyticsConfig() config.AnalyticsConfig {
+	cfg := infraConfig.GetGlobalConfig()
+	return infraConfig.NewAnalyticsConfigAdapter(cfg)
+}
+
+func ProvideTrashConfig() config.TrashConfig {
+	cfg := infraConfig.GetGlobalConfig()
+	return infraConfig.NewTrashConfigAdapter(cfg)
+}
+
+func ProvideURLSafetyConfig() config.URLSafetyConfig {
+	cfg := infraConfig.GetGlobalConfig()
+	return infraConfig.NewURLSafetyConfigAdapter(cfg)
+}
+
+func ProvideDestinationConfig() config.DestinationConfig {
+	cfg := infraConfig.GetGlobalConfig()
+	return infraConfig.NewDestinationConfigAdapter(cfg)
+}
+
 func ProvideServerConfig() config.ServerConfig {
 	cfg := infraConfig.GetGlobalConfig()
 	return infraConfig.NewServerConfigAdapter(cfg)
@@ -73,17 +103,90 @@ func NewRedisClient(log logger.Logger, redisConfig config.RedisConfig) redis.Cli
 	return redis.NewClient(log, redisConfig)
 }
 
+func NewMigrator(store postgres.Store, log logger.Logger) (*postgres.Migrator, error) {
+	return postgres.NewMigrator(store, migrations.FS, log)
+}
+
 func NewGenerator(urlConfig config.URLConfig) interfaces.ShortCodeGenerator {
 	return urlDomainService.NewGenerator(urlConfig.ShortURLLength())
 }
 
+// NewURLValidator creates the URL validator, rejecting links to other URL shorteners only
+// when that is the configured policy for them
+func NewURLValidator(destinationConfig config.DestinationConfig) interfaces.URLValidator {
+	var shortenerHosts []string
+	if destinationConfig.ShortenerPolicy() == config.ShortenerPolicyReject {
+		shortenerHosts = destinationConfig.ShortenerHosts()
+	}
+	return urlDomainService.NewValidator(destinationConfig.OwnHosts(), shortenerHosts)
+}
+
+func NewShortenerResolver(logger logger.Logger, destinationConfig config.DestinationConfig) *shortener.Resolver {
+	return shortener.NewResolver(&http.Client{}, logger, destinationConfig)
+}
+
+func NewDomainVerifier() *dns.TXTVerifier {
+	return dns.NewTXTVerifier(net.DefaultResolver)
+}
+
 func NewURLService(
 	generator interfaces.ShortCodeGenerator,
 	validator interfaces.URLValidator,
+	safetyChecker interfaces.URLSafetyChecker,
+	resolver service.DestinationResolver,
 	repository urlRepository.URLRepository,
+	clickRepository urlRepository.ClickRepository,
+	domainRepository urlRepository.DomainRepository,
+	workspaceRepository workspaceRepository.WorkspaceRepository,
+	clickRecorder service.ClickRecorder,
 	cache urlCache.URLCache,
+	counter urlCache.RedirectCounter,
+	hasher interfaces.PasswordHasher,
+	unlockTokens service.LinkUnlockTokenGenerator,
 	logger logger.Logger,
 	urlConfig config.URLConfig,
+	destinationConfig config.DestinationConfig,
 ) service.URLService {
-	return service.NewURLService(generator, validator, repository, cache, logger, urlConfig.MaxCollisionRetries())
+	return service.NewURLService(
+		generator,
+		validator,
+		safetyChecker,
+		resolver,
+		repository,
+		clickRepository,
+		domainRepository,
+		workspaceRepository,
+		clickRecorder,
+		cache,
+		counter,
+		hasher,
+		unlockTokens,
+		logger,
+		destinationConfig.OwnHosts(),
+		urlConfig.MaxCollisionRetries(),
+		urlConfig.MaxBulkURLs(),
+		urlConfig.MaxPageSize(),
+	)
+}
+
+func NewQRCodeService(
+	repository urlRepository.URLRepository,
+	workspaceRepository workspaceRepository.WorkspaceRepository,
+	renderer service.QRCodeRenderer,
+	cache urlCache.QRCodeCache,
+	logger logger.Logger,
+	urlConfig config.URLConfig,
+) service.QRCodeService {
+	return service.NewQRCodeService(repository, workspaceRepository, renderer, cache, logger, urlConfig.PublicURL())
+}
+
+func NewAdminService(
+	users userRepository.UserRepository,
+	urls urlRepository.URLRepository,
+	refreshTokens userRepository.RefreshTokenRepository,
+	revocations userCache.TokenRevocationCache,
+	logger logger.Logger,
+	urlConfig config.URLConfig,
+) service.AdminService {
+	return service.NewAdminService(users, urls, refreshTokens, revocations, logger, urlConfig.MaxPageSize())
 }
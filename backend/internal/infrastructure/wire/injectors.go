@@ -20,20 +20,47 @@
 package wire
 
 import (
+	"github.com/PraveenGongada/shortly/internal/application/worker"
 	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/cache/redis"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/http/handler"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/persistence/postgres"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/safety"
 	"github.com/google/wire"
 )
 
+// Application holds the components main starts and stops. The Postgres and Redis
+// clients and the migrator are nil when running with in-memory storage.
 type Application struct {
-	Handler        *handler.Handler
+	Handler         *handler.Handler
+	PostgresClient  postgres.Store
+	RedisClient     redis.Client
+	ClickRecorder   *worker.ClickRecorder
+	RedirectFlusher *worker.RedirectFlusher
+	TrashPurger     *worker.TrashPurger
+	URLRescanner    *worker.URLRescanner
+	SafetyChecker   *safety.BlocklistChecker
+	Migrator        *postgres.Migrator
+}
+
+func InitializeMemoryApplication(domainLogger logger.Logger) (*Application, error) {
+	wire.Build(MemoryApplicationSet, wire.Struct(new(Application), "Handler", "ClickRecorder", "RedirectFlusher", "TrashPurger",
+		"URLRescanner", "SafetyChecker"))
+	return &Application{}, nil
+}
+
+// Migration holds what the migrate command needs, without Redis or the HTTP layer
+type Migration struct {
 	PostgresClient postgres.Store
-	RedisClient    redis.Client
+	Migrator       *postgres.Migrator
 }
 
 func InitializeApplication(domainLogger logger.Logger) (*Application, error) {
 	wire.Build(FullApplicationSet, wire.Struct(new(Application), "*"))
 	return &Application{}, nil
 }
+
+func InitializeMigration(domainLogger logger.Logger) (*Migration, error) {
+	wire.Build(MigrationSet, wire.Struct(new(Migration), "*"))
+	return &Migration{}, nil
+}
@@ -23,28 +23,48 @@ import (
 	"github.com/google/wire"
 
 	"github.com/PraveenGongada/shortly/internal/application/service"
-	urlDomainService "github.com/PraveenGongada/shortly/internal/domain/url/service"
+	"github.com/PraveenGongada/shortly/internal/application/worker"
+	"github.com/PraveenGongada/shortly/internal/domain/interfaces"
 	userDomainService "github.com/PraveenGongada/shortly/internal/domain/user/service"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/auth"
+	memoryCache "github.com/PraveenGongada/shortly/internal/infrastructure/cache/memory"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/cache/redis"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/dns"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/http/cookie"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/http/handler"
+	httpmiddleware "github.com/PraveenGongada/shortly/internal/infrastructure/http/middleware"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/http/router"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/persistence/memory"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/persistence/postgres"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/qrcode"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/ratelimit"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/safety"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/shortener"
 )
 
 var DomainLayerSet = wire.NewSet(
 	NewGenerator,
-	urlDomainService.NewValidator,
+	NewURLValidator,
 	userDomainService.NewValidator,
 	userDomainService.NewHasher,
 
 	ProvideURLConfig,
+	ProvideDestinationConfig,
 )
 
 var ApplicationLayerSet = wire.NewSet(
 	service.NewUserService,
+	service.NewAPIKeyService,
+	service.NewDomainService,
+	service.NewWorkspaceService,
 	NewURLService,
+	NewQRCodeService,
+	NewAdminService,
+	worker.NewClickRecorder,
+	worker.NewRedirectFlusher,
+	worker.NewTrashPurger,
+	worker.NewURLRescanner,
+	wire.Bind(new(service.ClickRecorder), new(*worker.ClickRecorder)),
 )
 
 var InterfaceLayerSet = wire.NewSet(
@@ -53,26 +73,88 @@ var InterfaceLayerSet = wire.NewSet(
 )
 
 var InfrastructureLayerSet = wire.NewSet(
-	ProvideDatabaseConfig,
 	ProvideAuthConfig,
 	ProvideServerConfig,
 	ProvideLogConfig,
-	ProvideRedisConfig,
 	ProvideSecurityConfig,
+	ProvideAnalyticsConfig,
+	ProvideTrashConfig,
+	ProvideURLSafetyConfig,
+	auth.NewJwtTokenGenerator,
+	wire.Bind(new(service.TokenGenerator), new(auth.TokenGenerator)),
+	wire.Bind(new(httpmiddleware.TokenParser), new(auth.TokenGenerator)),
+	auth.NewAPIKeyGenerator,
+	wire.Bind(new(service.APIKeyGenerator), new(auth.APIKeyGenerator)),
+	auth.NewLinkUnlockTokenGenerator,
+	wire.Bind(new(service.LinkUnlockTokenGenerator), new(auth.LinkUnlockTokenGenerator)),
+	qrcode.NewRenderer,
+	wire.Bind(new(service.QRCodeRenderer), new(qrcode.Renderer)),
+	NewShortenerResolver,
+	wire.Bind(new(service.DestinationResolver), new(*shortener.Resolver)),
+	NewDomainVerifier,
+	wire.Bind(new(service.DomainVerifier), new(*dns.TXTVerifier)),
+	safety.NewBlocklistChecker,
+	wire.Bind(new(interfaces.URLSafetyChecker), new(*safety.BlocklistChecker)),
+
+	cookie.NewCookieManager,
+)
+
+// PersistenceSet stores data in PostgreSQL and shares caches through Redis
+var PersistenceSet = wire.NewSet(
+	ProvideDatabaseConfig,
+	ProvideRedisConfig,
 	postgres.NewPostgresClient,
+	NewMigrator,
 	postgres.NewUserRepository,
+	postgres.NewRefreshTokenRepository,
+	postgres.NewAPIKeyRepository,
 	postgres.NewURLRepository,
+	postgres.NewClickRepository,
+	postgres.NewDomainRepository,
+	postgres.NewWorkspaceRepository,
 	NewRedisClient,
 	redis.NewURLCache,
-	auth.NewJwtTokenGenerator,
-	wire.Bind(new(service.TokenGenerator), new(auth.TokenGenerator)),
+	redis.NewQRCodeCache,
+	redis.NewRedirectCounter,
+	redis.NewTokenRevocationCache,
+	ratelimit.NewRedisLimiter,
+)
 
-	cookie.NewCookieManager,
+// MemoryPersistenceSet keeps all data in process, for running without external services
+var MemoryPersistenceSet = wire.NewSet(
+	memory.NewStore,
+	memory.NewUserRepository,
+	memory.NewRefreshTokenRepository,
+	memory.NewAPIKeyRepository,
+	memory.NewURLRepository,
+	memory.NewClickRepository,
+	memory.NewDomainRepository,
+	memory.NewWorkspaceRepository,
+	memoryCache.NewURLCache,
+	memoryCache.NewQRCodeCache,
+	memoryCache.NewRedirectCounter,
+	memoryCache.NewTokenRevocationCache,
+	ratelimit.NewMemoryLimiter,
+)
+
+var MigrationSet = wire.NewSet(
+	ProvideDatabaseConfig,
+	postgres.NewPostgresClient,
+	NewMigrator,
 )
 
 var FullApplicationSet = wire.NewSet(
 	DomainLayerSet,
 	InfrastructureLayerSet,
+	PersistenceSet,
+	ApplicationLayerSet,
+	InterfaceLayerSet,
+)
+
+var MemoryApplicationSet = wire.NewSet(
+	DomainLayerSet,
+	InfrastructureLayerSet,
+	MemoryPersistenceSet,
 	ApplicationLayerSet,
 	InterfaceLayerSet,
 )
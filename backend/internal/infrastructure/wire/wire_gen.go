@@ -8,49 +8,183 @@ package wire
 
 import (
 	service2 "github.com/PraveenGongada/shortly/internal/application/service"
+	"github.com/PraveenGongada/shortly/internal/application/worker"
 	"github.com/PraveenGongada/shortly/internal/domain/shared/logger"
-	service3 "github.com/PraveenGongada/shortly/internal/domain/url/service"
 	"github.com/PraveenGongada/shortly/internal/domain/user/service"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/auth"
+	memory2 "github.com/PraveenGongada/shortly/internal/infrastructure/cache/memory"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/cache/redis"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/http/cookie"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/http/handler"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/persistence/memory"
 	"github.com/PraveenGongada/shortly/internal/infrastructure/persistence/postgres"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/qrcode"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/ratelimit"
+	"github.com/PraveenGongada/shortly/internal/infrastructure/safety"
 )
 
 // Injectors from injectors.go:
 
+func InitializeMemoryApplication(domainLogger logger.Logger) (*Application, error) {
+	userValidator := service.NewValidator()
+	passwordHasher := service.NewHasher()
+	store := memory.NewStore()
+	userRepository := memory.NewUserRepository(store)
+	refreshTokenRepository := memory.NewRefreshTokenRepository(store)
+	tokenRevocationCache := memory2.NewTokenRevocationCache()
+	workspaceRepository := memory.NewWorkspaceRepository(store)
+	authConfig := ProvideAuthConfig()
+	tokenGenerator := auth.NewJwtTokenGenerator(domainLogger, authConfig)
+	userService := service2.NewUserService(userValidator, passwordHasher, userRepository, refreshTokenRepository, tokenRevocationCache, workspaceRepository, tokenGenerator, domainLogger)
+	urlConfig := ProvideURLConfig()
+	shortCodeGenerator := NewGenerator(urlConfig)
+	destinationConfig := ProvideDestinationConfig()
+	urlValidator := NewURLValidator(destinationConfig)
+	urlSafetyConfig := ProvideURLSafetyConfig()
+	blocklistChecker, err := safety.NewBlocklistChecker(domainLogger, urlSafetyConfig)
+	if err != nil {
+		return nil, err
+	}
+	resolver := NewShortenerResolver(domainLogger, destinationConfig)
+	urlRepository := memory.NewURLRepository(store)
+	clickRepository := memory.NewClickRepository(store)
+	domainRepository := memory.NewDomainRepository(store)
+	analyticsConfig := ProvideAnalyticsConfig()
+	clickRecorder := worker.NewClickRecorder(clickRepository, domainLogger, analyticsConfig)
+	urlCache := memory2.NewURLCache()
+	redirectCounter := memory2.NewRedirectCounter()
+	linkUnlockTokenGenerator := auth.NewLinkUnlockTokenGenerator(domainLogger, authConfig)
+	urlService := NewURLService(shortCodeGenerator, urlValidator, blocklistChecker, resolver, urlRepository, clickRepository, domainRepository, workspaceRepository, clickRecorder, urlCache, redirectCounter, passwordHasher, linkUnlockTokenGenerator, domainLogger, urlConfig, destinationConfig)
+	renderer := qrcode.NewRenderer()
+	qrCodeCache := memory2.NewQRCodeCache()
+	qrCodeService := NewQRCodeService(urlRepository, workspaceRepository, renderer, qrCodeCache, domainLogger, urlConfig)
+	apiKeyGenerator := auth.NewAPIKeyGenerator()
+	apiKeyRepository := memory.NewAPIKeyRepository(store)
+	apiKeyService := service2.NewAPIKeyService(apiKeyGenerator, apiKeyRepository, userRepository, domainLogger)
+	txtVerifier := NewDomainVerifier()
+	domainService := service2.NewDomainService(domainRepository, urlRepository, txtVerifier, urlValidator, domainLogger)
+	workspaceService := service2.NewWorkspaceService(workspaceRepository, userRepository, userValidator, domainLogger)
+	adminService := NewAdminService(userRepository, urlRepository, refreshTokenRepository, tokenRevocationCache, domainLogger, urlConfig)
+	manager := cookie.NewCookieManager(authConfig)
+	limiter := ratelimit.NewMemoryLimiter()
+	securityConfig := ProvideSecurityConfig()
+	handlerHandler := handler.New(userService, urlService, qrCodeService, apiKeyService, domainService, workspaceService, adminService, manager, limiter, domainLogger, tokenGenerator, securityConfig)
+	redirectFlusher := worker.NewRedirectFlusher(redirectCounter, urlRepository, domainLogger, analyticsConfig)
+	trashConfig := ProvideTrashConfig()
+	trashPurger := worker.NewTrashPurger(urlRepository, domainLogger, trashConfig)
+	urlRescanner := worker.NewURLRescanner(urlRepository, urlCache, blocklistChecker, domainLogger, urlSafetyConfig)
+	application := &Application{
+		Handler:         handlerHandler,
+		ClickRecorder:   clickRecorder,
+		RedirectFlusher: redirectFlusher,
+		TrashPurger:     trashPurger,
+		URLRescanner:    urlRescanner,
+		SafetyChecker:   blocklistChecker,
+	}
+	return application, nil
+}
+
 func InitializeApplication(domainLogger logger.Logger) (*Application, error) {
 	userValidator := service.NewValidator()
 	passwordHasher := service.NewHasher()
 	databaseConfig := ProvideDatabaseConfig()
 	store := postgres.NewPostgresClient(domainLogger, databaseConfig)
 	userRepository := postgres.NewUserRepository(store, domainLogger)
+	refreshTokenRepository := postgres.NewRefreshTokenRepository(store, domainLogger)
+	redisConfig := ProvideRedisConfig()
+	client := NewRedisClient(domainLogger, redisConfig)
+	tokenRevocationCache := redis.NewTokenRevocationCache(client, domainLogger)
+	workspaceRepository := postgres.NewWorkspaceRepository(store, domainLogger)
 	authConfig := ProvideAuthConfig()
 	tokenGenerator := auth.NewJwtTokenGenerator(domainLogger, authConfig)
-	userService := service2.NewUserService(userValidator, passwordHasher, userRepository, tokenGenerator, domainLogger)
+	userService := service2.NewUserService(userValidator, passwordHasher, userRepository, refreshTokenRepository, tokenRevocationCache, workspaceRepository, tokenGenerator, domainLogger)
 	urlConfig := ProvideURLConfig()
 	shortCodeGenerator := NewGenerator(urlConfig)
-	urlValidator := service3.NewValidator()
+	destinationConfig := ProvideDestinationConfig()
+	urlValidator := NewURLValidator(destinationConfig)
+	urlSafetyConfig := ProvideURLSafetyConfig()
+	blocklistChecker, err := safety.NewBlocklistChecker(domainLogger, urlSafetyConfig)
+	if err != nil {
+		return nil, err
+	}
+	resolver := NewShortenerResolver(domainLogger, destinationConfig)
 	urlRepository := postgres.NewURLRepository(store, domainLogger)
-	redisConfig := ProvideRedisConfig()
-	client := NewRedisClient(domainLogger, redisConfig)
+	clickRepository := postgres.NewClickRepository(store, domainLogger)
+	domainRepository := postgres.NewDomainRepository(store, domainLogger)
+	analyticsConfig := ProvideAnalyticsConfig()
+	clickRecorder := worker.NewClickRecorder(clickRepository, domainLogger, analyticsConfig)
 	urlCache := redis.NewURLCache(client, domainLogger)
-	urlService := NewURLService(shortCodeGenerator, urlValidator, urlRepository, urlCache, domainLogger, urlConfig)
+	redirectCounter := redis.NewRedirectCounter(client, domainLogger)
+	linkUnlockTokenGenerator := auth.NewLinkUnlockTokenGenerator(domainLogger, authConfig)
+	urlService := NewURLService(shortCodeGenerator, urlValidator, blocklistChecker, resolver, urlRepository, clickRepository, domainRepository, workspaceRepository, clickRecorder, urlCache, redirectCounter, passwordHasher, linkUnlockTokenGenerator, domainLogger, urlConfig, destinationConfig)
+	renderer := qrcode.NewRenderer()
+	qrCodeCache := redis.NewQRCodeCache(client, domainLogger)
+	qrCodeService := NewQRCodeService(urlRepository, workspaceRepository, renderer, qrCodeCache, domainLogger, urlConfig)
+	apiKeyGenerator := auth.NewAPIKeyGenerator()
+	apiKeyRepository := postgres.NewAPIKeyRepository(store, domainLogger)
+	apiKeyService := service2.NewAPIKeyService(apiKeyGenerator, apiKeyRepository, userRepository, domainLogger)
+	txtVerifier := NewDomainVerifier()
+	domainService := service2.NewDomainService(domainRepository, urlRepository, txtVerifier, urlValidator, domainLogger)
+	workspaceService := service2.NewWorkspaceService(workspaceRepository, userRepository, userValidator, domainLogger)
+	adminService := NewAdminService(userRepository, urlRepository, refreshTokenRepository, tokenRevocationCache, domainLogger, urlConfig)
 	manager := cookie.NewCookieManager(authConfig)
-	handlerHandler := handler.New(userService, urlService, manager, domainLogger, authConfig)
+	limiter := ratelimit.NewRedisLimiter(client, domainLogger)
+	securityConfig := ProvideSecurityConfig()
+	handlerHandler := handler.New(userService, urlService, qrCodeService, apiKeyService, domainService, workspaceService, adminService, manager, limiter, domainLogger, tokenGenerator, securityConfig)
+	redirectFlusher := worker.NewRedirectFlusher(redirectCounter, urlRepository, domainLogger, analyticsConfig)
+	trashConfig := ProvideTrashConfig()
+	trashPurger := worker.NewTrashPurger(urlRepository, domainLogger, trashConfig)
+	urlRescanner := worker.NewURLRescanner(urlRepository, urlCache, blocklistChecker, domainLogger, urlSafetyConfig)
+	migrator, err := NewMigrator(store, domainLogger)
+	if err != nil {
+		return nil, err
+	}
 	application := &Application{
-		Handler:        handlerHandler,
-		PostgresClient: store,
-		RedisClient:    client,
+		Handler:         handlerHandler,
+		PostgresClient:  store,
+		RedisClient:     client,
+		ClickRecorder:   clickRecorder,
+		RedirectFlusher: redirectFlusher,
+		TrashPurger:     trashPurger,
+		URLRescanner:    urlRescanner,
+		SafetyChecker:   blocklistChecker,
+		Migrator:        migrator,
 	}
 	return application, nil
 }
 
+func InitializeMigration(domainLogger logger.Logger) (*Migration, error) {
+	databaseConfig := ProvideDatabaseConfig()
+	store := postgres.NewPostgresClient(domainLogger, databaseConfig)
+	migrator, err := NewMigrator(store, domainLogger)
+	if err != nil {
+		return nil, err
+	}
+	migration := &Migration{
+		PostgresClient: store,
+		Migrator:       migrator,
+	}
+	return migration, nil
+}
+
 // injectors.go:
 
+// Application holds the components main starts and stops. The Postgres and Redis
+// clients and the migrator are nil when running with in-memory storage.
 type Application struct {
-	Handler        *handler.Handler
+	Handler         *handler.Handler
+	PostgresClient  postgres.Store
+	RedisClient     redis.Client
+	ClickRecorder   *worker.ClickRecorder
+	RedirectFlusher *worker.RedirectFlusher
+	TrashPurger     *worker.TrashPurger
+	URLRescanner    *worker.URLRescanner
+	SafetyChecker   *safety.BlocklistChecker
+	Migrator        *postgres.Migrator
+}
+
+// Migration holds what the migrate command needs, without Redis or the HTTP layer
+type Migration struct {
 	PostgresClient postgres.Store
-	RedisClient    redis.Client
+	Migrator       *postgres.Migrator
 }
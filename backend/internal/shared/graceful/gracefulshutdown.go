@@ -32,11 +32,26 @@ type (
 	ServerOperation func() error
 )
 
+// GracefulShutdown runs the server until it fails or a termination signal is received,
+// then executes all shutdown operations concurrently
 func GracefulShutdown(
 	serverOp ServerOperation,
 	shutdownTimeout time.Duration,
 	operations map[string]Operation,
 	log logger.Logger,
+) {
+	GracefulShutdownInStages(serverOp, shutdownTimeout, []map[string]Operation{operations}, log)
+}
+
+// GracefulShutdownInStages behaves like GracefulShutdown but executes the shutdown
+// operations stage by stage. Operations within a stage run concurrently and all of
+// them finish before the next stage starts, so components that produce work can be
+// stopped before the ones that consume it.
+func GracefulShutdownInStages(
+	serverOp ServerOperation,
+	shutdownTimeout time.Duration,
+	stages []map[string]Operation,
+	log logger.Logger,
 ) {
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
@@ -69,23 +84,31 @@ func GracefulShutdown(
 	})
 	defer timeAfterExecuted.Stop()
 
-	if len(operations) > 0 {
-		log.Info(context.Background(), "Executing shutdown operations...")
-		wg := sync.WaitGroup{}
-		wg.Add(len(operations))
-		for k, op := range operations {
-			go func(k string, op Operation) {
-				defer wg.Done()
-				log.Info(ctx, "Shutting down component", logger.String("component", k))
-				if err := op(ctx); err != nil {
-					log.Error(ctx, "Error shutting down component",
-						logger.String("component", k),
-						logger.Error(err))
-				}
-			}(k, op)
-		}
-		wg.Wait()
+	for _, operations := range stages {
+		runOperations(ctx, operations, log)
 	}
 
 	log.Info(context.Background(), "Graceful shutdown completed")
 }
+
+func runOperations(ctx context.Context, operations map[string]Operation, log logger.Logger) {
+	if len(operations) == 0 {
+		return
+	}
+
+	log.Info(context.Background(), "Executing shutdown operations...")
+	wg := sync.WaitGroup{}
+	wg.Add(len(operations))
+	for k, op := range operations {
+		go func(k string, op Operation) {
+			defer wg.Done()
+			log.Info(ctx, "Shutting down component", logger.String("component", k))
+			if err := op(ctx); err != nil {
+				log.Error(ctx, "Error shutting down component",
+					logger.String("component", k),
+					logger.Error(err))
+			}
+		}(k, op)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,5 @@
+-- Custom aliases longer than generated short codes cannot be kept, so rolling back
+-- permanently deletes the URLs using them
+DELETE FROM url WHERE length("short_url") > 7;
+
+ALTER TABLE url ALTER COLUMN "short_url" TYPE varchar(7);
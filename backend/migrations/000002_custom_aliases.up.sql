@@ -0,0 +1 @@
+ALTER TABLE url ALTER COLUMN "short_url" TYPE varchar(32);
@@ -0,0 +1,3 @@
+ALTER TABLE url
+    DROP COLUMN IF EXISTS "max_clicks",
+    DROP COLUMN IF EXISTS "expires_at";
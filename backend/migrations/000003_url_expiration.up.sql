@@ -0,0 +1,3 @@
+ALTER TABLE url
+    ADD COLUMN IF NOT EXISTS "expires_at" timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS "max_clicks" INT CHECK ("max_clicks" > 0);
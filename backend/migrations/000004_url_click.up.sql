@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS url_click (
+    "id" BIGSERIAL PRIMARY KEY,
+    "url_id" character(36) NOT NULL REFERENCES url(id) ON DELETE CASCADE,
+    "clicked_at" timestamp with time zone NOT NULL,
+    "referrer" TEXT,
+    "user_agent" TEXT,
+    "ip_address" TEXT,
+    "accept_language" TEXT
+);
+
+CREATE INDEX IF NOT EXISTS url_click_url_id_clicked_at_idx ON url_click ("url_id", "clicked_at");
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS refresh_token (
+    "id" character(36) PRIMARY KEY,
+    "user_id" character(36) NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    "family_id" character(36) NOT NULL,
+    "token_hash" character(64) NOT NULL,
+    "expires_at" timestamp with time zone NOT NULL,
+    "revoked_at" timestamp with time zone,
+    "created_at" timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS refresh_token_token_hash_idx ON refresh_token ("token_hash");
+CREATE INDEX IF NOT EXISTS refresh_token_family_id_idx ON refresh_token ("family_id");
+CREATE INDEX IF NOT EXISTS refresh_token_user_id_idx ON refresh_token ("user_id");
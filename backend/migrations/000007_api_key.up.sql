@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS api_key (
+    "id" character(36) PRIMARY KEY,
+    "user_id" character(36) NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    "name" TEXT NOT NULL,
+    "prefix" character varying(16) NOT NULL,
+    "key_hash" character(64) NOT NULL,
+    "scopes" TEXT[] NOT NULL,
+    "expires_at" timestamp with time zone,
+    "last_used_at" timestamp with time zone,
+    "created_at" timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS api_key_prefix_idx ON api_key ("prefix");
+CREATE INDEX IF NOT EXISTS api_key_user_id_idx ON api_key ("user_id");
@@ -0,0 +1,3 @@
+ALTER TABLE url
+    DROP COLUMN IF EXISTS "forward_query",
+    DROP COLUMN IF EXISTS "redirect_type";
@@ -0,0 +1,3 @@
+ALTER TABLE url
+    ADD COLUMN IF NOT EXISTS "redirect_type" SMALLINT NOT NULL DEFAULT 302 CHECK ("redirect_type" IN (301, 302, 307, 308)),
+    ADD COLUMN IF NOT EXISTS "forward_query" BOOLEAN NOT NULL DEFAULT FALSE;
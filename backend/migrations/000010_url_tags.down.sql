@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS url_title_trgm_idx;
+DROP INDEX IF EXISTS url_long_url_trgm_idx;
+DROP INDEX IF EXISTS url_short_url_trgm_idx;
+DROP INDEX IF EXISTS url_user_id_created_at_idx;
+
+DROP TABLE IF EXISTS url_tag;
+DROP TABLE IF EXISTS tag;
+
+ALTER TABLE url
+    DROP COLUMN IF EXISTS "description",
+    DROP COLUMN IF EXISTS "title";
@@ -0,0 +1,27 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE url
+    ADD COLUMN IF NOT EXISTS "title" TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS "description" TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS tag (
+    "id" BIGSERIAL PRIMARY KEY,
+    "user_id" character(36) NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    "name" TEXT NOT NULL,
+    "created_at" timestamp with time zone NOT NULL DEFAULT NOW(),
+    UNIQUE ("user_id", "name")
+);
+
+CREATE TABLE IF NOT EXISTS url_tag (
+    "url_id" character(36) NOT NULL REFERENCES url(id) ON DELETE CASCADE,
+    "tag_id" BIGINT NOT NULL REFERENCES tag(id) ON DELETE CASCADE,
+    PRIMARY KEY ("url_id", "tag_id")
+);
+
+CREATE INDEX IF NOT EXISTS url_tag_tag_id_idx ON url_tag ("tag_id");
+
+-- Listing filters by owner and creation time, and searches substrings with trigrams
+CREATE INDEX IF NOT EXISTS url_user_id_created_at_idx ON url ("user_id", "created_at");
+CREATE INDEX IF NOT EXISTS url_short_url_trgm_idx ON url USING GIN ("short_url" gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS url_long_url_trgm_idx ON url USING GIN ("long_url" gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS url_title_trgm_idx ON url USING GIN ("title" gin_trgm_ops);
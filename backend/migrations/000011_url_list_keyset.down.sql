@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS url_user_id_name_id_idx;
+DROP INDEX IF EXISTS url_user_id_redirects_id_idx;
+DROP INDEX IF EXISTS url_user_id_updated_at_id_idx;
+DROP INDEX IF EXISTS url_user_id_created_at_id_idx;
+
+CREATE INDEX IF NOT EXISTS url_user_id_created_at_idx ON url ("user_id", "created_at");
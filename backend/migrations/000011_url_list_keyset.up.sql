@@ -0,0 +1,7 @@
+-- Keyset pagination of URL listings seeks into one index per sort order
+DROP INDEX IF EXISTS url_user_id_created_at_idx;
+
+CREATE INDEX IF NOT EXISTS url_user_id_created_at_id_idx ON url ("user_id", "created_at", "id");
+CREATE INDEX IF NOT EXISTS url_user_id_updated_at_id_idx ON url ("user_id", (COALESCE("updated_at", "created_at")), "id");
+CREATE INDEX IF NOT EXISTS url_user_id_redirects_id_idx ON url ("user_id", "redirects", "id");
+CREATE INDEX IF NOT EXISTS url_user_id_name_id_idx ON url ("user_id", (LOWER(COALESCE(NULLIF("title", ''), "short_url")) COLLATE "C"), "id");
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS url_deleted_at_idx;
+
+-- URLs in the trash would otherwise become active again
+DELETE FROM url WHERE "deleted_at" IS NOT NULL;
+
+ALTER TABLE url
+    DROP COLUMN IF EXISTS "deleted_at";
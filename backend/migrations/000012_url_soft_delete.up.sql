@@ -0,0 +1,5 @@
+ALTER TABLE url
+    ADD COLUMN IF NOT EXISTS "deleted_at" timestamp with time zone;
+
+-- The purger looks up URLs in the trash by deletion time
+CREATE INDEX IF NOT EXISTS url_deleted_at_idx ON url ("deleted_at") WHERE "deleted_at" IS NOT NULL;
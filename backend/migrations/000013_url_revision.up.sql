@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS url_revision (
+    "id" BIGSERIAL PRIMARY KEY,
+    "url_id" character(36) NOT NULL REFERENCES url(id) ON DELETE CASCADE,
+    "old_url" TEXT NOT NULL,
+    "new_url" TEXT NOT NULL,
+    -- Revisions outlive the account of whoever made them
+    "actor_id" character(36) REFERENCES "user"(id) ON DELETE SET NULL,
+    "created_at" timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS url_revision_url_id_id_idx ON url_revision ("url_id", "id");
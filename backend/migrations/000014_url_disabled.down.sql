@@ -0,0 +1,4 @@
+ALTER TABLE url
+    DROP COLUMN IF EXISTS "disabled_reason";
+ALTER TABLE url
+    DROP COLUMN IF EXISTS "disabled_at";
@@ -0,0 +1,4 @@
+ALTER TABLE url
+    ADD COLUMN IF NOT EXISTS "disabled_at" timestamp with time zone;
+ALTER TABLE url
+    ADD COLUMN IF NOT EXISTS "disabled_reason" TEXT;
@@ -0,0 +1,10 @@
+-- Short codes of custom domains may clash with those of the default domain, so their
+-- URLs cannot be kept once short codes are unique again
+DELETE FROM url WHERE "domain" <> '';
+
+ALTER TABLE url DROP CONSTRAINT IF EXISTS url_domain_short_url_key;
+ALTER TABLE url ADD CONSTRAINT url_short_url_key UNIQUE ("short_url");
+ALTER TABLE url
+    DROP COLUMN IF EXISTS "domain";
+
+DROP TABLE IF EXISTS "domain";
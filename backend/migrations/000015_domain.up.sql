@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS "domain" (
+    "id" character(36) PRIMARY KEY,
+    "user_id" character(36) NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    "hostname" character varying(253) NOT NULL,
+    "verification_token" TEXT NOT NULL,
+    "verified_at" timestamp with time zone,
+    "created_at" timestamp with time zone NOT NULL DEFAULT NOW(),
+    UNIQUE ("user_id", "hostname")
+);
+
+-- Any user may add a hostname, but only one of them can verify it
+CREATE UNIQUE INDEX IF NOT EXISTS domain_verified_hostname_idx ON "domain" ("hostname") WHERE "verified_at" IS NOT NULL;
+
+-- Short codes are unique per domain, the empty domain being the default one
+ALTER TABLE url
+    ADD COLUMN IF NOT EXISTS "domain" character varying(253) NOT NULL DEFAULT '';
+ALTER TABLE url DROP CONSTRAINT IF EXISTS url_short_url_key;
+ALTER TABLE url ADD CONSTRAINT url_domain_short_url_key UNIQUE ("domain", "short_url");
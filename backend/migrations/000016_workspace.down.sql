@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS url_workspace_id_created_at_id_idx;
+DROP INDEX IF EXISTS url_workspace_id_updated_at_id_idx;
+DROP INDEX IF EXISTS url_workspace_id_redirects_id_idx;
+DROP INDEX IF EXISTS url_workspace_id_name_id_idx;
+
+CREATE INDEX IF NOT EXISTS url_user_id_created_at_id_idx ON url ("user_id", "created_at", "id");
+CREATE INDEX IF NOT EXISTS url_user_id_updated_at_id_idx ON url ("user_id", (COALESCE("updated_at", "created_at")), "id");
+CREATE INDEX IF NOT EXISTS url_user_id_redirects_id_idx ON url ("user_id", "redirects", "id");
+CREATE INDEX IF NOT EXISTS url_user_id_name_id_idx ON url ("user_id", (LOWER(COALESCE(NULLIF("title", ''), "short_url")) COLLATE "C"), "id");
+
+-- URLs go back to being owned by the user who created them
+ALTER TABLE url
+    DROP COLUMN IF EXISTS "workspace_id";
+
+DROP TABLE IF EXISTS workspace_invitation;
+DROP TABLE IF EXISTS workspace_member;
+DROP TABLE IF EXISTS workspace;
@@ -0,0 +1,61 @@
+CREATE TABLE IF NOT EXISTS workspace (
+    "id" character(36) PRIMARY KEY,
+    "name" character varying(100) NOT NULL,
+    -- Set for the personal workspace of a user, which nobody else can join
+    "personal_user_id" character(36) UNIQUE REFERENCES "user"(id) ON DELETE CASCADE,
+    "created_at" timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS workspace_member (
+    "workspace_id" character(36) NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+    "user_id" character(36) NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    "role" character varying(16) NOT NULL CHECK ("role" IN ('owner', 'admin', 'editor', 'viewer')),
+    "created_at" timestamp with time zone NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("workspace_id", "user_id")
+);
+
+CREATE INDEX IF NOT EXISTS workspace_member_user_id_idx ON workspace_member ("user_id");
+
+CREATE TABLE IF NOT EXISTS workspace_invitation (
+    "id" character(36) PRIMARY KEY,
+    "workspace_id" character(36) NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+    "email" character varying(255) NOT NULL,
+    "role" character varying(16) NOT NULL CHECK ("role" IN ('owner', 'admin', 'editor', 'viewer')),
+    "token_hash" character(64) NOT NULL,
+    "invited_by" character(36) NOT NULL REFERENCES "user"(id) ON DELETE CASCADE,
+    "expires_at" timestamp with time zone NOT NULL,
+    "created_at" timestamp with time zone NOT NULL DEFAULT NOW(),
+    UNIQUE ("workspace_id", "email")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS workspace_invitation_token_hash_idx ON workspace_invitation ("token_hash");
+
+-- Every existing user gets a personal workspace owning their URLs
+INSERT INTO workspace ("id", "name", "personal_user_id", "created_at")
+SELECT gen_random_uuid()::text, 'Personal', u.id, u.created_at
+FROM "user" u
+WHERE NOT EXISTS (SELECT 1 FROM workspace w WHERE w.personal_user_id = u.id);
+
+INSERT INTO workspace_member ("workspace_id", "user_id", "role", "created_at")
+SELECT w.id, w.personal_user_id, 'owner', w.created_at
+FROM workspace w
+WHERE w.personal_user_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE url
+    ADD COLUMN IF NOT EXISTS "workspace_id" character(36) REFERENCES workspace(id) ON DELETE CASCADE;
+UPDATE url SET "workspace_id" = w.id
+FROM workspace w
+WHERE w.personal_user_id = url.user_id AND url.workspace_id IS NULL;
+ALTER TABLE url ALTER COLUMN "workspace_id" SET NOT NULL;
+
+-- URL listings are scoped to a workspace instead of their creator
+DROP INDEX IF EXISTS url_user_id_created_at_id_idx;
+DROP INDEX IF EXISTS url_user_id_updated_at_id_idx;
+DROP INDEX IF EXISTS url_user_id_redirects_id_idx;
+DROP INDEX IF EXISTS url_user_id_name_id_idx;
+
+CREATE INDEX IF NOT EXISTS url_workspace_id_created_at_id_idx ON url ("workspace_id", "created_at", "id");
+CREATE INDEX IF NOT EXISTS url_workspace_id_updated_at_id_idx ON url ("workspace_id", (COALESCE("updated_at", "created_at")), "id");
+CREATE INDEX IF NOT EXISTS url_workspace_id_redirects_id_idx ON url ("workspace_id", "redirects", "id");
+CREATE INDEX IF NOT EXISTS url_workspace_id_name_id_idx ON url ("workspace_id", (LOWER(COALESCE(NULLIF("title", ''), "short_url")) COLLATE "C"), "id");
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS user_name_trgm_idx;
+DROP INDEX IF EXISTS user_email_trgm_idx;
+DROP INDEX IF EXISTS url_created_at_id_idx;
+DROP INDEX IF EXISTS user_created_at_id_idx;
+
+ALTER TABLE "user"
+    DROP COLUMN IF EXISTS "suspended_at",
+    DROP COLUMN IF EXISTS "role";
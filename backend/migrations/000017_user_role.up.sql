@@ -0,0 +1,11 @@
+ALTER TABLE "user"
+    ADD COLUMN IF NOT EXISTS "role" character varying(16) NOT NULL DEFAULT 'user' CHECK ("role" IN ('user', 'admin')),
+    ADD COLUMN IF NOT EXISTS "suspended_at" timestamp with time zone;
+
+-- Administrators list users newest first and links across every workspace
+CREATE INDEX IF NOT EXISTS user_created_at_id_idx ON "user" ("created_at", "id");
+CREATE INDEX IF NOT EXISTS url_created_at_id_idx ON url ("created_at", "id");
+
+-- Substring searches of users are served by trigram indexes, like those of URLs
+CREATE INDEX IF NOT EXISTS user_email_trgm_idx ON "user" USING GIN ("email" gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS user_name_trgm_idx ON "user" USING GIN ("name" gin_trgm_ops);
@@ -47,7 +47,7 @@ export function DeleteDialog({ link, onClose }: DeleteDialogProps) {
       open={link !== null}
       onOpenChange={(open) => !open && onClose()}
       title="Delete link?"
-      description="This can't be undone. The short link will stop working immediately."
+      description="The short link will stop working immediately and move to the trash."
     >
       {link && (
         <p className="mb-5 truncate rounded-lg border border-border bg-elevated px-3 py-2 font-mono text-sm text-muted">
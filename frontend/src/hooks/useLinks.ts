@@ -26,14 +26,14 @@ import type { CreateUrlRequest, UrlUpdateRequest } from "@/types";
 
 export const PAGE_SIZE = 9;
 
-export function useLinks(offset: number) {
+export function useLinks(cursor: string) {
   return useQuery({
-    queryKey: ["links", offset],
+    queryKey: ["links", cursor],
     queryFn: async () => {
-      const rows = (await urlApi.list(PAGE_SIZE + 1, offset)) ?? [];
+      const page = await urlApi.list(PAGE_SIZE, cursor);
       return {
-        items: rows.slice(0, PAGE_SIZE),
-        hasNext: rows.length > PAGE_SIZE,
+        items: page?.items ?? [],
+        nextCursor: page?.next_cursor ?? "",
       };
     },
     placeholderData: keepPreviousData,
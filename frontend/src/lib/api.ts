@@ -21,7 +21,7 @@ import type {
   LoginRequest,
   RegisterRequest,
   TokenResponse,
-  UrlResponse,
+  UrlPage,
   UrlUpdateRequest,
 } from "@/types";
 
@@ -100,15 +100,17 @@ export const authApi = {
   register: (data: RegisterRequest) =>
     request<TokenResponse>("/user/register", { method: "POST", body: data }),
 
-  logout: () => request<null>("/user/logout", { method: "GET" }),
+  logout: () => request<null>("/user/logout", { method: "POST" }),
 };
 
 export const urlApi = {
   create: (data: CreateUrlRequest) =>
     request<CreateUrlResponse>("/url/create", { method: "POST", body: data }),
 
-  list: (limit: number, offset: number) =>
-    request<UrlResponse[] | null>(`/urls?limit=${limit}&offset=${offset}`),
+  list: (limit: number, cursor: string) =>
+    request<UrlPage>(
+      `/urls?limit=${limit}${cursor ? `&cursor=${encodeURIComponent(cursor)}` : ""}`,
+    ),
 
   update: (data: UrlUpdateRequest) =>
     request<null>("/url/update", { method: "PATCH", body: data }),
@@ -26,22 +26,24 @@ import { EditDialog } from "@/components/EditDialog";
 import { DeleteDialog } from "@/components/DeleteDialog";
 import { Button } from "@/components/ui/button";
 import { Spinner } from "@/components/ui/spinner";
-import { PAGE_SIZE, useLinks } from "@/hooks/useLinks";
+import { useLinks } from "@/hooks/useLinks";
 import type { UrlResponse } from "@/types";
 
 export function Dashboard() {
-  const [page, setPage] = useState(0);
+  // Cursors of the pages visited so far; the first page has none
+  const [cursors, setCursors] = useState<string[]>([""]);
+  const page = cursors.length - 1;
   const [qrCode, setQrCode] = useState<string | null>(null);
   const [editLink, setEditLink] = useState<UrlResponse | null>(null);
   const [deleteLink, setDeleteLink] = useState<UrlResponse | null>(null);
 
-  const { data, isLoading, isError, isFetching, refetch } = useLinks(page * PAGE_SIZE);
+  const { data, isLoading, isError, isFetching, refetch } = useLinks(cursors[page]);
   const items = data?.items ?? [];
   const maxRedirects = items.reduce((m, l) => Math.max(m, l.redirects), 0);
 
   useEffect(() => {
     if (!isLoading && !isError && page > 0 && items.length === 0) {
-      setPage((p) => Math.max(0, p - 1));
+      setCursors((c) => c.slice(0, -1));
     }
   }, [isLoading, isError, page, items.length]);
 
@@ -92,10 +94,13 @@ export function Dashboard() {
               <div className="border-t border-border">
                 <Pagination
                   page={page}
-                  hasNext={data?.hasNext ?? false}
+                  hasNext={Boolean(data?.nextCursor)}
                   disabled={isFetching}
-                  onPrev={() => setPage((p) => Math.max(0, p - 1))}
-                  onNext={() => setPage((p) => p + 1)}
+                  onPrev={() => setCursors((c) => (c.length > 1 ? c.slice(0, -1) : c))}
+                  onNext={() => {
+                    const next = data?.nextCursor;
+                    if (next) setCursors((c) => [...c, next]);
+                  }}
                 />
               </div>
             </>
@@ -51,6 +51,12 @@ export interface UrlResponse {
   redirects: number;
 }
 
+export interface UrlPage {
+  items: UrlResponse[];
+  next_cursor?: string;
+  total: number;
+}
+
 export interface UrlUpdateRequest {
   id: string;
   new_url: string;